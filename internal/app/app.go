@@ -9,32 +9,72 @@ import (
 	"os/signal"
 	"syscall"
 
+	"event-booker/internal/auth"
 	"event-booker/internal/config"
 	"event-booker/internal/http-server/handler/booking"
 	"event-booker/internal/http-server/handler/event"
+	organizer_handler "event-booker/internal/http-server/handler/organizer"
+	outbox_handler "event-booker/internal/http-server/handler/outbox"
+	payment_handler "event-booker/internal/http-server/handler/payment"
+	promo_handler "event-booker/internal/http-server/handler/promo"
+	seat_handler "event-booker/internal/http-server/handler/seat"
+	"event-booker/internal/http-server/handler/stream"
 	"event-booker/internal/http-server/handler/user"
+	venue_handler "event-booker/internal/http-server/handler/venue"
+	"event-booker/internal/http-server/handler/waitlist"
+	webhook_handler "event-booker/internal/http-server/handler/webhook"
 	"event-booker/internal/http-server/router"
+	"event-booker/internal/metrics"
+	"event-booker/internal/notification"
 	"event-booker/internal/notification/composite"
 	"event-booker/internal/notification/email"
+	"event-booker/internal/notification/outbox"
 	"event-booker/internal/notification/telegram"
+	"event-booker/internal/notification/webhook"
+	"event-booker/internal/payment/fake"
+	"event-booker/internal/realtime"
 	booking_repo "event-booker/internal/repository/booking/postgres"
 	event_repo "event-booker/internal/repository/event/postgres"
+	idempotency_repo "event-booker/internal/repository/idempotency/postgres"
+	organizer_repo "event-booker/internal/repository/organizer/postgres"
+	outbox_repo "event-booker/internal/repository/outbox/postgres"
+	payment_repo "event-booker/internal/repository/payment/postgres"
+	promo_repo "event-booker/internal/repository/promo/postgres"
+	refund_repo "event-booker/internal/repository/refund/postgres"
+	reminder_repo "event-booker/internal/repository/reminder/postgres"
+	seat_repo "event-booker/internal/repository/seat/postgres"
 	user_repo "event-booker/internal/repository/user/postgres"
+	venue_repo "event-booker/internal/repository/venue/postgres"
+	waitlist_repo "event-booker/internal/repository/waitlist/postgres"
+	webhook_repo "event-booker/internal/repository/webhook/postgres"
 	"event-booker/internal/scheduler"
 	booking_uc "event-booker/internal/usecase/booking"
 	event_uc "event-booker/internal/usecase/event"
+	idempotency_uc "event-booker/internal/usecase/idempotency"
+	organizer_uc "event-booker/internal/usecase/organizer"
+	outbox_uc "event-booker/internal/usecase/outbox"
+	payment_uc "event-booker/internal/usecase/payment"
+	promo_uc "event-booker/internal/usecase/promo"
+	reminder_uc "event-booker/internal/usecase/reminder"
+	seat_uc "event-booker/internal/usecase/seat"
 	user_uc "event-booker/internal/usecase/user"
+	venue_uc "event-booker/internal/usecase/venue"
+	waitlist_uc "event-booker/internal/usecase/waitlist"
+	webhook_uc "event-booker/internal/usecase/webhook"
 
 	"github.com/wb-go/wbf/dbpg"
 	"github.com/wb-go/wbf/zlog"
 )
 
 type App struct {
-	cfg       *config.Config
-	server    *http.Server
-	logger    *zlog.Zerolog
-	db        *dbpg.DB
-	scheduler *scheduler.Scheduler
+	cfg        *config.Config
+	server     *http.Server
+	logger     *zlog.Zerolog
+	db         *dbpg.DB
+	scheduler  *scheduler.Scheduler
+	dispatcher *outbox.Dispatcher
+	deliverer  *webhook.Deliverer
+	broker     *realtime.Broker
 }
 
 func NewApp(cfg *config.Config, logger *zlog.Zerolog) (*App, error) {
@@ -52,26 +92,77 @@ func NewApp(cfg *config.Config, logger *zlog.Zerolog) (*App, error) {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
 
-	emailNotifier := email.NewNotifier(cfg)
+	if err := metrics.RegisterDB(db.Master, cfg.DB.DBName); err != nil {
+		return nil, fmt.Errorf("failed to register database metrics: %w", err)
+	}
+
+	emailNotifier, err := email.NewNotifier(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load email templates: %w", err)
+	}
 	telegramNotifier := telegram.NewNotifier(cfg.TelegramConfig.BotToken)
-	compositeNotifier := composite.NewCompositeNotifier(emailNotifier, telegramNotifier)
+	webhookRepo := webhook_repo.NewWebhookRepository(db, retries)
+	channels := notification.NewRegistry(emailNotifier, telegramNotifier, webhook.NewChannel(webhookRepo))
+	compositeNotifier := composite.NewCompositeNotifier(channels)
+	publisher := webhook.NewPublisher(webhookRepo)
+	deliverer := webhook.NewDeliverer(webhookRepo, cfg, logger)
 
 	bookingRepo := booking_repo.NewBookingRepository(db, retries)
 	eventRepo := event_repo.NewEventRepository(db, retries)
 	userRepo := user_repo.NewUserRepository(db, retries)
+	waitlistRepo := waitlist_repo.NewWaitlistRepository(db, retries)
+	outboxRepo := outbox_repo.NewOutboxRepository(db, retries)
+	paymentRepo := payment_repo.NewPaymentRepository(db, retries)
+	refundRepo := refund_repo.NewRefundRepository(db, retries)
+	idempotencyRepo := idempotency_repo.NewIdempotencyRepository(db, retries)
+	reminderRepo := reminder_repo.NewReminderRepository(db, retries)
+	seatRepo := seat_repo.NewSeatRepository(db, retries)
+	promoRepo := promo_repo.NewPromoRepository(db, retries)
+	venueRepo := venue_repo.NewVenueRepository(db, retries)
+	organizerRepo := organizer_repo.NewOrganizerRepository(db, retries)
+
+	fakeGateway := fake.NewProvider(cfg.Payment.WebhookSecret, cfg.Payment.CheckoutBaseURL, cfg.Payment.WebhookURL)
+
+	waitlistUsecase := waitlist_uc.NewWaitlistUsecase(waitlistRepo, eventRepo, bookingRepo, outboxRepo, publisher, cfg, logger)
+	paymentUsecase := payment_uc.NewPaymentUsecase(db, fakeGateway, paymentRepo, refundRepo, bookingRepo, eventRepo, outboxRepo, publisher, logger)
+	promoUsecase := promo_uc.NewPromoUsecase(promoRepo, eventRepo, logger)
+	bookingUsecase := booking_uc.NewBookingUsecase(db, bookingRepo, eventRepo, seatRepo, waitlistUsecase, paymentUsecase, promoUsecase, outboxRepo, publisher, cfg, logger)
+	eventUsecase := event_uc.NewEventUsecase(db, eventRepo, bookingRepo, seatRepo, venueRepo, organizerRepo, reminderRepo, waitlistUsecase, paymentUsecase, promoUsecase, outboxRepo, publisher, logger)
+	outboxUsecase := outbox_uc.NewOutboxUsecase(outboxRepo, logger)
+	idempotencyUsecase := idempotency_uc.NewIdempotencyUsecase(idempotencyRepo, cfg.Idempotency.TTL, cfg.Idempotency.Lease, logger)
+	seatUsecase := seat_uc.NewSeatUsecase(db, seatRepo, eventRepo, logger)
+	venueUsecase := venue_uc.NewVenueUsecase(db, venueRepo, logger)
+	organizerUsecase := organizer_uc.NewOrganizerUsecase(db, organizerRepo, logger)
+	webhookUsecase := webhook_uc.NewWebhookUsecase(webhookRepo, deliverer, logger)
+	reminderUsecase := reminder_uc.NewReminderUsecase(db, reminderRepo, outboxRepo, cfg.Reminders.Leads, cfg.Reminders.PaymentLead, logger)
+	tokenManager := auth.NewTokenManager(cfg.Auth.JWTSecret, cfg.Auth.TokenTTL)
+	userUsecase := user_uc.NewUserUsecase(userRepo, tokenManager)
+	if cfg.Auth.AdminEmail != "" && cfg.Auth.AdminPassword != "" {
+		if err := userUsecase.EnsureAdmin(context.Background(), cfg.Auth.AdminEmail, cfg.Auth.AdminPassword); err != nil {
+			return nil, fmt.Errorf("failed to bootstrap admin user: %w", err)
+		}
+	}
 
-	bookingUsecase := booking_uc.NewBookingUsecase(db, bookingRepo, eventRepo, userRepo, compositeNotifier, cfg, logger)
-	eventUsecase := event_uc.NewEventUsecase(db, eventRepo, bookingRepo, userRepo, compositeNotifier, logger)
-	userUsecase := user_uc.NewUserUsecase(userRepo)
-
-	sch := scheduler.NewScheduler(bookingUsecase, cfg, logger)
+	sch := scheduler.NewScheduler(bookingUsecase, eventUsecase, paymentUsecase, reminderUsecase, idempotencyUsecase, cfg, logger)
+	dispatcher := outbox.NewDispatcher(outboxRepo, userRepo, compositeNotifier, cfg, logger)
+	broker := realtime.NewBroker(cfg.DBDSN(), cfg, logger)
 
 	h := &router.Handler{
-		EventHandler:   event.NewEventHandler(eventUsecase, logger),
-		BookingHandler: booking.NewBookingHandler(bookingUsecase, logger),
-		UserHandler:    user.NewUserHandler(userUsecase, logger),
+		EventHandler:     event.NewEventHandler(eventUsecase, logger),
+		BookingHandler:   booking.NewBookingHandler(bookingUsecase, logger),
+		UserHandler:      user.NewUserHandler(userUsecase, logger),
+		WaitlistHandler:  waitlist.NewWaitlistHandler(waitlistUsecase, logger),
+		OutboxHandler:    outbox_handler.NewOutboxHandler(outboxUsecase, logger),
+		PaymentHandler:   payment_handler.NewPaymentHandler(paymentUsecase, logger),
+		WebhookHandler:   webhook_handler.NewWebhookHandler(webhookUsecase, logger),
+		StreamHandler:    stream.NewStreamHandler(broker, eventUsecase, cfg.Stream.Heartbeat, logger),
+		SeatHandler:      seat_handler.NewSeatHandler(seatUsecase, logger),
+		PromoHandler:     promo_handler.NewPromoHandler(promoUsecase, logger),
+		VenueHandler:     venue_handler.NewVenueHandler(venueUsecase, logger),
+		OrganizerHandler: organizer_handler.NewOrganizerHandler(organizerUsecase, logger),
+		FakeGateway:      fakeGateway.Handler(),
 	}
-	mux := router.SetupRouter(h)
+	mux := router.SetupRouter(h, tokenManager, organizerUsecase, idempotencyUsecase)
 	server := &http.Server{
 		Addr:         ":" + cfg.Server.Addr,
 		Handler:      mux,
@@ -80,11 +171,14 @@ func NewApp(cfg *config.Config, logger *zlog.Zerolog) (*App, error) {
 		IdleTimeout:  cfg.Server.IdleTimeout,
 	}
 	return &App{
-		cfg:       cfg,
-		server:    server,
-		logger:    logger,
-		db:        db,
-		scheduler: sch,
+		cfg:        cfg,
+		server:     server,
+		logger:     logger,
+		db:         db,
+		scheduler:  sch,
+		dispatcher: dispatcher,
+		deliverer:  deliverer,
+		broker:     broker,
 	}, nil
 }
 
@@ -93,6 +187,9 @@ func (a *App) Run() error {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 	a.scheduler.Start(ctx)
+	a.dispatcher.Start(ctx)
+	a.deliverer.Start(ctx)
+	a.broker.Start(ctx)
 	serverErr := make(chan error, 1)
 	go func() {
 		if err := a.server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
@@ -119,15 +216,26 @@ func (a *App) handleSignals(cancel context.CancelFunc, serverErr chan error) {
 	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), a.cfg.Server.ShutdownTimeout)
 	defer shutdownCancel()
 
+	// Open event streams never go idle, so they are ended before the server
+	// waits for connections to drain.
+	if a.broker != nil {
+		a.broker.Stop()
+	}
 	if err := a.server.Shutdown(shutdownCtx); err != nil {
 		a.logger.Error().Err(err).Msg("Server shutdown failed")
 	}
-	if a.db != nil && a.db.Master != nil {
-		a.db.Master.Close()
-	}
 	if a.scheduler != nil {
 		a.scheduler.Stop()
 	}
+	if a.dispatcher != nil {
+		a.dispatcher.Stop()
+	}
+	if a.deliverer != nil {
+		a.deliverer.Stop()
+	}
+	if a.db != nil && a.db.Master != nil {
+		a.db.Master.Close()
+	}
 
 	a.logger.Info().Msg("Server stopped gracefully")
 }
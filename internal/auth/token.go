@@ -0,0 +1,85 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"event-booker/internal/domain"
+)
+
+var (
+	ErrInvalidToken = errors.New("invalid token")
+	ErrTokenExpired = errors.New("token expired")
+)
+
+// TokenManager issues and verifies HS256-signed JWTs carrying the user ID and role.
+type TokenManager struct {
+	secret []byte
+	ttl    time.Duration
+}
+
+type claims struct {
+	Subject   string          `json:"sub"`
+	Role      domain.UserRole `json:"role"`
+	IssuedAt  int64           `json:"iat"`
+	ExpiresAt int64           `json:"exp"`
+}
+
+var encodedHeader = base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+
+func NewTokenManager(secret string, ttl time.Duration) *TokenManager {
+	return &TokenManager{secret: []byte(secret), ttl: ttl}
+}
+
+func (m *TokenManager) Issue(user *domain.User) (string, time.Time, error) {
+	now := time.Now()
+	expiresAt := now.Add(m.ttl)
+	payload, err := json.Marshal(claims{
+		Subject:   user.ID,
+		Role:      user.Role,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: expiresAt.Unix(),
+	})
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	unsigned := encodedHeader + "." + base64.RawURLEncoding.EncodeToString(payload)
+	return unsigned + "." + m.sign(unsigned), expiresAt, nil
+}
+
+func (m *TokenManager) Parse(token string) (*domain.Principal, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 || parts[0] != encodedHeader {
+		return nil, ErrInvalidToken
+	}
+	expected := m.sign(parts[0] + "." + parts[1])
+	if !hmac.Equal([]byte(expected), []byte(parts[2])) {
+		return nil, ErrInvalidToken
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+	var c claims
+	if err := json.Unmarshal(payload, &c); err != nil {
+		return nil, ErrInvalidToken
+	}
+	if c.Subject == "" {
+		return nil, ErrInvalidToken
+	}
+	if time.Now().Unix() >= c.ExpiresAt {
+		return nil, ErrTokenExpired
+	}
+	return &domain.Principal{UserID: c.Subject, Role: c.Role}, nil
+}
+
+func (m *TokenManager) sign(unsigned string) string {
+	mac := hmac.New(sha256.New, m.secret)
+	mac.Write([]byte(unsigned))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -34,8 +34,43 @@ type Config struct {
 		Backoff  float64 `env:"RETRIES_BACKOFF" validate:"required"`
 	}
 	Scheduler struct {
-		CleanupInterval time.Duration `env:"SCHEDULER_CLEANUP_INTERVAL" validate:"required"`
-		BookingTTL      time.Duration `env:"SCHEDULER_BOOKING_TTL" validate:"required"`
+		CleanupInterval   time.Duration `env:"SCHEDULER_CLEANUP_INTERVAL" validate:"required"`
+		BookingTTL        time.Duration `env:"SCHEDULER_BOOKING_TTL" validate:"required"`
+		LifecycleInterval time.Duration `env:"SCHEDULER_LIFECYCLE_INTERVAL" env-default:"1m"`
+		ReminderInterval  time.Duration `env:"SCHEDULER_REMINDER_INTERVAL" env-default:"1m"`
+	}
+	Reminders struct {
+		// Leads lists how long before an event its attendees are reminded.
+		Leads []time.Duration `env:"REMINDER_LEADS" env-default:"24h,1h"`
+		// PaymentLead is how long before a pending paid booking expires its
+		// holder is reminded to pay; zero disables the reminder.
+		PaymentLead time.Duration `env:"REMINDER_PAYMENT_LEAD" env-default:"10m"`
+	}
+	Outbox struct {
+		PollInterval time.Duration `env:"OUTBOX_POLL_INTERVAL" env-default:"5s"`
+		BatchSize    int           `env:"OUTBOX_BATCH_SIZE" env-default:"50"`
+		MaxAttempts  int           `env:"OUTBOX_MAX_ATTEMPTS" env-default:"8"`
+		BaseBackoff  time.Duration `env:"OUTBOX_BASE_BACKOFF" env-default:"10s"`
+		MaxBackoff   time.Duration `env:"OUTBOX_MAX_BACKOFF" env-default:"1h"`
+		Lease        time.Duration `env:"OUTBOX_LEASE" env-default:"5m"`
+	}
+	Stream struct {
+		// Heartbeat is how often idle SSE connections get a comment line so
+		// that proxies keep them open.
+		Heartbeat    time.Duration `env:"STREAM_HEARTBEAT" env-default:"15s"`
+		ClientBuffer int           `env:"STREAM_CLIENT_BUFFER" env-default:"32"`
+	}
+	Webhooks struct {
+		PollInterval time.Duration `env:"WEBHOOK_POLL_INTERVAL" env-default:"5s"`
+		BatchSize    int           `env:"WEBHOOK_BATCH_SIZE" env-default:"50"`
+		MaxAttempts  int           `env:"WEBHOOK_MAX_ATTEMPTS" env-default:"8"`
+		Timeout      time.Duration `env:"WEBHOOK_TIMEOUT" env-default:"10s"`
+		BaseBackoff  time.Duration `env:"WEBHOOK_BASE_BACKOFF" env-default:"10s"`
+		MaxBackoff   time.Duration `env:"WEBHOOK_MAX_BACKOFF" env-default:"1h"`
+		Lease        time.Duration `env:"WEBHOOK_LEASE" env-default:"5m"`
+		// AllowPrivateNetworks lets webhooks reach loopback and private
+		// addresses. Only meant for local development.
+		AllowPrivateNetworks bool `env:"WEBHOOK_ALLOW_PRIVATE_NETWORKS" env-default:"false"`
 	}
 	EmailConfig struct {
 		SMTPHost     string `env:"SMTP_HOST" validate:"required"`
@@ -43,10 +78,34 @@ type Config struct {
 		SMTPUser     string `env:"SMTP_USER" validate:"required"`
 		SMTPPassword string `env:"SMTP_PASSWORD" validate:"required"`
 		FromEmail    string `env:"FROM_EMAIL" validate:"required"`
+		// TemplatesDir holds template overrides laid out like the built-in
+		// templates, e.g. ru/booking_created.html; files not found there fall
+		// back to the built-in ones.
+		TemplatesDir string `env:"EMAIL_TEMPLATES_DIR"`
+		// BaseURL is the public address used for links in emails.
+		BaseURL string `env:"APP_BASE_URL" env-default:"http://localhost:8005"`
 	}
 	TelegramConfig struct {
 		BotToken string `env:"TELEGRAM_BOT_TOKEN" validate:"required"`
 	}
+	Idempotency struct {
+		TTL time.Duration `env:"IDEMPOTENCY_TTL" env-default:"24h"`
+		// Lease is how long a request may hold its key before another request
+		// with the same key may take it over, e.g. after a crash.
+		Lease time.Duration `env:"IDEMPOTENCY_LEASE" env-default:"1m"`
+	}
+	Payment struct {
+		Provider        string `env:"PAYMENT_PROVIDER" env-default:"fake" validate:"oneof=fake"`
+		WebhookSecret   string `env:"PAYMENT_WEBHOOK_SECRET" validate:"required,min=16"`
+		WebhookURL      string `env:"PAYMENT_WEBHOOK_URL"`
+		CheckoutBaseURL string `env:"PAYMENT_CHECKOUT_BASE_URL" env-default:"/fake-pay"`
+	}
+	Auth struct {
+		JWTSecret     string        `env:"AUTH_JWT_SECRET" validate:"required,min=32"`
+		TokenTTL      time.Duration `env:"AUTH_TOKEN_TTL" env-default:"24h"`
+		AdminEmail    string        `env:"AUTH_ADMIN_EMAIL"`
+		AdminPassword string        `env:"AUTH_ADMIN_PASSWORD"`
+	}
 }
 
 func MustLoad() (*Config, error) {
@@ -6,10 +6,21 @@ type Booking struct {
 	ID          string
 	EventID     string
 	UserID      string
+	Quantity    int
 	Status      BookingStatus
 	CreatedAt   time.Time
 	ExpiresAt   time.Time
 	ConfirmedAt *time.Time
+	// TierID is the ticket tier the booking was made in; nil for events
+	// without tiers.
+	TierID *string
+	// PromoCodeID is the promo code applied to the booking and Discount the
+	// amount it took off the price, in minor units of the ticket currency.
+	PromoCodeID *string
+	Discount    int64
+	// SeatIDs lists the reserved seats; it is only filled in when the booking
+	// is made.
+	SeatIDs []string `json:",omitempty"`
 }
 
 type BookingStatus string
@@ -19,3 +30,12 @@ const (
 	BookingConfirmed BookingStatus = "confirmed"
 	BookingCancelled BookingStatus = "cancelled"
 )
+
+// UserBooking is a booking together with the event details shown in a user's
+// booking history.
+type UserBooking struct {
+	*Booking
+	EventName   string
+	EventDate   time.Time
+	EventStatus EventStatus
+}
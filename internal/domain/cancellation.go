@@ -0,0 +1,65 @@
+package domain
+
+import "time"
+
+// CancellationPolicy decides how much of a paid booking is refunded when the
+// booking is cancelled, based on how long before the event it happens.
+type CancellationPolicy struct {
+	// FreeWindow is the notice that still earns a full refund.
+	FreeWindow time.Duration
+	// Tiers grant partial refunds once the free window has passed. The tier
+	// with the largest MinNotice that is still met applies.
+	Tiers []RefundTier
+	// Cutoff is the notice below which nothing is refunded. It is also the
+	// latest point at which the organizer may cancel the event.
+	Cutoff time.Duration
+}
+
+type RefundTier struct {
+	MinNotice time.Duration
+	Percent   int
+}
+
+// DefaultCancellationPolicy refunds in full up to a day before the event and
+// nothing afterwards.
+func DefaultCancellationPolicy() CancellationPolicy {
+	return CancellationPolicy{
+		FreeWindow: 24 * time.Hour,
+		Cutoff:     24 * time.Hour,
+	}
+}
+
+// RefundPercent returns the share of the paid amount, in percent, refunded for a
+// cancellation made at the given time.
+func (p CancellationPolicy) RefundPercent(eventDate, at time.Time) int {
+	notice := eventDate.Sub(at)
+	if notice < 0 || notice < p.Cutoff {
+		return 0
+	}
+	if notice >= p.FreeWindow {
+		return 100
+	}
+	percent := 0
+	best := time.Duration(-1)
+	for _, tier := range p.Tiers {
+		if notice >= tier.MinNotice && tier.MinNotice > best {
+			best = tier.MinNotice
+			percent = tier.Percent
+		}
+	}
+	return percent
+}
+
+// Valid reports whether all durations are non-negative, the free window is no
+// shorter than the cutoff and all percentages lie between 0 and 100.
+func (p CancellationPolicy) Valid() bool {
+	if p.FreeWindow < 0 || p.Cutoff < 0 || p.FreeWindow < p.Cutoff {
+		return false
+	}
+	for _, tier := range p.Tiers {
+		if tier.MinNotice < 0 || tier.Percent < 0 || tier.Percent > 100 {
+			return false
+		}
+	}
+	return true
+}
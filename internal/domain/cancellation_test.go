@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCancellationPolicyRefundPercent(t *testing.T) {
+	eventDate := time.Date(2026, 6, 1, 18, 0, 0, 0, time.UTC)
+	tiered := CancellationPolicy{
+		FreeWindow: 7 * 24 * time.Hour,
+		Tiers: []RefundTier{
+			{MinNotice: 24 * time.Hour, Percent: 25},
+			{MinNotice: 72 * time.Hour, Percent: 50},
+		},
+		Cutoff: 12 * time.Hour,
+	}
+	tests := []struct {
+		name   string
+		policy CancellationPolicy
+		notice time.Duration
+		want   int
+	}{
+		{"default well before", DefaultCancellationPolicy(), 48 * time.Hour, 100},
+		{"default at free window", DefaultCancellationPolicy(), 24 * time.Hour, 100},
+		{"default just inside cutoff", DefaultCancellationPolicy(), 24*time.Hour - time.Second, 0},
+		{"after event start", DefaultCancellationPolicy(), -time.Hour, 0},
+		{"tiered at free window", tiered, 7 * 24 * time.Hour, 100},
+		{"tiered just inside free window", tiered, 7*24*time.Hour - time.Second, 50},
+		{"tiered at upper tier", tiered, 72 * time.Hour, 50},
+		{"tiered between tiers", tiered, 48 * time.Hour, 25},
+		{"tiered at lower tier", tiered, 24 * time.Hour, 25},
+		{"tiered above cutoff below tiers", tiered, 18 * time.Hour, 0},
+		{"tiered at cutoff", tiered, 12 * time.Hour, 0},
+		{"tiered inside cutoff", tiered, 6 * time.Hour, 0},
+		{"no cutoff at event start", CancellationPolicy{FreeWindow: time.Hour}, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.policy.RefundPercent(eventDate, eventDate.Add(-tt.notice))
+			if got != tt.want {
+				t.Errorf("RefundPercent with %v notice = %d, want %d", tt.notice, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCancellationPolicyValid(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy CancellationPolicy
+		want   bool
+	}{
+		{"default", DefaultCancellationPolicy(), true},
+		{"zero", CancellationPolicy{}, true},
+		{"free window after cutoff", CancellationPolicy{FreeWindow: 72 * time.Hour, Cutoff: 24 * time.Hour}, true},
+		{"free window before cutoff", CancellationPolicy{FreeWindow: 24 * time.Hour, Cutoff: 72 * time.Hour}, false},
+		{"negative free window", CancellationPolicy{FreeWindow: -time.Hour}, false},
+		{"negative cutoff", CancellationPolicy{FreeWindow: time.Hour, Cutoff: -time.Hour}, false},
+		{"negative tier notice", CancellationPolicy{FreeWindow: time.Hour, Tiers: []RefundTier{{MinNotice: -time.Hour, Percent: 50}}}, false},
+		{"tier over 100 percent", CancellationPolicy{FreeWindow: time.Hour, Tiers: []RefundTier{{MinNotice: time.Minute, Percent: 101}}}, false},
+		{"negative tier percent", CancellationPolicy{FreeWindow: time.Hour, Tiers: []RefundTier{{MinNotice: time.Minute, Percent: -1}}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.Valid(); got != tt.want {
+				t.Errorf("Valid() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -8,11 +8,22 @@ type Event struct {
 	Date            time.Time
 	TotalSeats      int
 	Available       int
+	MaxPerBooking   int
 	BookingTTL      time.Duration
 	RequiresPayment bool
-	CreatedAt       time.Time
-	UpdatedAt       time.Time
-	Status          EventStatus
+	Price           int64
+	Currency        string
+	Cancellation    CancellationPolicy
+	// LayoutID is set for reserved-seating events; TotalSeats then equals the
+	// number of seats in the layout.
+	LayoutID *string
+	// SeriesID links an occurrence of a recurring event to its series.
+	SeriesID    *string
+	VenueID     *string
+	OrganizerID *string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	Status      EventStatus
 }
 
 type EventStatus string
@@ -22,3 +33,18 @@ const (
 	EventCancelled EventStatus = "cancelled"
 	EventCompleted EventStatus = "completed"
 )
+
+// EventPatch describes a partial update of an event; nil fields are left as they
+// are.
+type EventPatch struct {
+	Name            *string
+	Date            *time.Time
+	BookingTTL      *time.Duration
+	RequiresPayment *bool
+	Price           *int64
+	Currency        *string
+	TotalSeats      *int
+	MaxPerBooking   *int
+	VenueID         *string
+	OrganizerID     *string
+}
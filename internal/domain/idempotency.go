@@ -0,0 +1,17 @@
+package domain
+
+import "time"
+
+// IdempotencyRecord remembers the outcome of a request sent with an
+// Idempotency-Key so that retries can be answered with the original response.
+// CompletedAt is nil while the first request is still being processed.
+type IdempotencyRecord struct {
+	UserID      string
+	Key         string
+	Fingerprint string
+	StatusCode  int
+	ContentType string
+	Body        []byte
+	CreatedAt   time.Time
+	CompletedAt *time.Time
+}
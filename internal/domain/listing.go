@@ -0,0 +1,76 @@
+package domain
+
+import "time"
+
+type EventSort string
+
+const (
+	EventSortDateAsc     EventSort = "date_asc"
+	EventSortDateDesc    EventSort = "date_desc"
+	EventSortCreatedDesc EventSort = "created_desc"
+	EventSortNameAsc     EventSort = "name_asc"
+)
+
+// EventFilter selects a page of events. Zero-valued fields do not filter.
+type EventFilter struct {
+	Status          EventStatus
+	DateFrom        *time.Time
+	DateTo          *time.Time
+	RequiresPayment *bool
+	SeriesID        string
+	VenueID         string
+	OrganizerID     string
+	// OrganizerIDs, when non-nil, limits the events to these organizers.
+	OrganizerIDs []string
+	Search       string
+	Sort         EventSort
+	Cursor       string
+	Limit        int
+}
+
+type BookingSort string
+
+const (
+	BookingSortCreatedDesc BookingSort = "created_desc"
+	BookingSortCreatedAsc  BookingSort = "created_asc"
+)
+
+// BookingFilter selects a page of bookings. Zero-valued fields do not filter.
+type BookingFilter struct {
+	Status  BookingStatus
+	EventID string
+	UserID  string
+	// OrganizerIDs, when non-nil, limits the bookings to events of these
+	// organizers.
+	OrganizerIDs []string
+	CreatedFrom  *time.Time
+	CreatedTo    *time.Time
+	Sort         BookingSort
+	Cursor       string
+	Limit        int
+}
+
+// Page is one slice of a keyset-paginated listing. NextCursor is empty on the
+// last page.
+type Page[T any] struct {
+	Items      []T
+	NextCursor string
+}
+
+type UserBookingScope string
+
+const (
+	UserBookingsUpcoming  UserBookingScope = "upcoming"
+	UserBookingsPast      UserBookingScope = "past"
+	UserBookingsCancelled UserBookingScope = "cancelled"
+)
+
+// UserBookingFilter selects a page of one user's bookings. An empty Scope
+// returns all of them.
+type UserBookingFilter struct {
+	UserID string
+	Scope  UserBookingScope
+	Now    time.Time
+	Cursor string
+	Limit  int
+}
@@ -0,0 +1,53 @@
+package domain
+
+import "time"
+
+type NotificationKind string
+
+const (
+	NotificationBookingCreated   NotificationKind = "booking_created"
+	NotificationPaymentPending   NotificationKind = "payment_pending"
+	NotificationBookingConfirmed NotificationKind = "booking_confirmed"
+	NotificationBookingExpired   NotificationKind = "booking_expired"
+	NotificationBookingCancelled NotificationKind = "booking_cancelled"
+	NotificationEventCancelled   NotificationKind = "event_cancelled"
+	NotificationWaitlistPromoted NotificationKind = "waitlist_promoted"
+	NotificationEventRescheduled NotificationKind = "event_rescheduled"
+	NotificationEventReminder    NotificationKind = "event_reminder"
+)
+
+type Notification struct {
+	Kind    NotificationKind
+	UserID  string
+	Booking *Booking
+	// Reason is the explanation given for event_cancelled notifications.
+	Reason string
+	// Event is a snapshot of the booked event. Messages enqueued before it was
+	// recorded carry only the booking. PreviousDate is set for
+	// event_rescheduled notifications.
+	Event        *Event     `json:",omitempty"`
+	PreviousDate *time.Time `json:",omitempty"`
+}
+
+type OutboxMessage struct {
+	ID           string
+	Notification Notification
+	// Delivered lists the channels that already received the notification.
+	// Retries skip them.
+	Delivered     []NotificationChannel
+	Status        OutboxStatus
+	Attempts      int
+	NextAttemptAt time.Time
+	LastError     string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	SentAt        *time.Time
+}
+
+type OutboxStatus string
+
+const (
+	OutboxPending OutboxStatus = "pending"
+	OutboxSent    OutboxStatus = "sent"
+	OutboxDead    OutboxStatus = "dead"
+)
@@ -0,0 +1,76 @@
+package domain
+
+import "time"
+
+type NotificationChannel string
+
+const (
+	ChannelEmail    NotificationChannel = "email"
+	ChannelTelegram NotificationChannel = "telegram"
+	ChannelWebhook  NotificationChannel = "webhook"
+)
+
+// NotificationPreferences controls which channels a user is notified on and
+// whether they receive reminders. Transactional messages about their bookings
+// cannot be switched off entirely.
+type NotificationPreferences struct {
+	UserID    string
+	Email     bool
+	Telegram  bool
+	Webhook   bool
+	Reminders bool
+	UpdatedAt *time.Time
+}
+
+// NotificationPreferencesPatch is a partial update; nil fields are left as
+// they are.
+type NotificationPreferencesPatch struct {
+	Email     *bool
+	Telegram  *bool
+	Webhook   *bool
+	Reminders *bool
+}
+
+func DefaultNotificationPreferences(userID string) NotificationPreferences {
+	return NotificationPreferences{UserID: userID, Email: true, Telegram: true, Webhook: true, Reminders: true}
+}
+
+// Enabled reports whether the user accepts notifications on channel. Channels
+// without a preference of their own are enabled.
+func (p NotificationPreferences) Enabled(channel NotificationChannel) bool {
+	switch channel {
+	case ChannelEmail:
+		return p.Email
+	case ChannelTelegram:
+		return p.Telegram
+	case ChannelWebhook:
+		return p.Webhook
+	default:
+		return true
+	}
+}
+
+// Optional reports whether users may opt out of the kind altogether.
+func (k NotificationKind) Optional() bool {
+	return k == NotificationEventReminder || k == NotificationPaymentPending
+}
+
+// NotificationChannels picks the channels, out of those the user can be reached
+// on, that a notification of kind is sent to. Optional kinds go only to enabled
+// channels. Mandatory kinds fall back to every reachable channel when all of
+// them are disabled.
+func (u *User) NotificationChannels(kind NotificationKind, reachable []NotificationChannel) []NotificationChannel {
+	if kind.Optional() && !u.Preferences.Reminders {
+		return nil
+	}
+	var enabled []NotificationChannel
+	for _, channel := range reachable {
+		if u.Preferences.Enabled(channel) {
+			enabled = append(enabled, channel)
+		}
+	}
+	if len(enabled) == 0 && !kind.Optional() {
+		return reachable
+	}
+	return enabled
+}
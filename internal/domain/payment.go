@@ -0,0 +1,55 @@
+package domain
+
+import "time"
+
+type Payment struct {
+	ID          string
+	BookingID   string
+	Provider    string
+	IntentID    string
+	Amount      int64
+	Currency    string
+	Status      PaymentStatus
+	CheckoutURL string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+type PaymentStatus string
+
+const (
+	PaymentPending PaymentStatus = "pending"
+	// PaymentAuthorized is a payment the provider reported as succeeded whose
+	// booking is confirmed but which has not been captured yet.
+	PaymentAuthorized PaymentStatus = "authorized"
+	PaymentSucceeded  PaymentStatus = "succeeded"
+	PaymentFailed     PaymentStatus = "failed"
+	PaymentCancelled  PaymentStatus = "cancelled"
+)
+
+type Refund struct {
+	ID               string
+	PaymentID        string
+	BookingID        string
+	Amount           int64
+	Currency         string
+	Percent          int
+	Reason           string
+	Status           RefundStatus
+	ProviderRefundID string
+	Attempts         int
+	LastError        string
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+type RefundStatus string
+
+const (
+	RefundPending RefundStatus = "pending"
+	// RefundProcessing is a refund claimed by a run that is submitting it to
+	// the provider.
+	RefundProcessing RefundStatus = "processing"
+	RefundSucceeded  RefundStatus = "succeeded"
+	RefundFailed     RefundStatus = "failed"
+)
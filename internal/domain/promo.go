@@ -0,0 +1,74 @@
+package domain
+
+import "time"
+
+// PromoCode discounts bookings of paid events. A code with an EventID only
+// applies to that event; one without applies to every paid event.
+type PromoCode struct {
+	ID      string
+	Code    string
+	EventID *string
+	Kind    DiscountKind
+	// Value is a percentage (1-100) for percent codes and an amount in minor
+	// units of Currency for fixed codes.
+	Value    int64
+	Currency string
+	// MaxUses and MaxUsesPerUser limit how many active bookings may hold the
+	// code; zero means unlimited.
+	MaxUses        int
+	MaxUsesPerUser int
+	Uses           int
+	ValidFrom      *time.Time
+	ValidUntil     *time.Time
+	Active         bool
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+type DiscountKind string
+
+const (
+	DiscountPercent DiscountKind = "percent"
+	DiscountFixed   DiscountKind = "fixed"
+)
+
+// ValidAt reports whether the code is active and now falls within its
+// validity window.
+func (p *PromoCode) ValidAt(now time.Time) bool {
+	if !p.Active {
+		return false
+	}
+	if p.ValidFrom != nil && now.Before(*p.ValidFrom) {
+		return false
+	}
+	if p.ValidUntil != nil && !now.Before(*p.ValidUntil) {
+		return false
+	}
+	return true
+}
+
+// Discount returns the amount taken off subtotal; it never exceeds subtotal.
+func (p *PromoCode) Discount(subtotal int64) int64 {
+	var discount int64
+	switch p.Kind {
+	case DiscountPercent:
+		discount = subtotal * p.Value / 100
+	case DiscountFixed:
+		discount = p.Value
+	}
+	if discount > subtotal {
+		return subtotal
+	}
+	return discount
+}
+
+// PromoCodePatch describes a partial update of a promo code; nil fields are
+// left as they are. ClearValidUntil removes the expiry and cannot be combined
+// with ValidUntil.
+type PromoCodePatch struct {
+	Active          *bool
+	MaxUses         *int
+	MaxUsesPerUser  *int
+	ValidUntil      *time.Time
+	ClearValidUntil bool
+}
@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPromoCodeDiscount(t *testing.T) {
+	tests := []struct {
+		name     string
+		kind     DiscountKind
+		value    int64
+		subtotal int64
+		want     int64
+	}{
+		{"percent", DiscountPercent, 20, 10000, 2000},
+		{"percent rounds down", DiscountPercent, 15, 999, 149},
+		{"full percent", DiscountPercent, 100, 10000, 10000},
+		{"percent of nothing", DiscountPercent, 50, 0, 0},
+		{"fixed", DiscountFixed, 500, 10000, 500},
+		{"fixed equal to subtotal", DiscountFixed, 10000, 10000, 10000},
+		{"fixed above subtotal", DiscountFixed, 15000, 10000, 10000},
+		{"fixed on free booking", DiscountFixed, 500, 0, 0},
+		{"unknown kind", DiscountKind("bogus"), 500, 10000, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &PromoCode{Kind: tt.kind, Value: tt.value}
+			if got := p.Discount(tt.subtotal); got != tt.want {
+				t.Errorf("Discount(%d) = %d, want %d", tt.subtotal, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPromoCodeValidAt(t *testing.T) {
+	from := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		active bool
+		from   *time.Time
+		until  *time.Time
+		now    time.Time
+		want   bool
+	}{
+		{"unbounded", true, nil, nil, from, true},
+		{"inactive", false, nil, nil, from, false},
+		{"before window", true, &from, &until, from.Add(-time.Second), false},
+		{"at window start", true, &from, &until, from, true},
+		{"inside window", true, &from, &until, from.Add(24 * time.Hour), true},
+		{"just before window end", true, &from, &until, until.Add(-time.Second), true},
+		{"at window end", true, &from, &until, until, false},
+		{"open-ended start", true, nil, &until, from, true},
+		{"open-ended end", true, &from, nil, until, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &PromoCode{Active: tt.active, ValidFrom: tt.from, ValidUntil: tt.until}
+			if got := p.ValidAt(tt.now); got != tt.want {
+				t.Errorf("ValidAt(%v) = %v, want %v", tt.now, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,11 @@
+package domain
+
+import "time"
+
+// Reminder is a notice sent Lead before the event of a confirmed booking or,
+// for payment reminders, before a pending booking expires.
+type Reminder struct {
+	Booking *Booking
+	Event   *Event
+	Lead    time.Duration
+}
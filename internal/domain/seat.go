@@ -0,0 +1,41 @@
+package domain
+
+import "time"
+
+// SeatLayout is the seating plan of a venue. Events created with a layout sell
+// specific seats instead of a general-admission counter.
+type SeatLayout struct {
+	ID        string
+	Name      string
+	Seats     []*Seat
+	CreatedAt time.Time
+}
+
+type Seat struct {
+	ID         string
+	Section    string
+	Row        string
+	Number     int
+	Accessible bool
+}
+
+type SeatStatus string
+
+const (
+	SeatAvailable SeatStatus = "available"
+	// SeatHeld seats belong to a pending booking and are freed if it expires.
+	SeatHeld   SeatStatus = "held"
+	SeatBooked SeatStatus = "booked"
+)
+
+// EventSeat is a seat of a reserved-seating event together with its current
+// availability.
+type EventSeat struct {
+	*Seat
+	Status SeatStatus
+}
+
+// ReservedSeating reports whether the event sells specific seats.
+func (e *Event) ReservedSeating() bool {
+	return e.LayoutID != nil
+}
@@ -0,0 +1,16 @@
+package domain
+
+import "time"
+
+// EventSeries groups the occurrences of a recurring event. The occurrences are
+// ordinary events, generated up front from Recurrence (an RRULE) starting at
+// Start, and carry the series ID.
+type EventSeries struct {
+	ID          string
+	Name        string
+	Recurrence  string
+	Start       time.Time
+	OrganizerID *string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
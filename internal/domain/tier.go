@@ -0,0 +1,41 @@
+package domain
+
+import "time"
+
+// TicketTier is a category of tickets for an event (e.g. Standard, VIP) with
+// its own price, quota and sales window. Available counts the tier's unbooked
+// tickets; the event-wide counter still caps bookings across all tiers.
+type TicketTier struct {
+	ID         string
+	EventID    string
+	Name       string
+	Price      int64
+	Currency   string
+	Quota      int
+	Available  int
+	SalesStart *time.Time
+	SalesEnd   *time.Time
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// OnSale reports whether now falls within the tier's sales window; an unset
+// bound leaves that side of the window open.
+func (t *TicketTier) OnSale(now time.Time) bool {
+	if t.SalesStart != nil && now.Before(*t.SalesStart) {
+		return false
+	}
+	if t.SalesEnd != nil && !now.Before(*t.SalesEnd) {
+		return false
+	}
+	return true
+}
+
+// TicketPrice returns the unit price and currency of a ticket for event,
+// bought in tier if the event has tiers.
+func TicketPrice(event *Event, tier *TicketTier) (int64, string) {
+	if tier != nil {
+		return tier.Price, tier.Currency
+	}
+	return event.Price, event.Currency
+}
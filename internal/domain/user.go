@@ -3,11 +3,43 @@ package domain
 import "time"
 
 type User struct {
-	ID        string
-	Email     string
-	Telegram  string
-	Role      UserRole
-	CreatedAt time.Time
+	ID           string
+	Email        string
+	Telegram     string
+	PasswordHash string `json:"-"`
+	Role         UserRole
+	Locale       Locale
+	Timezone     string
+	Preferences  NotificationPreferences
+	CreatedAt    time.Time
+}
+
+// Locale selects the language of the messages sent to a user.
+type Locale string
+
+const (
+	LocaleEnglish Locale = "en"
+	LocaleRussian Locale = "ru"
+
+	DefaultLocale   = LocaleEnglish
+	DefaultTimezone = "UTC"
+)
+
+func (l Locale) Valid() bool {
+	return l == LocaleEnglish || l == LocaleRussian
+}
+
+// Location returns the user's time zone, falling back to UTC when it is unset
+// or unknown.
+func (u *User) Location() *time.Location {
+	if u.Timezone == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(u.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
 }
 
 type UserRole string
@@ -16,3 +48,75 @@ const (
 	RoleUser  UserRole = "user"
 	RoleAdmin UserRole = "admin"
 )
+
+// Principal is the authenticated caller. Admins act across all organizers;
+// everyone else is limited to the organizers listed in Memberships.
+type Principal struct {
+	UserID      string
+	Role        UserRole
+	Memberships map[string]OrganizerRole
+}
+
+func (p *Principal) IsAdmin() bool {
+	return p != nil && p.Role == RoleAdmin
+}
+
+func (p *Principal) CanAccessUser(userID string) bool {
+	return p.IsAdmin() || (p != nil && p.UserID == userID)
+}
+
+// IsStaff reports whether the caller is an admin or belongs to an organizer.
+func (p *Principal) IsStaff() bool {
+	return p.IsAdmin() || (p != nil && len(p.Memberships) > 0)
+}
+
+// ScopedToOrganizers reports whether the caller only sees the events and
+// bookings of their own organizers: organizer staff that are not admins.
+func (p *Principal) ScopedToOrganizers() bool {
+	return !p.IsAdmin() && p.IsStaff()
+}
+
+// HasOrganizerRole reports whether the caller holds one of roles in the
+// organizer; admins always do. Events without an organizer are left to admins.
+func (p *Principal) HasOrganizerRole(organizerID *string, roles ...OrganizerRole) bool {
+	if p.IsAdmin() {
+		return true
+	}
+	if p == nil || organizerID == nil {
+		return false
+	}
+	role, ok := p.Memberships[*organizerID]
+	if !ok {
+		return false
+	}
+	for _, r := range roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// CanManageEvents reports whether the caller may create and change the
+// organizer's events.
+func (p *Principal) CanManageEvents(organizerID *string) bool {
+	return p.HasOrganizerRole(organizerID, OrganizerOwner, OrganizerManager)
+}
+
+// CanViewBookings reports whether the caller may see bookings of the
+// organizer's events.
+func (p *Principal) CanViewBookings(organizerID *string) bool {
+	return p.HasOrganizerRole(organizerID, OrganizerOwner, OrganizerManager, OrganizerCheckIn)
+}
+
+// OrganizerIDs returns the organizers the caller belongs to.
+func (p *Principal) OrganizerIDs() []string {
+	if p == nil {
+		return nil
+	}
+	ids := make([]string, 0, len(p.Memberships))
+	for id := range p.Memberships {
+		ids = append(ids, id)
+	}
+	return ids
+}
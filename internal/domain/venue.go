@@ -0,0 +1,68 @@
+package domain
+
+import "time"
+
+// Venue is a place where events take place. Capacity caps the total seats of
+// every event held there; Timezone is an IANA name such as Europe/Moscow.
+// Latitude and Longitude are either both set or both nil.
+type Venue struct {
+	ID        string
+	Name      string
+	Address   string
+	Timezone  string
+	Capacity  int
+	Latitude  *float64
+	Longitude *float64
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// VenuePatch describes a partial update of a venue; nil fields are left as
+// they are.
+type VenuePatch struct {
+	Name      *string
+	Address   *string
+	Timezone  *string
+	Capacity  *int
+	Latitude  *float64
+	Longitude *float64
+}
+
+type Organizer struct {
+	ID        string
+	Name      string
+	Email     string
+	Website   string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// OrganizerRole is a user's role within one organizer. Owners manage the
+// organizer and its members, managers run its events, and check-in staff may
+// only look at bookings.
+type OrganizerRole string
+
+const (
+	OrganizerOwner   OrganizerRole = "owner"
+	OrganizerManager OrganizerRole = "manager"
+	OrganizerCheckIn OrganizerRole = "checkin"
+)
+
+func (r OrganizerRole) Valid() bool {
+	return r == OrganizerOwner || r == OrganizerManager || r == OrganizerCheckIn
+}
+
+type OrganizerMember struct {
+	OrganizerID string
+	UserID      string
+	Role        OrganizerRole
+	CreatedAt   time.Time
+}
+
+// OrganizerPatch describes a partial update of an organizer; nil fields are
+// left as they are.
+type OrganizerPatch struct {
+	Name    *string
+	Email   *string
+	Website *string
+}
@@ -0,0 +1,23 @@
+package domain
+
+import "time"
+
+type WaitlistEntry struct {
+	ID         string
+	EventID    string
+	UserID     string
+	Quantity   int
+	TierID     *string
+	Status     WaitlistStatus
+	BookingID  *string
+	CreatedAt  time.Time
+	PromotedAt *time.Time
+}
+
+type WaitlistStatus string
+
+const (
+	WaitlistWaiting   WaitlistStatus = "waiting"
+	WaitlistPromoted  WaitlistStatus = "promoted"
+	WaitlistCancelled WaitlistStatus = "cancelled"
+)
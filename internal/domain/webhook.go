@@ -0,0 +1,108 @@
+package domain
+
+import (
+	"encoding/json"
+	"slices"
+	"time"
+)
+
+// WebhookEndpoint is a URL that receives signed HTTP requests. It belongs to
+// exactly one owner: a user, who receives their own notifications, or an
+// organizer, which receives the domain events of its events.
+type WebhookEndpoint struct {
+	ID          string
+	UserID      *string
+	OrganizerID *string
+	URL         string
+	Secret      string
+	CreatedAt   time.Time
+}
+
+// WebhookSubscription is an integrator's URL that receives domain events as
+// signed HTTP requests. An empty EventTypes subscribes to every event type.
+type WebhookSubscription struct {
+	ID         string
+	URL        string
+	Secret     string
+	EventTypes []DomainEventType
+	Active     bool
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+type WebhookSubscriptionPatch struct {
+	URL        *string
+	EventTypes *[]DomainEventType
+	Active     *bool
+}
+
+func (s *WebhookSubscription) Matches(t DomainEventType) bool {
+	return len(s.EventTypes) == 0 || slices.Contains(s.EventTypes, t)
+}
+
+// WebhookDelivery is one attempt log entry for sending a payload to either a
+// user's endpoint or an integrator's subscription. Kind is the notification
+// kind or domain event type. URL and Secret are copied from the target when
+// the delivery is claimed for sending.
+type WebhookDelivery struct {
+	ID             string
+	EndpointID     *string
+	SubscriptionID *string
+	Kind           string
+	Payload        json.RawMessage
+	Status         WebhookDeliveryStatus
+	Attempts       int
+	NextAttemptAt  time.Time
+	ResponseStatus *int
+	LastError      string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	DeliveredAt    *time.Time
+	URL            string `json:"-"`
+	Secret         string `json:"-"`
+}
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliveryDelivered WebhookDeliveryStatus = "delivered"
+	WebhookDeliveryFailed    WebhookDeliveryStatus = "failed"
+)
+
+// DomainEventType names a state change published to webhook subscriptions.
+type DomainEventType string
+
+const (
+	DomainEventCreated     DomainEventType = "event.created"
+	DomainEventCancelled   DomainEventType = "event.cancelled"
+	DomainBookingCreated   DomainEventType = "booking.created"
+	DomainBookingConfirmed DomainEventType = "booking.confirmed"
+	DomainBookingCancelled DomainEventType = "booking.cancelled"
+	DomainBookingExpired   DomainEventType = "booking.expired"
+	// DomainPing is only ever sent by the test-ping endpoint.
+	DomainPing DomainEventType = "ping"
+)
+
+var DomainEventTypes = []DomainEventType{
+	DomainEventCreated,
+	DomainEventCancelled,
+	DomainBookingCreated,
+	DomainBookingConfirmed,
+	DomainBookingCancelled,
+	DomainBookingExpired,
+}
+
+// Valid reports whether t can be subscribed to.
+func (t DomainEventType) Valid() bool {
+	return slices.Contains(DomainEventTypes, t)
+}
+
+// DomainEvent is a state change published to webhook subscriptions. Booking is
+// nil for event-level changes.
+type DomainEvent struct {
+	Type    DomainEventType
+	Event   *Event
+	Booking *Booking
+	Reason  string
+}
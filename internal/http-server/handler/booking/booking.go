@@ -2,10 +2,17 @@ package booking
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
+	"strconv"
+	"time"
 
+	"event-booker/internal/domain"
 	"event-booker/internal/http-server/handler/booking/dto"
+	"event-booker/internal/http-server/middleware"
 	bookingErr "event-booker/internal/usecase/booking"
+	paymentErr "event-booker/internal/usecase/payment"
+	promoErr "event-booker/internal/usecase/promo"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/wb-go/wbf/zlog"
@@ -20,23 +27,125 @@ func NewBookingHandler(usecase bookingUsecase, logger *zlog.Zerolog) *BookingHan
 	return &BookingHandler{usecase: usecase, logger: logger}
 }
 
+// ListBookings returns one page of bookings as a JSON array; the cursor for the
+// following page, if any, is sent in the X-Next-Cursor header.
 func (h *BookingHandler) ListBookings(w http.ResponseWriter, r *http.Request) {
 	h.logger.Info().
 		Str("method", r.Method).
 		Str("path", r.URL.Path).
+		Str("query", r.URL.RawQuery).
 		Msg("List bookings request received")
-	bookings, err := h.usecase.ListBookings(r.Context())
+	filter, err := parseBookingFilter(r)
+	if err != nil {
+		h.logger.Error().Err(err).Msg("Failed to parse booking filter")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	page, err := h.usecase.ListBookings(r.Context(), filter, middleware.PrincipalFromContext(r.Context()))
 	if err != nil {
 		h.logger.Error().Err(err).Msg("Failed to list bookings")
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		switch err {
+		case bookingErr.ErrNotStaff:
+			http.Error(w, "Forbidden", http.StatusForbidden)
+		case bookingErr.ErrInvalidFilter:
+			http.Error(w, "Invalid filter. Status must be pending, confirmed or cancelled; sort must be created_desc or created_asc", http.StatusBadRequest)
+		case bookingErr.ErrInvalidCursor:
+			http.Error(w, "Invalid cursor", http.StatusBadRequest)
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
 		return
 	}
 	w.Header().Set("Content-Type", "application/json")
-	if err := json.NewEncoder(w).Encode(bookings); err != nil {
+	if page.NextCursor != "" {
+		w.Header().Set(dto.NextCursorHeader, page.NextCursor)
+	}
+	if err := json.NewEncoder(w).Encode(page.Items); err != nil {
 		h.logger.Error().Err(err).Msg("Failed to encode bookings")
 	}
 }
 
+func parseBookingFilter(r *http.Request) (domain.BookingFilter, error) {
+	q := r.URL.Query()
+	filter := domain.BookingFilter{
+		Status:  domain.BookingStatus(q.Get("status")),
+		EventID: q.Get("event_id"),
+		UserID:  q.Get("user_id"),
+		Sort:    domain.BookingSort(q.Get("sort")),
+		Cursor:  q.Get("cursor"),
+	}
+	if v := q.Get("created_from"); v != "" {
+		from, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return filter, errors.New("invalid created_from, use RFC3339 format")
+		}
+		filter.CreatedFrom = &from
+	}
+	if v := q.Get("created_to"); v != "" {
+		to, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return filter, errors.New("invalid created_to, use RFC3339 format")
+		}
+		filter.CreatedTo = &to
+	}
+	if v := q.Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil {
+			return filter, errors.New("invalid limit")
+		}
+		filter.Limit = limit
+	}
+	return filter, nil
+}
+
+// ListUserBookings returns one page of the user's bookings with event details.
+// The scope query parameter narrows them to upcoming, past or cancelled ones.
+func (h *BookingHandler) ListUserBookings(w http.ResponseWriter, r *http.Request) {
+	userID := chi.URLParam(r, "id")
+	h.logger.Info().
+		Str("method", r.Method).
+		Str("path", r.URL.Path).
+		Str("user_id", userID).
+		Str("query", r.URL.RawQuery).
+		Msg("List user bookings request received")
+	q := r.URL.Query()
+	filter := domain.UserBookingFilter{
+		UserID: userID,
+		Scope:  domain.UserBookingScope(q.Get("scope")),
+		Cursor: q.Get("cursor"),
+	}
+	if v := q.Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil {
+			http.Error(w, "invalid limit", http.StatusBadRequest)
+			return
+		}
+		filter.Limit = limit
+	}
+	page, err := h.usecase.ListUserBookings(r.Context(), filter, middleware.PrincipalFromContext(r.Context()))
+	if err != nil {
+		h.logger.Error().Err(err).Str("user_id", userID).Msg("Failed to list user bookings")
+		switch err {
+		case bookingErr.ErrForbidden:
+			http.Error(w, "Forbidden", http.StatusForbidden)
+		case bookingErr.ErrInvalidFilter:
+			http.Error(w, "Invalid filter. Scope must be upcoming, past or cancelled", http.StatusBadRequest)
+		case bookingErr.ErrInvalidCursor:
+			http.Error(w, "Invalid cursor", http.StatusBadRequest)
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if page.NextCursor != "" {
+		w.Header().Set(dto.NextCursorHeader, page.NextCursor)
+	}
+	if err := json.NewEncoder(w).Encode(page.Items); err != nil {
+		h.logger.Error().Err(err).Str("user_id", userID).Msg("Failed to encode user bookings")
+	}
+}
+
 func (h *BookingHandler) Book(w http.ResponseWriter, r *http.Request) {
 	eventID := chi.URLParam(r, "id")
 	h.logger.Info().
@@ -44,38 +153,76 @@ func (h *BookingHandler) Book(w http.ResponseWriter, r *http.Request) {
 		Str("path", r.URL.Path).
 		Str("event_id", eventID).
 		Msg("Booking request received")
+	principal := middleware.PrincipalFromContext(r.Context())
 	var req dto.BookRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.logger.Error().
-			Err(err).
-			Str("event_id", eventID).
-			Msg("Failed to decode booking request")
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
-		return
+	if r.ContentLength > 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			h.logger.Error().
+				Err(err).
+				Str("event_id", eventID).
+				Msg("Failed to decode booking request")
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
 	}
-	if req.UserID == "" {
-		h.logger.Error().
-			Str("event_id", eventID).
-			Msg("User ID is required")
-		http.Error(w, "User ID is required", http.StatusBadRequest)
-		return
+	if req.Quantity == 0 {
+		req.Quantity = 1
+		if len(req.SeatIDs) > 0 {
+			req.Quantity = len(req.SeatIDs)
+		}
 	}
 	h.logger.Info().
 		Str("event_id", eventID).
-		Str("user_id", req.UserID).
+		Str("user_id", principal.UserID).
+		Int("quantity", req.Quantity).
+		Strs("seat_ids", req.SeatIDs).
+		Str("tier_id", req.TierID).
+		Str("promo_code", req.PromoCode).
 		Msg("Processing booking")
-	booking, err := h.usecase.BookPlace(r.Context(), eventID, req.UserID)
+	booking, payment, err := h.usecase.BookPlace(r.Context(), eventID, principal.UserID, req.Quantity, req.SeatIDs, req.TierID, req.PromoCode)
 	if err != nil {
 		h.logger.Error().
 			Err(err).
 			Str("event_id", eventID).
-			Str("user_id", req.UserID).
+			Str("user_id", principal.UserID).
 			Msg("Booking failed")
 		switch err {
 		case bookingErr.ErrEventNotFound:
 			http.Error(w, "Event not found", http.StatusNotFound)
 		case bookingErr.ErrNoSeatsAvailable:
 			http.Error(w, "No seats available", http.StatusConflict)
+		case bookingErr.ErrAlreadyBooked:
+			http.Error(w, "You already have a booking for this event", http.StatusConflict)
+		case bookingErr.ErrEventCancelled:
+			http.Error(w, "Event has been cancelled", http.StatusGone)
+		case bookingErr.ErrEventCompleted:
+			http.Error(w, "Event has already taken place", http.StatusGone)
+		case bookingErr.ErrEventStarted:
+			http.Error(w, "Event has already started", http.StatusConflict)
+		case bookingErr.ErrInvalidQuantity:
+			http.Error(w, "Quantity must be positive", http.StatusBadRequest)
+		case bookingErr.ErrQuantityTooLarge:
+			http.Error(w, "Quantity exceeds the per-booking limit for this event", http.StatusBadRequest)
+		case bookingErr.ErrSeatsRequired, bookingErr.ErrSeatsNotSupported, bookingErr.ErrSeatCountMismatch, bookingErr.ErrDuplicateSeat:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		case bookingErr.ErrSeatNotFound:
+			http.Error(w, "Seat not found for this event", http.StatusNotFound)
+		case bookingErr.ErrSeatTaken:
+			http.Error(w, "One or more seats are already taken", http.StatusConflict)
+		case bookingErr.ErrTierRequired:
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		case bookingErr.ErrTierNotFound:
+			http.Error(w, "Ticket tier not found for this event", http.StatusNotFound)
+		case bookingErr.ErrTierNotOnSale:
+			http.Error(w, "Ticket tier is not on sale", http.StatusConflict)
+		case bookingErr.ErrTierSoldOut:
+			http.Error(w, "Ticket tier is sold out", http.StatusConflict)
+		case promoErr.ErrPromoNotFound:
+			http.Error(w, "Promo code not found", http.StatusNotFound)
+		case promoErr.ErrPromoNotValid, promoErr.ErrPromoNotApplicable, promoErr.ErrPromoExhausted, promoErr.ErrPromoUserLimit:
+			http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		case paymentErr.ErrPaymentNotConfigured:
+			http.Error(w, "Event price is not configured", http.StatusConflict)
 		default:
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 		}
@@ -84,11 +231,12 @@ func (h *BookingHandler) Book(w http.ResponseWriter, r *http.Request) {
 	h.logger.Info().
 		Str("booking_id", booking.ID).
 		Str("event_id", eventID).
+		Int("quantity", booking.Quantity).
 		Str("status", string(booking.Status)).
 		Msg("Booking successful")
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
-	if err := json.NewEncoder(w).Encode(booking); err != nil {
+	if err := json.NewEncoder(w).Encode(dto.BookResponse{Booking: booking, Payment: payment}); err != nil {
 		h.logger.Error().
 			Err(err).
 			Str("booking_id", booking.ID).
@@ -103,7 +251,7 @@ func (h *BookingHandler) Confirm(w http.ResponseWriter, r *http.Request) {
 		Str("path", r.URL.Path).
 		Str("booking_id", bookingID).
 		Msg("Confirmation request received")
-	if err := h.usecase.ConfirmBooking(r.Context(), bookingID); err != nil {
+	if err := h.usecase.ConfirmBooking(r.Context(), bookingID, middleware.PrincipalFromContext(r.Context())); err != nil {
 		h.logger.Error().
 			Err(err).
 			Str("booking_id", bookingID).
@@ -111,10 +259,14 @@ func (h *BookingHandler) Confirm(w http.ResponseWriter, r *http.Request) {
 		switch err {
 		case bookingErr.ErrBookingNotFound:
 			http.Error(w, "Booking not found", http.StatusNotFound)
+		case bookingErr.ErrForbidden:
+			http.Error(w, "Forbidden", http.StatusForbidden)
 		case bookingErr.ErrBookingNotPending:
 			http.Error(w, "Booking is not pending confirmation", http.StatusBadRequest)
 		case bookingErr.ErrBookingExpired:
 			http.Error(w, "Booking has expired", http.StatusGone)
+		case bookingErr.ErrPaymentRequired:
+			http.Error(w, "Booking is confirmed automatically once payment succeeds", http.StatusPaymentRequired)
 		default:
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 		}
@@ -137,7 +289,8 @@ func (h *BookingHandler) Cancel(w http.ResponseWriter, r *http.Request) {
 		Str("path", r.URL.Path).
 		Str("booking_id", bookingID).
 		Msg("Cancel booking request received")
-	if err := h.usecase.CancelBooking(r.Context(), bookingID); err != nil {
+	refund, err := h.usecase.CancelBooking(r.Context(), bookingID, middleware.PrincipalFromContext(r.Context()))
+	if err != nil {
 		h.logger.Error().
 			Err(err).
 			Str("booking_id", bookingID).
@@ -145,6 +298,8 @@ func (h *BookingHandler) Cancel(w http.ResponseWriter, r *http.Request) {
 		switch err {
 		case bookingErr.ErrBookingNotFound:
 			http.Error(w, "Booking not found", http.StatusNotFound)
+		case bookingErr.ErrForbidden:
+			http.Error(w, "Forbidden", http.StatusForbidden)
 		case bookingErr.ErrAlreadyCancelled:
 			http.Error(w, "Booking is already cancelled", http.StatusConflict)
 		default:
@@ -154,10 +309,12 @@ func (h *BookingHandler) Cancel(w http.ResponseWriter, r *http.Request) {
 	}
 	h.logger.Info().
 		Str("booking_id", bookingID).
+		Bool("refunded", refund != nil).
 		Msg("Booking cancelled successfully")
 	w.WriteHeader(http.StatusOK)
-	json.NewEncoder(w).Encode(map[string]string{
-		"message":    "Booking cancelled successfully",
-		"booking_id": bookingID,
+	json.NewEncoder(w).Encode(dto.CancelResponse{
+		Message:   "Booking cancelled successfully",
+		BookingID: bookingID,
+		Refund:    refund,
 	})
 }
@@ -6,8 +6,9 @@ import (
 )
 
 type bookingUsecase interface {
-	BookPlace(ctx context.Context, eventID, userID string) (*domain.Booking, error)
-	ConfirmBooking(ctx context.Context, bookingID string) error
-	CancelBooking(ctx context.Context, bookingID string) error
-	ListBookings(ctx context.Context) ([]*domain.Booking, error)
+	BookPlace(ctx context.Context, eventID, userID string, quantity int, seatIDs []string, tierID, promoCode string) (*domain.Booking, *domain.Payment, error)
+	ConfirmBooking(ctx context.Context, bookingID string, actor *domain.Principal) error
+	CancelBooking(ctx context.Context, bookingID string, actor *domain.Principal) (*domain.Refund, error)
+	ListBookings(ctx context.Context, filter domain.BookingFilter, actor *domain.Principal) (*domain.Page[*domain.Booking], error)
+	ListUserBookings(ctx context.Context, filter domain.UserBookingFilter, actor *domain.Principal) (*domain.Page[*domain.UserBooking], error)
 }
@@ -1,5 +1,28 @@
 package dto
 
+import "event-booker/internal/domain"
+
+// NextCursorHeader carries the cursor of the next page in list responses.
+const NextCursorHeader = "X-Next-Cursor"
+
+// BookRequest books Quantity places of a general-admission event, or the seats
+// listed in SeatIDs of a reserved-seating event; Quantity then defaults to
+// their number. TierID is required for events with ticket tiers; PromoCode
+// optionally discounts a paid booking.
 type BookRequest struct {
-	UserID string `json:"user_id"`
+	Quantity  int      `json:"quantity,omitempty"`
+	SeatIDs   []string `json:"seat_ids,omitempty"`
+	TierID    string   `json:"tier_id,omitempty"`
+	PromoCode string   `json:"promo_code,omitempty"`
+}
+
+type BookResponse struct {
+	*domain.Booking
+	Payment *domain.Payment `json:",omitempty"`
+}
+
+type CancelResponse struct {
+	Message   string         `json:"message"`
+	BookingID string         `json:"booking_id"`
+	Refund    *domain.Refund `json:"refund,omitempty"`
 }
@@ -2,14 +2,25 @@ package event
 
 import (
 	"context"
+	"time"
 
 	"event-booker/internal/domain"
-	"time"
 )
 
 type eventUsecase interface {
-	CreateEvent(ctx context.Context, name string, date time.Time, totalSeats int, ttl time.Duration, requiresPayment bool) (*domain.Event, error)
+	CreateEvent(ctx context.Context, input *domain.Event, actor *domain.Principal) (*domain.Event, error)
 	GetEvent(ctx context.Context, id string) (*domain.Event, error)
-	ListEvents(ctx context.Context) ([]*domain.Event, error)
-	CancelEvent(ctx context.Context, eventID string, reason string) error
+	ListEvents(ctx context.Context, filter domain.EventFilter) (*domain.Page[*domain.Event], error)
+	ListManagedEvents(ctx context.Context, filter domain.EventFilter, actor *domain.Principal) (*domain.Page[*domain.Event], error)
+	CancelEvent(ctx context.Context, eventID string, reason string, actor *domain.Principal) error
+	UpdateCapacity(ctx context.Context, eventID string, totalSeats int, actor *domain.Principal) (*domain.Event, error)
+	UpdateEvent(ctx context.Context, eventID string, patch *domain.EventPatch, actor *domain.Principal) (*domain.Event, error)
+	CreateTier(ctx context.Context, eventID string, input *domain.TicketTier, actor *domain.Principal) (*domain.TicketTier, error)
+	ListTiers(ctx context.Context, eventID string) ([]*domain.TicketTier, error)
+	UpdateTier(ctx context.Context, eventID, tierID string, input *domain.TicketTier, actor *domain.Principal) (*domain.TicketTier, error)
+	DeleteTier(ctx context.Context, eventID, tierID string, actor *domain.Principal) error
+	CreateSeries(ctx context.Context, input *domain.Event, rule string, actor *domain.Principal) (*domain.EventSeries, []*domain.Event, error)
+	GetSeries(ctx context.Context, id string) (*domain.EventSeries, error)
+	UpdateSeries(ctx context.Context, seriesID string, patch *domain.EventPatch, from time.Time, actor *domain.Principal) ([]*domain.Event, error)
+	CancelSeries(ctx context.Context, seriesID, reason string, from time.Time, actor *domain.Principal) ([]string, error)
 }
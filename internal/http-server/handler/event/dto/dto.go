@@ -1,9 +1,87 @@
 package dto
 
+// NextCursorHeader carries the cursor of the next page in list responses.
+const NextCursorHeader = "X-Next-Cursor"
+
 type CreateEventRequest struct {
 	Name            string `json:"name"`
 	Date            string `json:"date"`
 	TotalSeats      int    `json:"total_seats"`
+	MaxPerBooking   int    `json:"max_per_booking,omitempty"`
 	BookingTTL      string `json:"booking_ttl"`
 	RequiresPayment bool   `json:"requires_payment"`
+	Price           int64  `json:"price,omitempty"`
+	Currency        string `json:"currency,omitempty"`
+	// CancellationPolicy defaults to a full refund up to 24h before the event.
+	CancellationPolicy *CancellationPolicy `json:"cancellation_policy,omitempty"`
+	// LayoutID makes the event reserved-seating; total_seats is then taken
+	// from the layout and may be omitted.
+	LayoutID string `json:"layout_id,omitempty"`
+	// VenueID and OrganizerID are optional; total seats must fit the venue's
+	// capacity.
+	VenueID     string `json:"venue_id,omitempty"`
+	OrganizerID string `json:"organizer_id,omitempty"`
+}
+
+// CancellationPolicy uses Go duration strings (e.g. 72h) for all notice periods.
+type CancellationPolicy struct {
+	FreeWindow string       `json:"free_window"`
+	Cutoff     string       `json:"cutoff"`
+	Tiers      []RefundTier `json:"tiers,omitempty"`
+}
+
+type RefundTier struct {
+	MinNotice string `json:"min_notice"`
+	Percent   int    `json:"percent"`
+}
+
+type UpdateCapacityRequest struct {
+	TotalSeats int `json:"total_seats"`
+}
+
+// UpdateEventRequest carries the fields to change; omitted fields are kept.
+type UpdateEventRequest struct {
+	Name            *string `json:"name,omitempty"`
+	Date            *string `json:"date,omitempty"`
+	TotalSeats      *int    `json:"total_seats,omitempty"`
+	MaxPerBooking   *int    `json:"max_per_booking,omitempty"`
+	BookingTTL      *string `json:"booking_ttl,omitempty"`
+	RequiresPayment *bool   `json:"requires_payment,omitempty"`
+	Price           *int64  `json:"price,omitempty"`
+	Currency        *string `json:"currency,omitempty"`
+	// VenueID and OrganizerID relink the event; an empty string unlinks it.
+	VenueID     *string `json:"venue_id,omitempty"`
+	OrganizerID *string `json:"organizer_id,omitempty"`
+}
+
+// TierRequest describes a ticket tier. Sales bounds are RFC3339 timestamps;
+// omitting one leaves that side of the sales window open. The currency
+// defaults to the event's.
+type TierRequest struct {
+	Name       string  `json:"name"`
+	Price      int64   `json:"price"`
+	Currency   string  `json:"currency,omitempty"`
+	Quota      int     `json:"quota"`
+	SalesStart *string `json:"sales_start,omitempty"`
+	SalesEnd   *string `json:"sales_end,omitempty"`
+}
+
+// CreateSeriesRequest describes the first occurrence of a recurring event
+// together with its RRULE-style recurrence, e.g.
+// FREQ=WEEKLY;BYDAY=TU,TH;COUNT=10 or FREQ=MONTHLY;UNTIL=20251231.
+type CreateSeriesRequest struct {
+	CreateEventRequest
+	Recurrence string `json:"recurrence"`
+}
+
+// UpdateSeriesRequest changes the occurrences dated at or after From (an
+// RFC3339 timestamp, defaulting to now). Date cannot be set.
+type UpdateSeriesRequest struct {
+	UpdateEventRequest
+	From *string `json:"from,omitempty"`
+}
+
+type CancelSeriesRequest struct {
+	Reason string  `json:"reason"`
+	From   *string `json:"from,omitempty"`
 }
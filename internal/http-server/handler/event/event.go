@@ -4,9 +4,13 @@ import (
 	"encoding/json"
 	"errors"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
+	"event-booker/internal/domain"
 	"event-booker/internal/http-server/handler/event/dto"
+	"event-booker/internal/http-server/middleware"
 	eventErr "event-booker/internal/usecase/event"
 
 	"github.com/go-chi/chi/v5"
@@ -35,12 +39,46 @@ func (h *EventHandler) CreateEvent(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Invalid request body", http.StatusBadRequest)
 		return
 	}
+	input, ok := h.parseCreateEvent(w, &req)
+	if !ok {
+		return
+	}
+	event, err := h.usecase.CreateEvent(r.Context(), input, middleware.PrincipalFromContext(r.Context()))
+	if err != nil {
+		h.logger.Error().
+			Err(err).
+			Str("name", req.Name).
+			Msg("Failed to create event")
+		writeCreateEventError(w, err)
+		return
+	}
+	h.logger.Info().
+		Str("event_id", event.ID).
+		Str("name", event.Name).
+		Int("available_seats", event.Available).
+		Msg("Event created successfully")
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(event); err != nil {
+		h.logger.Error().
+			Err(err).
+			Str("event_id", event.ID).
+			Msg("Failed to encode event response")
+	}
+}
+
+// parseCreateEvent validates req and converts it to the usecase input,
+// writing a 400 response and returning false when it is malformed.
+func (h *EventHandler) parseCreateEvent(w http.ResponseWriter, req *dto.CreateEventRequest) (*domain.Event, bool) {
 	h.logger.Debug().
 		Str("name", req.Name).
 		Str("date", req.Date).
 		Int("seats", req.TotalSeats).
+		Int("max_per_booking", req.MaxPerBooking).
 		Str("ttl", req.BookingTTL).
 		Bool("requires_payment", req.RequiresPayment).
+		Int64("price", req.Price).
+		Str("currency", req.Currency).
 		Msg("Parsed create event request")
 	eventDate, err := time.Parse(time.RFC3339, req.Date)
 	if err != nil {
@@ -49,7 +87,7 @@ func (h *EventHandler) CreateEvent(w http.ResponseWriter, r *http.Request) {
 			Str("date_string", req.Date).
 			Msg("Failed to parse event date")
 		http.Error(w, "Invalid date format. Use RFC3339 format (e.g., 2024-01-01T18:00:00Z)", http.StatusBadRequest)
-		return
+		return nil, false
 	}
 	bookingTTL, err := time.ParseDuration(req.BookingTTL)
 	if err != nil {
@@ -58,57 +96,115 @@ func (h *EventHandler) CreateEvent(w http.ResponseWriter, r *http.Request) {
 			Str("ttl_string", req.BookingTTL).
 			Msg("Failed to parse booking TTL")
 		http.Error(w, "Invalid booking_ttl format. Use Go duration format (e.g., 30m, 2h, 24h)", http.StatusBadRequest)
-		return
+		return nil, false
 	}
 	if bookingTTL <= 0 {
 		h.logger.Error().
 			Str("ttl", req.BookingTTL).
 			Msg("Booking TTL must be positive")
 		http.Error(w, "Booking TTL must be positive duration", http.StatusBadRequest)
-		return
+		return nil, false
 	}
 	if eventDate.Before(time.Now()) {
 		h.logger.Error().
 			Time("event_date", eventDate).
 			Msg("Event date is in the past")
 		http.Error(w, "Event date must be in the future", http.StatusBadRequest)
-		return
+		return nil, false
 	}
-	if req.TotalSeats <= 0 {
+	if req.LayoutID == "" && req.TotalSeats <= 0 {
 		h.logger.Error().
 			Int("total_seats", req.TotalSeats).
 			Msg("Total seats must be positive")
 		http.Error(w, "Total seats must be positive", http.StatusBadRequest)
-		return
+		return nil, false
+	}
+	if req.MaxPerBooking < 0 || (req.LayoutID == "" && req.MaxPerBooking > req.TotalSeats) {
+		h.logger.Error().
+			Int("max_per_booking", req.MaxPerBooking).
+			Int("total_seats", req.TotalSeats).
+			Msg("Invalid max per booking")
+		http.Error(w, "Max per booking must be between 0 and total seats", http.StatusBadRequest)
+		return nil, false
+	}
+	if req.Price < 0 {
+		h.logger.Error().
+			Int64("price", req.Price).
+			Msg("Price must not be negative")
+		http.Error(w, "Price must not be negative", http.StatusBadRequest)
+		return nil, false
+	}
+	if req.Currency != "" && len(req.Currency) != 3 {
+		h.logger.Error().
+			Str("currency", req.Currency).
+			Msg("Invalid currency code")
+		http.Error(w, "Currency must be a 3-letter ISO 4217 code", http.StatusBadRequest)
+		return nil, false
+	}
+	policy := domain.DefaultCancellationPolicy()
+	if req.CancellationPolicy != nil {
+		policy, err = parseCancellationPolicy(req.CancellationPolicy)
+		if err != nil {
+			h.logger.Error().
+				Err(err).
+				Msg("Failed to parse cancellation policy")
+			http.Error(w, "Invalid cancellation_policy durations. Use Go duration format (e.g., 24h, 72h)", http.StatusBadRequest)
+			return nil, false
+		}
 	}
 	h.logger.Info().
 		Str("name", req.Name).
 		Time("date", eventDate).
 		Int("seats", req.TotalSeats).
+		Int("max_per_booking", req.MaxPerBooking).
 		Dur("ttl", bookingTTL).
 		Bool("requires_payment", req.RequiresPayment).
 		Msg("Creating new event")
-	event, err := h.usecase.CreateEvent(r.Context(), req.Name, eventDate, req.TotalSeats, bookingTTL, req.RequiresPayment)
-	if err != nil {
-		h.logger.Error().
-			Err(err).
-			Str("name", req.Name).
-			Msg("Failed to create event")
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
+	var layoutID *string
+	if req.LayoutID != "" {
+		layoutID = &req.LayoutID
 	}
-	h.logger.Info().
-		Str("event_id", event.ID).
-		Str("name", event.Name).
-		Int("available_seats", event.Available).
-		Msg("Event created successfully")
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusCreated)
-	if err := json.NewEncoder(w).Encode(event); err != nil {
-		h.logger.Error().
-			Err(err).
-			Str("event_id", event.ID).
-			Msg("Failed to encode event response")
+	event := &domain.Event{
+		Name:            req.Name,
+		Date:            eventDate,
+		TotalSeats:      req.TotalSeats,
+		MaxPerBooking:   req.MaxPerBooking,
+		BookingTTL:      bookingTTL,
+		RequiresPayment: req.RequiresPayment,
+		Price:           req.Price,
+		Currency:        strings.ToUpper(req.Currency),
+		Cancellation:    policy,
+		LayoutID:        layoutID,
+	}
+	if req.VenueID != "" {
+		event.VenueID = &req.VenueID
+	}
+	if req.OrganizerID != "" {
+		event.OrganizerID = &req.OrganizerID
+	}
+	return event, true
+}
+
+func writeCreateEventError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, eventErr.ErrForbidden):
+		http.Error(w, "Forbidden", http.StatusForbidden)
+	case errors.Is(err, eventErr.ErrPriceRequired):
+		http.Error(w, "Paid events must have a positive price", http.StatusBadRequest)
+	case errors.Is(err, eventErr.ErrInvalidPolicy):
+		http.Error(w, "Cancellation policy durations must not be negative, the free window must not be shorter than the cutoff and percentages must be between 0 and 100", http.StatusBadRequest)
+	case errors.Is(err, eventErr.ErrLayoutNotFound):
+		http.Error(w, "Seat layout not found", http.StatusBadRequest)
+	case errors.Is(err, eventErr.ErrVenueNotFound):
+		http.Error(w, "Venue not found", http.StatusBadRequest)
+	case errors.Is(err, eventErr.ErrOrganizerNotFound):
+		http.Error(w, "Organizer not found", http.StatusBadRequest)
+	case errors.Is(err, eventErr.ErrInvalidCapacity),
+		errors.Is(err, eventErr.ErrInvalidMaxPerBooking),
+		errors.Is(err, eventErr.ErrCapacityExceedsVenue):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
 	}
 }
 
@@ -146,30 +242,110 @@ func (h *EventHandler) GetEvent(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// ListEvents returns one page of the public catalog as a JSON array; the
+// cursor for the following page, if any, is sent in the X-Next-Cursor header.
 func (h *EventHandler) ListEvents(w http.ResponseWriter, r *http.Request) {
+	h.listEvents(w, r, func(filter domain.EventFilter) (*domain.Page[*domain.Event], error) {
+		return h.usecase.ListEvents(r.Context(), filter)
+	})
+}
+
+// ListManagedEvents lists the events of the caller's organizers, or all
+// events for admins, in the same format as ListEvents.
+func (h *EventHandler) ListManagedEvents(w http.ResponseWriter, r *http.Request) {
+	h.listEvents(w, r, func(filter domain.EventFilter) (*domain.Page[*domain.Event], error) {
+		return h.usecase.ListManagedEvents(r.Context(), filter, middleware.PrincipalFromContext(r.Context()))
+	})
+}
+
+func (h *EventHandler) listEvents(w http.ResponseWriter, r *http.Request, list func(domain.EventFilter) (*domain.Page[*domain.Event], error)) {
 	h.logger.Info().
 		Str("method", r.Method).
 		Str("path", r.URL.Path).
+		Str("query", r.URL.RawQuery).
 		Msg("List events request received")
-	events, err := h.usecase.ListEvents(r.Context())
+	filter, err := parseEventFilter(r)
+	if err != nil {
+		h.logger.Error().
+			Err(err).
+			Msg("Failed to parse event filter")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	page, err := list(filter)
 	if err != nil {
 		h.logger.Error().
 			Err(err).
 			Msg("Failed to list events")
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		switch {
+		case errors.Is(err, eventErr.ErrForbidden):
+			http.Error(w, "Forbidden", http.StatusForbidden)
+		case errors.Is(err, eventErr.ErrInvalidFilter):
+			http.Error(w, "Invalid filter. Status must be active, cancelled or completed; sort must be date_asc, date_desc, created_desc or name_asc", http.StatusBadRequest)
+		case errors.Is(err, eventErr.ErrInvalidCursor):
+			http.Error(w, "Invalid cursor", http.StatusBadRequest)
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
 		return
 	}
 	h.logger.Info().
-		Int("count", len(events)).
+		Int("count", len(page.Items)).
+		Bool("has_more", page.NextCursor != "").
 		Msg("Events listed successfully")
 	w.Header().Set("Content-Type", "application/json")
-	if err := json.NewEncoder(w).Encode(events); err != nil {
+	if page.NextCursor != "" {
+		w.Header().Set(dto.NextCursorHeader, page.NextCursor)
+	}
+	if err := json.NewEncoder(w).Encode(page.Items); err != nil {
 		h.logger.Error().
 			Err(err).
 			Msg("Failed to encode events response")
 	}
 }
 
+func parseEventFilter(r *http.Request) (domain.EventFilter, error) {
+	q := r.URL.Query()
+	filter := domain.EventFilter{
+		Status:      domain.EventStatus(q.Get("status")),
+		Search:      q.Get("q"),
+		Sort:        domain.EventSort(q.Get("sort")),
+		Cursor:      q.Get("cursor"),
+		SeriesID:    q.Get("series_id"),
+		VenueID:     q.Get("venue_id"),
+		OrganizerID: q.Get("organizer_id"),
+	}
+	if v := q.Get("from"); v != "" {
+		from, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return filter, errors.New("invalid from, use RFC3339 format")
+		}
+		filter.DateFrom = &from
+	}
+	if v := q.Get("to"); v != "" {
+		to, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return filter, errors.New("invalid to, use RFC3339 format")
+		}
+		filter.DateTo = &to
+	}
+	if v := q.Get("requires_payment"); v != "" {
+		requiresPayment, err := strconv.ParseBool(v)
+		if err != nil {
+			return filter, errors.New("invalid requires_payment, use true or false")
+		}
+		filter.RequiresPayment = &requiresPayment
+	}
+	if v := q.Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil {
+			return filter, errors.New("invalid limit")
+		}
+		filter.Limit = limit
+	}
+	return filter, nil
+}
+
 func (h *EventHandler) DeleteEvent(w http.ResponseWriter, r *http.Request) {
 	eventID := chi.URLParam(r, "id")
 	h.logger.Info().
@@ -192,14 +368,17 @@ func (h *EventHandler) DeleteEvent(w http.ResponseWriter, r *http.Request) {
 		Str("event_id", eventID).
 		Str("reason", req.Reason).
 		Msg("Processing event cancellation")
-	if err := h.usecase.CancelEvent(r.Context(), eventID, req.Reason); err != nil {
+	if err := h.usecase.CancelEvent(r.Context(), eventID, req.Reason, middleware.PrincipalFromContext(r.Context())); err != nil {
 		h.logger.Error().
 			Err(err).
 			Str("event_id", eventID).
 			Msg("Event cancellation failed")
-		if errors.Is(err, eventErr.ErrEventNotFound) {
+		switch {
+		case errors.Is(err, eventErr.ErrEventNotFound):
 			http.Error(w, "Event not found", http.StatusNotFound)
-		} else {
+		case errors.Is(err, eventErr.ErrForbidden):
+			http.Error(w, "Forbidden", http.StatusForbidden)
+		default:
 			http.Error(w, err.Error(), http.StatusBadRequest)
 		}
 		return
@@ -215,3 +394,199 @@ func (h *EventHandler) DeleteEvent(w http.ResponseWriter, r *http.Request) {
 		"reason":   req.Reason,
 	})
 }
+
+func (h *EventHandler) UpdateCapacity(w http.ResponseWriter, r *http.Request) {
+	eventID := chi.URLParam(r, "id")
+	h.logger.Info().
+		Str("method", r.Method).
+		Str("path", r.URL.Path).
+		Str("event_id", eventID).
+		Msg("Update capacity request received")
+	var req dto.UpdateCapacityRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Error().
+			Err(err).
+			Str("event_id", eventID).
+			Msg("Failed to decode update capacity request")
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	event, err := h.usecase.UpdateCapacity(r.Context(), eventID, req.TotalSeats, middleware.PrincipalFromContext(r.Context()))
+	if err != nil {
+		h.logger.Error().
+			Err(err).
+			Str("event_id", eventID).
+			Int("total_seats", req.TotalSeats).
+			Msg("Failed to update event capacity")
+		switch {
+		case errors.Is(err, eventErr.ErrEventNotFound):
+			http.Error(w, "Event not found", http.StatusNotFound)
+		case errors.Is(err, eventErr.ErrForbidden):
+			http.Error(w, "Forbidden", http.StatusForbidden)
+		case errors.Is(err, eventErr.ErrEventNotActive):
+			http.Error(w, "Event is not active", http.StatusConflict)
+		case errors.Is(err, eventErr.ErrInvalidCapacity),
+			errors.Is(err, eventErr.ErrCapacityBelowReserved):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		case errors.Is(err, eventErr.ErrCapacityFixedByLayout),
+			errors.Is(err, eventErr.ErrQuotaExceedsCapacity),
+			errors.Is(err, eventErr.ErrCapacityExceedsVenue):
+			http.Error(w, err.Error(), http.StatusConflict)
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+	h.logger.Info().
+		Str("event_id", event.ID).
+		Int("total_seats", event.TotalSeats).
+		Int("available_seats", event.Available).
+		Msg("Event capacity updated successfully")
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(event); err != nil {
+		h.logger.Error().
+			Err(err).
+			Str("event_id", event.ID).
+			Msg("Failed to encode event response")
+	}
+}
+
+func (h *EventHandler) UpdateEvent(w http.ResponseWriter, r *http.Request) {
+	eventID := chi.URLParam(r, "id")
+	h.logger.Info().
+		Str("method", r.Method).
+		Str("path", r.URL.Path).
+		Str("event_id", eventID).
+		Msg("Update event request received")
+	var req dto.UpdateEventRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Error().
+			Err(err).
+			Str("event_id", eventID).
+			Msg("Failed to decode update event request")
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	patch, ok := h.parseEventPatch(w, &req)
+	if !ok {
+		return
+	}
+	event, err := h.usecase.UpdateEvent(r.Context(), eventID, patch, middleware.PrincipalFromContext(r.Context()))
+	if err != nil {
+		h.logger.Error().
+			Err(err).
+			Str("event_id", eventID).
+			Msg("Failed to update event")
+		writeUpdateEventError(w, err)
+		return
+	}
+	h.logger.Info().
+		Str("event_id", event.ID).
+		Str("name", event.Name).
+		Int("total_seats", event.TotalSeats).
+		Int("available_seats", event.Available).
+		Msg("Event updated successfully")
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(event); err != nil {
+		h.logger.Error().
+			Err(err).
+			Str("event_id", event.ID).
+			Msg("Failed to encode event response")
+	}
+}
+
+func (h *EventHandler) parseEventPatch(w http.ResponseWriter, req *dto.UpdateEventRequest) (*domain.EventPatch, bool) {
+	patch := &domain.EventPatch{
+		Name:            req.Name,
+		TotalSeats:      req.TotalSeats,
+		MaxPerBooking:   req.MaxPerBooking,
+		RequiresPayment: req.RequiresPayment,
+		Price:           req.Price,
+		VenueID:         req.VenueID,
+		OrganizerID:     req.OrganizerID,
+	}
+	if req.Date != nil {
+		date, err := time.Parse(time.RFC3339, *req.Date)
+		if err != nil {
+			h.logger.Error().
+				Err(err).
+				Str("date_string", *req.Date).
+				Msg("Failed to parse event date")
+			http.Error(w, "Invalid date format. Use RFC3339 format (e.g., 2024-01-01T18:00:00Z)", http.StatusBadRequest)
+			return nil, false
+		}
+		patch.Date = &date
+	}
+	if req.BookingTTL != nil {
+		ttl, err := time.ParseDuration(*req.BookingTTL)
+		if err != nil {
+			h.logger.Error().
+				Err(err).
+				Str("ttl_string", *req.BookingTTL).
+				Msg("Failed to parse booking TTL")
+			http.Error(w, "Invalid booking_ttl format. Use Go duration format (e.g., 30m, 2h, 24h)", http.StatusBadRequest)
+			return nil, false
+		}
+		patch.BookingTTL = &ttl
+	}
+	if req.Currency != nil {
+		currency := strings.ToUpper(*req.Currency)
+		patch.Currency = &currency
+	}
+	return patch, true
+}
+
+func writeUpdateEventError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, eventErr.ErrEventNotFound):
+		http.Error(w, "Event not found", http.StatusNotFound)
+	case errors.Is(err, eventErr.ErrForbidden):
+		http.Error(w, "Forbidden", http.StatusForbidden)
+	case errors.Is(err, eventErr.ErrEventNotActive):
+		http.Error(w, "Event is not active", http.StatusConflict)
+	case errors.Is(err, eventErr.ErrCapacityBelowReserved):
+		http.Error(w, "Total seats cannot be lower than seats held by pending and confirmed bookings", http.StatusConflict)
+	case errors.Is(err, eventErr.ErrCapacityFixedByLayout),
+		errors.Is(err, eventErr.ErrQuotaExceedsCapacity),
+		errors.Is(err, eventErr.ErrCapacityExceedsVenue):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case errors.Is(err, eventErr.ErrVenueNotFound):
+		http.Error(w, "Venue not found", http.StatusBadRequest)
+	case errors.Is(err, eventErr.ErrOrganizerNotFound):
+		http.Error(w, "Organizer not found", http.StatusBadRequest)
+	case errors.Is(err, eventErr.ErrInvalidName),
+		errors.Is(err, eventErr.ErrEventDateInPast),
+		errors.Is(err, eventErr.ErrInvalidBookingTTL),
+		errors.Is(err, eventErr.ErrInvalidCapacity),
+		errors.Is(err, eventErr.ErrInvalidMaxPerBooking),
+		errors.Is(err, eventErr.ErrInvalidPrice),
+		errors.Is(err, eventErr.ErrInvalidCurrency),
+		errors.Is(err, eventErr.ErrPriceRequired):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func parseCancellationPolicy(req *dto.CancellationPolicy) (domain.CancellationPolicy, error) {
+	var policy domain.CancellationPolicy
+	var err error
+	if req.FreeWindow != "" {
+		if policy.FreeWindow, err = time.ParseDuration(req.FreeWindow); err != nil {
+			return policy, err
+		}
+	}
+	if req.Cutoff != "" {
+		if policy.Cutoff, err = time.ParseDuration(req.Cutoff); err != nil {
+			return policy, err
+		}
+	}
+	for _, tier := range req.Tiers {
+		notice, err := time.ParseDuration(tier.MinNotice)
+		if err != nil {
+			return policy, err
+		}
+		policy.Tiers = append(policy.Tiers, domain.RefundTier{MinNotice: notice, Percent: tier.Percent})
+	}
+	return policy, nil
+}
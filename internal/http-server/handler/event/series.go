@@ -0,0 +1,207 @@
+package event
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"event-booker/internal/http-server/handler/event/dto"
+	"event-booker/internal/http-server/middleware"
+	eventErr "event-booker/internal/usecase/event"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func (h *EventHandler) CreateSeries(w http.ResponseWriter, r *http.Request) {
+	h.logger.Info().
+		Str("method", r.Method).
+		Str("path", r.URL.Path).
+		Msg("Create event series request received")
+	var req dto.CreateSeriesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Error().
+			Err(err).
+			Msg("Failed to decode create event series request")
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	input, ok := h.parseCreateEvent(w, &req.CreateEventRequest)
+	if !ok {
+		return
+	}
+	series, events, err := h.usecase.CreateSeries(r.Context(), input, req.Recurrence, middleware.PrincipalFromContext(r.Context()))
+	if err != nil {
+		h.logger.Error().
+			Err(err).
+			Str("name", req.Name).
+			Str("recurrence", req.Recurrence).
+			Msg("Failed to create event series")
+		switch {
+		case errors.Is(err, eventErr.ErrInvalidRecurrence):
+			http.Error(w, "Invalid recurrence. Use RRULE format with FREQ=WEEKLY or FREQ=MONTHLY and COUNT or UNTIL (e.g., FREQ=WEEKLY;BYDAY=TU,TH;COUNT=10)", http.StatusBadRequest)
+		case errors.Is(err, eventErr.ErrTooManyOccurrences),
+			errors.Is(err, eventErr.ErrNoOccurrences):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		default:
+			writeCreateEventError(w, err)
+		}
+		return
+	}
+	h.logger.Info().
+		Str("series_id", series.ID).
+		Str("name", series.Name).
+		Int("occurrences", len(events)).
+		Msg("Event series created successfully")
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(map[string]any{
+		"series": series,
+		"events": events,
+	}); err != nil {
+		h.logger.Error().
+			Err(err).
+			Str("series_id", series.ID).
+			Msg("Failed to encode event series response")
+	}
+}
+
+// GetSeries returns the series definition; its occurrences are listed through
+// ListEvents with the series_id filter.
+func (h *EventHandler) GetSeries(w http.ResponseWriter, r *http.Request) {
+	seriesID := chi.URLParam(r, "id")
+	series, err := h.usecase.GetSeries(r.Context(), seriesID)
+	if err != nil {
+		h.logger.Error().
+			Err(err).
+			Str("series_id", seriesID).
+			Msg("Failed to get event series")
+		if errors.Is(err, eventErr.ErrSeriesNotFound) {
+			http.Error(w, "Event series not found", http.StatusNotFound)
+		} else {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(series); err != nil {
+		h.logger.Error().
+			Err(err).
+			Str("series_id", seriesID).
+			Msg("Failed to encode event series response")
+	}
+}
+
+func (h *EventHandler) UpdateSeries(w http.ResponseWriter, r *http.Request) {
+	seriesID := chi.URLParam(r, "id")
+	h.logger.Info().
+		Str("method", r.Method).
+		Str("path", r.URL.Path).
+		Str("series_id", seriesID).
+		Msg("Update event series request received")
+	var req dto.UpdateSeriesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Error().
+			Err(err).
+			Str("series_id", seriesID).
+			Msg("Failed to decode update event series request")
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	from, err := parseSeriesFrom(req.From)
+	if err != nil {
+		http.Error(w, "Invalid from. Use RFC3339 format (e.g., 2024-01-01T18:00:00Z)", http.StatusBadRequest)
+		return
+	}
+	patch, ok := h.parseEventPatch(w, &req.UpdateEventRequest)
+	if !ok {
+		return
+	}
+	events, err := h.usecase.UpdateSeries(r.Context(), seriesID, patch, from, middleware.PrincipalFromContext(r.Context()))
+	if err != nil {
+		h.logger.Error().
+			Err(err).
+			Str("series_id", seriesID).
+			Msg("Failed to update event series")
+		switch {
+		case errors.Is(err, eventErr.ErrSeriesNotFound):
+			http.Error(w, "Event series not found", http.StatusNotFound)
+		case errors.Is(err, eventErr.ErrSeriesDatePatch):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		default:
+			writeUpdateEventError(w, err)
+		}
+		return
+	}
+	h.logger.Info().
+		Str("series_id", seriesID).
+		Int("updated", len(events)).
+		Msg("Event series updated successfully")
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(events); err != nil {
+		h.logger.Error().
+			Err(err).
+			Str("series_id", seriesID).
+			Msg("Failed to encode events response")
+	}
+}
+
+func (h *EventHandler) CancelSeries(w http.ResponseWriter, r *http.Request) {
+	seriesID := chi.URLParam(r, "id")
+	h.logger.Info().
+		Str("method", r.Method).
+		Str("path", r.URL.Path).
+		Str("series_id", seriesID).
+		Msg("Cancel event series request received")
+	var req dto.CancelSeriesRequest
+	if r.ContentLength > 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			h.logger.Error().
+				Err(err).
+				Str("series_id", seriesID).
+				Msg("Failed to decode cancel event series request")
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+	}
+	from, err := parseSeriesFrom(req.From)
+	if err != nil {
+		http.Error(w, "Invalid from. Use RFC3339 format (e.g., 2024-01-01T18:00:00Z)", http.StatusBadRequest)
+		return
+	}
+	cancelled, err := h.usecase.CancelSeries(r.Context(), seriesID, req.Reason, from, middleware.PrincipalFromContext(r.Context()))
+	if err != nil {
+		h.logger.Error().
+			Err(err).
+			Str("series_id", seriesID).
+			Msg("Event series cancellation failed")
+		switch {
+		case errors.Is(err, eventErr.ErrSeriesNotFound):
+			http.Error(w, "Event series not found", http.StatusNotFound)
+		case errors.Is(err, eventErr.ErrForbidden):
+			http.Error(w, "Forbidden", http.StatusForbidden)
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+	h.logger.Info().
+		Str("series_id", seriesID).
+		Int("cancelled_events", len(cancelled)).
+		Msg("Event series cancelled successfully")
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]any{
+		"message":   "Event series cancelled successfully",
+		"series_id": seriesID,
+		"reason":    req.Reason,
+		"cancelled": cancelled,
+	})
+}
+
+func parseSeriesFrom(value *string) (time.Time, error) {
+	from, err := parseOptionalTime(value)
+	if err != nil || from == nil {
+		return time.Time{}, err
+	}
+	return *from, nil
+}
@@ -0,0 +1,196 @@
+package event
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"event-booker/internal/domain"
+	"event-booker/internal/http-server/handler/event/dto"
+	"event-booker/internal/http-server/middleware"
+	eventErr "event-booker/internal/usecase/event"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func (h *EventHandler) ListTiers(w http.ResponseWriter, r *http.Request) {
+	eventID := chi.URLParam(r, "id")
+	h.logger.Info().
+		Str("method", r.Method).
+		Str("path", r.URL.Path).
+		Str("event_id", eventID).
+		Msg("List ticket tiers request received")
+	tiers, err := h.usecase.ListTiers(r.Context(), eventID)
+	if err != nil {
+		h.logger.Error().
+			Err(err).
+			Str("event_id", eventID).
+			Msg("Failed to list ticket tiers")
+		if errors.Is(err, eventErr.ErrEventNotFound) {
+			http.Error(w, "Event not found", http.StatusNotFound)
+		} else {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(tiers); err != nil {
+		h.logger.Error().
+			Err(err).
+			Str("event_id", eventID).
+			Msg("Failed to encode ticket tiers response")
+	}
+}
+
+func (h *EventHandler) CreateTier(w http.ResponseWriter, r *http.Request) {
+	eventID := chi.URLParam(r, "id")
+	h.logger.Info().
+		Str("method", r.Method).
+		Str("path", r.URL.Path).
+		Str("event_id", eventID).
+		Msg("Create ticket tier request received")
+	input, ok := h.decodeTier(w, r)
+	if !ok {
+		return
+	}
+	tier, err := h.usecase.CreateTier(r.Context(), eventID, input, middleware.PrincipalFromContext(r.Context()))
+	if err != nil {
+		h.logger.Error().
+			Err(err).
+			Str("event_id", eventID).
+			Str("name", input.Name).
+			Msg("Failed to create ticket tier")
+		writeTierError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(tier); err != nil {
+		h.logger.Error().
+			Err(err).
+			Str("tier_id", tier.ID).
+			Msg("Failed to encode ticket tier response")
+	}
+}
+
+func (h *EventHandler) UpdateTier(w http.ResponseWriter, r *http.Request) {
+	eventID := chi.URLParam(r, "id")
+	tierID := chi.URLParam(r, "tierID")
+	h.logger.Info().
+		Str("method", r.Method).
+		Str("path", r.URL.Path).
+		Str("event_id", eventID).
+		Str("tier_id", tierID).
+		Msg("Update ticket tier request received")
+	input, ok := h.decodeTier(w, r)
+	if !ok {
+		return
+	}
+	tier, err := h.usecase.UpdateTier(r.Context(), eventID, tierID, input, middleware.PrincipalFromContext(r.Context()))
+	if err != nil {
+		h.logger.Error().
+			Err(err).
+			Str("event_id", eventID).
+			Str("tier_id", tierID).
+			Msg("Failed to update ticket tier")
+		writeTierError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(tier); err != nil {
+		h.logger.Error().
+			Err(err).
+			Str("tier_id", tier.ID).
+			Msg("Failed to encode ticket tier response")
+	}
+}
+
+func (h *EventHandler) DeleteTier(w http.ResponseWriter, r *http.Request) {
+	eventID := chi.URLParam(r, "id")
+	tierID := chi.URLParam(r, "tierID")
+	h.logger.Info().
+		Str("method", r.Method).
+		Str("path", r.URL.Path).
+		Str("event_id", eventID).
+		Str("tier_id", tierID).
+		Msg("Delete ticket tier request received")
+	if err := h.usecase.DeleteTier(r.Context(), eventID, tierID, middleware.PrincipalFromContext(r.Context())); err != nil {
+		h.logger.Error().
+			Err(err).
+			Str("event_id", eventID).
+			Str("tier_id", tierID).
+			Msg("Failed to delete ticket tier")
+		writeTierError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *EventHandler) decodeTier(w http.ResponseWriter, r *http.Request) (*domain.TicketTier, bool) {
+	var req dto.TierRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Error().
+			Err(err).
+			Msg("Failed to decode ticket tier request")
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return nil, false
+	}
+	tier := &domain.TicketTier{
+		Name:     strings.TrimSpace(req.Name),
+		Price:    req.Price,
+		Currency: strings.ToUpper(req.Currency),
+		Quota:    req.Quota,
+	}
+	var err error
+	if tier.SalesStart, err = parseOptionalTime(req.SalesStart); err == nil {
+		tier.SalesEnd, err = parseOptionalTime(req.SalesEnd)
+	}
+	if err != nil {
+		h.logger.Error().
+			Err(err).
+			Msg("Failed to parse ticket tier sales window")
+		http.Error(w, "Invalid sales window. Use RFC3339 format (e.g., 2024-01-01T18:00:00Z)", http.StatusBadRequest)
+		return nil, false
+	}
+	return tier, true
+}
+
+func parseOptionalTime(value *string) (*time.Time, error) {
+	if value == nil {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, *value)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func writeTierError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, eventErr.ErrEventNotFound):
+		http.Error(w, "Event not found", http.StatusNotFound)
+	case errors.Is(err, eventErr.ErrTierNotFound):
+		http.Error(w, "Ticket tier not found", http.StatusNotFound)
+	case errors.Is(err, eventErr.ErrForbidden):
+		http.Error(w, "Forbidden", http.StatusForbidden)
+	case errors.Is(err, eventErr.ErrEventNotActive):
+		http.Error(w, "Event is not active", http.StatusConflict)
+	case errors.Is(err, eventErr.ErrTierExists),
+		errors.Is(err, eventErr.ErrTierInUse),
+		errors.Is(err, eventErr.ErrQuotaBelowReserved),
+		errors.Is(err, eventErr.ErrQuotaExceedsCapacity):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case errors.Is(err, eventErr.ErrInvalidTierName),
+		errors.Is(err, eventErr.ErrInvalidPrice),
+		errors.Is(err, eventErr.ErrPriceRequired),
+		errors.Is(err, eventErr.ErrInvalidCurrency),
+		errors.Is(err, eventErr.ErrInvalidQuota),
+		errors.Is(err, eventErr.ErrInvalidSalesWindow):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,18 @@
+package organizer
+
+import (
+	"context"
+
+	"event-booker/internal/domain"
+)
+
+type organizerUsecase interface {
+	CreateOrganizer(ctx context.Context, input *domain.Organizer, actor *domain.Principal) (*domain.Organizer, error)
+	GetOrganizer(ctx context.Context, id string) (*domain.Organizer, error)
+	ListOrganizers(ctx context.Context) ([]*domain.Organizer, error)
+	UpdateOrganizer(ctx context.Context, id string, patch *domain.OrganizerPatch, actor *domain.Principal) (*domain.Organizer, error)
+	DeleteOrganizer(ctx context.Context, id string, actor *domain.Principal) error
+	ListMembers(ctx context.Context, organizerID string, actor *domain.Principal) ([]*domain.OrganizerMember, error)
+	SetMember(ctx context.Context, organizerID, userID string, role domain.OrganizerRole, actor *domain.Principal) (*domain.OrganizerMember, error)
+	RemoveMember(ctx context.Context, organizerID, userID string, actor *domain.Principal) error
+}
@@ -0,0 +1,20 @@
+package dto
+
+type CreateOrganizerRequest struct {
+	Name    string `json:"name"`
+	Email   string `json:"email,omitempty"`
+	Website string `json:"website,omitempty"`
+}
+
+// UpdateOrganizerRequest carries a partial update; omitted fields keep their
+// current values.
+type UpdateOrganizerRequest struct {
+	Name    *string `json:"name,omitempty"`
+	Email   *string `json:"email,omitempty"`
+	Website *string `json:"website,omitempty"`
+}
+
+// SetMemberRequest assigns a role: owner, manager or checkin.
+type SetMemberRequest struct {
+	Role string `json:"role"`
+}
@@ -0,0 +1,203 @@
+package organizer
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"event-booker/internal/domain"
+	"event-booker/internal/http-server/handler/organizer/dto"
+	"event-booker/internal/http-server/middleware"
+	organizerErr "event-booker/internal/usecase/organizer"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/wb-go/wbf/zlog"
+)
+
+type OrganizerHandler struct {
+	usecase organizerUsecase
+	logger  *zlog.Zerolog
+}
+
+func NewOrganizerHandler(usecase organizerUsecase, logger *zlog.Zerolog) *OrganizerHandler {
+	return &OrganizerHandler{usecase: usecase, logger: logger}
+}
+
+func (h *OrganizerHandler) CreateOrganizer(w http.ResponseWriter, r *http.Request) {
+	h.logger.Info().
+		Str("method", r.Method).
+		Str("path", r.URL.Path).
+		Msg("Create organizer request received")
+	var req dto.CreateOrganizerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Failed to decode organizer request")
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	organizer, err := h.usecase.CreateOrganizer(r.Context(), &domain.Organizer{
+		Name:    req.Name,
+		Email:   req.Email,
+		Website: req.Website,
+	}, middleware.PrincipalFromContext(r.Context()))
+	if err != nil {
+		h.logger.Error().Err(err).Str("name", req.Name).Msg("Failed to create organizer")
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(organizer); err != nil {
+		h.logger.Error().Err(err).Str("organizer_id", organizer.ID).Msg("Failed to encode organizer")
+	}
+}
+
+func (h *OrganizerHandler) ListOrganizers(w http.ResponseWriter, r *http.Request) {
+	organizers, err := h.usecase.ListOrganizers(r.Context())
+	if err != nil {
+		h.logger.Error().Err(err).Msg("Failed to list organizers")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(organizers); err != nil {
+		h.logger.Error().Err(err).Msg("Failed to encode organizers")
+	}
+}
+
+func (h *OrganizerHandler) GetOrganizer(w http.ResponseWriter, r *http.Request) {
+	organizerID := chi.URLParam(r, "id")
+	organizer, err := h.usecase.GetOrganizer(r.Context(), organizerID)
+	if err != nil {
+		h.logger.Error().Err(err).Str("organizer_id", organizerID).Msg("Failed to get organizer")
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(organizer); err != nil {
+		h.logger.Error().Err(err).Str("organizer_id", organizerID).Msg("Failed to encode organizer")
+	}
+}
+
+func (h *OrganizerHandler) UpdateOrganizer(w http.ResponseWriter, r *http.Request) {
+	organizerID := chi.URLParam(r, "id")
+	h.logger.Info().
+		Str("method", r.Method).
+		Str("path", r.URL.Path).
+		Str("organizer_id", organizerID).
+		Msg("Update organizer request received")
+	var req dto.UpdateOrganizerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Error().Err(err).Str("organizer_id", organizerID).Msg("Failed to decode organizer update")
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	organizer, err := h.usecase.UpdateOrganizer(r.Context(), organizerID, &domain.OrganizerPatch{
+		Name:    req.Name,
+		Email:   req.Email,
+		Website: req.Website,
+	}, middleware.PrincipalFromContext(r.Context()))
+	if err != nil {
+		h.logger.Error().Err(err).Str("organizer_id", organizerID).Msg("Failed to update organizer")
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(organizer); err != nil {
+		h.logger.Error().Err(err).Str("organizer_id", organizerID).Msg("Failed to encode organizer")
+	}
+}
+
+func (h *OrganizerHandler) DeleteOrganizer(w http.ResponseWriter, r *http.Request) {
+	organizerID := chi.URLParam(r, "id")
+	h.logger.Info().
+		Str("method", r.Method).
+		Str("path", r.URL.Path).
+		Str("organizer_id", organizerID).
+		Msg("Delete organizer request received")
+	if err := h.usecase.DeleteOrganizer(r.Context(), organizerID, middleware.PrincipalFromContext(r.Context())); err != nil {
+		h.logger.Error().Err(err).Str("organizer_id", organizerID).Msg("Failed to delete organizer")
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *OrganizerHandler) ListMembers(w http.ResponseWriter, r *http.Request) {
+	organizerID := chi.URLParam(r, "id")
+	members, err := h.usecase.ListMembers(r.Context(), organizerID, middleware.PrincipalFromContext(r.Context()))
+	if err != nil {
+		h.logger.Error().Err(err).Str("organizer_id", organizerID).Msg("Failed to list organizer members")
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(members); err != nil {
+		h.logger.Error().Err(err).Str("organizer_id", organizerID).Msg("Failed to encode organizer members")
+	}
+}
+
+func (h *OrganizerHandler) SetMember(w http.ResponseWriter, r *http.Request) {
+	organizerID := chi.URLParam(r, "id")
+	userID := chi.URLParam(r, "userID")
+	h.logger.Info().
+		Str("method", r.Method).
+		Str("path", r.URL.Path).
+		Str("organizer_id", organizerID).
+		Str("user_id", userID).
+		Msg("Set organizer member request received")
+	var req dto.SetMemberRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Error().Err(err).Str("organizer_id", organizerID).Msg("Failed to decode organizer member request")
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	member, err := h.usecase.SetMember(r.Context(), organizerID, userID, domain.OrganizerRole(req.Role), middleware.PrincipalFromContext(r.Context()))
+	if err != nil {
+		h.logger.Error().Err(err).Str("organizer_id", organizerID).Str("user_id", userID).Msg("Failed to set organizer member")
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(member); err != nil {
+		h.logger.Error().Err(err).Str("organizer_id", organizerID).Msg("Failed to encode organizer member")
+	}
+}
+
+func (h *OrganizerHandler) RemoveMember(w http.ResponseWriter, r *http.Request) {
+	organizerID := chi.URLParam(r, "id")
+	userID := chi.URLParam(r, "userID")
+	h.logger.Info().
+		Str("method", r.Method).
+		Str("path", r.URL.Path).
+		Str("organizer_id", organizerID).
+		Str("user_id", userID).
+		Msg("Remove organizer member request received")
+	if err := h.usecase.RemoveMember(r.Context(), organizerID, userID, middleware.PrincipalFromContext(r.Context())); err != nil {
+		h.logger.Error().Err(err).Str("organizer_id", organizerID).Str("user_id", userID).Msg("Failed to remove organizer member")
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, organizerErr.ErrOrganizerNotFound):
+		http.Error(w, "Organizer not found", http.StatusNotFound)
+	case errors.Is(err, organizerErr.ErrUserNotFound),
+		errors.Is(err, organizerErr.ErrMemberNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case errors.Is(err, organizerErr.ErrForbidden):
+		http.Error(w, "Forbidden", http.StatusForbidden)
+	case errors.Is(err, organizerErr.ErrOrganizerExists),
+		errors.Is(err, organizerErr.ErrOrganizerInUse),
+		errors.Is(err, organizerErr.ErrLastOwner):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case errors.Is(err, organizerErr.ErrInvalidName),
+		errors.Is(err, organizerErr.ErrInvalidEmail),
+		errors.Is(err, organizerErr.ErrInvalidRole):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,12 @@
+package outbox
+
+import (
+	"context"
+
+	"event-booker/internal/domain"
+)
+
+type outboxUsecase interface {
+	ListMessages(ctx context.Context, status domain.OutboxStatus) ([]*domain.OutboxMessage, error)
+	ReplayMessage(ctx context.Context, id string) (*domain.OutboxMessage, error)
+}
@@ -0,0 +1,77 @@
+package outbox
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"event-booker/internal/domain"
+	outboxErr "event-booker/internal/usecase/outbox"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/wb-go/wbf/zlog"
+)
+
+type OutboxHandler struct {
+	usecase outboxUsecase
+	logger  *zlog.Zerolog
+}
+
+func NewOutboxHandler(usecase outboxUsecase, logger *zlog.Zerolog) *OutboxHandler {
+	return &OutboxHandler{usecase: usecase, logger: logger}
+}
+
+func (h *OutboxHandler) ListMessages(w http.ResponseWriter, r *http.Request) {
+	status := domain.OutboxStatus(r.URL.Query().Get("status"))
+	h.logger.Info().
+		Str("method", r.Method).
+		Str("path", r.URL.Path).
+		Str("status", string(status)).
+		Msg("List outbox messages request received")
+	messages, err := h.usecase.ListMessages(r.Context(), status)
+	if err != nil {
+		h.logger.Error().Err(err).Msg("Failed to list outbox messages")
+		if errors.Is(err, outboxErr.ErrInvalidStatus) {
+			http.Error(w, "Invalid status, use pending, sent or dead", http.StatusBadRequest)
+		} else {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(messages); err != nil {
+		h.logger.Error().Err(err).Msg("Failed to encode outbox messages")
+	}
+}
+
+func (h *OutboxHandler) ReplayMessage(w http.ResponseWriter, r *http.Request) {
+	messageID := chi.URLParam(r, "id")
+	h.logger.Info().
+		Str("method", r.Method).
+		Str("path", r.URL.Path).
+		Str("message_id", messageID).
+		Msg("Replay outbox message request received")
+	msg, err := h.usecase.ReplayMessage(r.Context(), messageID)
+	if err != nil {
+		h.logger.Error().
+			Err(err).
+			Str("message_id", messageID).
+			Msg("Failed to replay outbox message")
+		switch {
+		case errors.Is(err, outboxErr.ErrMessageNotFound):
+			http.Error(w, "Message not found", http.StatusNotFound)
+		case errors.Is(err, outboxErr.ErrMessageNotDead):
+			http.Error(w, "Only dead-lettered messages can be replayed", http.StatusConflict)
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(msg); err != nil {
+		h.logger.Error().
+			Err(err).
+			Str("message_id", messageID).
+			Msg("Failed to encode outbox message")
+	}
+}
@@ -0,0 +1,12 @@
+package payment
+
+import (
+	"context"
+
+	"event-booker/internal/domain"
+)
+
+type paymentUsecase interface {
+	StartPayment(ctx context.Context, bookingID string, actor *domain.Principal) (*domain.Payment, error)
+	HandleWebhook(ctx context.Context, payload []byte, signature string) error
+}
@@ -0,0 +1,94 @@
+package payment
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"event-booker/internal/http-server/middleware"
+	"event-booker/internal/payment"
+	paymentErr "event-booker/internal/usecase/payment"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/wb-go/wbf/zlog"
+)
+
+const maxWebhookBody = 1 << 20
+
+type PaymentHandler struct {
+	usecase paymentUsecase
+	logger  *zlog.Zerolog
+}
+
+func NewPaymentHandler(usecase paymentUsecase, logger *zlog.Zerolog) *PaymentHandler {
+	return &PaymentHandler{usecase: usecase, logger: logger}
+}
+
+func (h *PaymentHandler) StartPayment(w http.ResponseWriter, r *http.Request) {
+	bookingID := chi.URLParam(r, "id")
+	h.logger.Info().
+		Str("method", r.Method).
+		Str("path", r.URL.Path).
+		Str("booking_id", bookingID).
+		Msg("Start payment request received")
+	p, err := h.usecase.StartPayment(r.Context(), bookingID, middleware.PrincipalFromContext(r.Context()))
+	if err != nil {
+		h.logger.Error().
+			Err(err).
+			Str("booking_id", bookingID).
+			Msg("Failed to start payment")
+		switch {
+		case errors.Is(err, paymentErr.ErrBookingNotFound):
+			http.Error(w, "Booking not found", http.StatusNotFound)
+		case errors.Is(err, paymentErr.ErrForbidden):
+			http.Error(w, "Forbidden", http.StatusForbidden)
+		case errors.Is(err, paymentErr.ErrBookingNotPending):
+			http.Error(w, "Booking is not pending payment", http.StatusConflict)
+		case errors.Is(err, paymentErr.ErrBookingExpired):
+			http.Error(w, "Booking has expired", http.StatusGone)
+		case errors.Is(err, paymentErr.ErrPaymentNotRequired):
+			http.Error(w, "Event does not require payment", http.StatusBadRequest)
+		case errors.Is(err, paymentErr.ErrPaymentNotConfigured):
+			http.Error(w, "Event price is not configured", http.StatusConflict)
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(p); err != nil {
+		h.logger.Error().
+			Err(err).
+			Str("payment_id", p.ID).
+			Msg("Failed to encode payment response")
+	}
+}
+
+func (h *PaymentHandler) Webhook(w http.ResponseWriter, r *http.Request) {
+	h.logger.Info().
+		Str("method", r.Method).
+		Str("path", r.URL.Path).
+		Msg("Payment webhook received")
+	payload, err := io.ReadAll(io.LimitReader(r.Body, maxWebhookBody))
+	if err != nil {
+		h.logger.Error().Err(err).Msg("Failed to read webhook body")
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if err := h.usecase.HandleWebhook(r.Context(), payload, r.Header.Get(payment.SignatureHeader)); err != nil {
+		h.logger.Error().Err(err).Msg("Failed to process payment webhook")
+		switch {
+		case errors.Is(err, paymentErr.ErrInvalidSignature):
+			http.Error(w, "Invalid signature", http.StatusUnauthorized)
+		case errors.Is(err, paymentErr.ErrPaymentNotFound):
+			http.Error(w, "Payment not found", http.StatusNotFound)
+		case errors.Is(err, paymentErr.ErrAmountMismatch):
+			http.Error(w, "Amount does not match payment", http.StatusBadRequest)
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,14 @@
+package promo
+
+import (
+	"context"
+
+	"event-booker/internal/domain"
+)
+
+type promoUsecase interface {
+	CreatePromoCode(ctx context.Context, input *domain.PromoCode) (*domain.PromoCode, error)
+	ListPromoCodes(ctx context.Context) ([]*domain.PromoCode, error)
+	UpdatePromoCode(ctx context.Context, id string, patch *domain.PromoCodePatch) (*domain.PromoCode, error)
+	DeletePromoCode(ctx context.Context, id string) error
+}
@@ -0,0 +1,26 @@
+package dto
+
+// CreatePromoCodeRequest describes a new promo code. Value is a percentage for
+// percent codes and an amount in minor units of Currency for fixed ones. An
+// empty EventID makes the code global; zero limits mean unlimited. Validity
+// bounds are RFC3339 timestamps.
+type CreatePromoCodeRequest struct {
+	Code           string  `json:"code"`
+	EventID        string  `json:"event_id,omitempty"`
+	Kind           string  `json:"kind"`
+	Value          int64   `json:"value"`
+	Currency       string  `json:"currency,omitempty"`
+	MaxUses        int     `json:"max_uses,omitempty"`
+	MaxUsesPerUser int     `json:"max_uses_per_user,omitempty"`
+	ValidFrom      *string `json:"valid_from,omitempty"`
+	ValidUntil     *string `json:"valid_until,omitempty"`
+}
+
+// UpdatePromoCodeRequest carries a partial update; omitted fields keep their
+// current values. An empty ValidUntil removes the expiry.
+type UpdatePromoCodeRequest struct {
+	Active         *bool   `json:"active,omitempty"`
+	MaxUses        *int    `json:"max_uses,omitempty"`
+	MaxUsesPerUser *int    `json:"max_uses_per_user,omitempty"`
+	ValidUntil     *string `json:"valid_until,omitempty"`
+}
@@ -0,0 +1,174 @@
+package promo
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"event-booker/internal/domain"
+	"event-booker/internal/http-server/handler/promo/dto"
+	promoErr "event-booker/internal/usecase/promo"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/wb-go/wbf/zlog"
+)
+
+type PromoHandler struct {
+	usecase promoUsecase
+	logger  *zlog.Zerolog
+}
+
+func NewPromoHandler(usecase promoUsecase, logger *zlog.Zerolog) *PromoHandler {
+	return &PromoHandler{usecase: usecase, logger: logger}
+}
+
+func (h *PromoHandler) CreatePromoCode(w http.ResponseWriter, r *http.Request) {
+	h.logger.Info().
+		Str("method", r.Method).
+		Str("path", r.URL.Path).
+		Msg("Create promo code request received")
+	var req dto.CreatePromoCodeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Failed to decode promo code request")
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	input := &domain.PromoCode{
+		Code:           req.Code,
+		Kind:           domain.DiscountKind(req.Kind),
+		Value:          req.Value,
+		Currency:       strings.ToUpper(req.Currency),
+		MaxUses:        req.MaxUses,
+		MaxUsesPerUser: req.MaxUsesPerUser,
+	}
+	if req.EventID != "" {
+		input.EventID = &req.EventID
+	}
+	var err error
+	if input.ValidFrom, err = parseOptionalTime(req.ValidFrom); err == nil {
+		input.ValidUntil, err = parseOptionalTime(req.ValidUntil)
+	}
+	if err != nil {
+		h.logger.Error().Err(err).Msg("Failed to parse promo code validity")
+		http.Error(w, "Invalid validity window. Use RFC3339 format (e.g., 2024-01-01T18:00:00Z)", http.StatusBadRequest)
+		return
+	}
+	promo, err := h.usecase.CreatePromoCode(r.Context(), input)
+	if err != nil {
+		h.logger.Error().Err(err).Str("code", req.Code).Msg("Failed to create promo code")
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(promo); err != nil {
+		h.logger.Error().Err(err).Str("promo_id", promo.ID).Msg("Failed to encode promo code")
+	}
+}
+
+func (h *PromoHandler) ListPromoCodes(w http.ResponseWriter, r *http.Request) {
+	h.logger.Info().
+		Str("method", r.Method).
+		Str("path", r.URL.Path).
+		Msg("List promo codes request received")
+	codes, err := h.usecase.ListPromoCodes(r.Context())
+	if err != nil {
+		h.logger.Error().Err(err).Msg("Failed to list promo codes")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(codes); err != nil {
+		h.logger.Error().Err(err).Msg("Failed to encode promo codes")
+	}
+}
+
+func (h *PromoHandler) UpdatePromoCode(w http.ResponseWriter, r *http.Request) {
+	promoID := chi.URLParam(r, "id")
+	h.logger.Info().
+		Str("method", r.Method).
+		Str("path", r.URL.Path).
+		Str("promo_id", promoID).
+		Msg("Update promo code request received")
+	var req dto.UpdatePromoCodeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Error().Err(err).Str("promo_id", promoID).Msg("Failed to decode promo code update")
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	patch := &domain.PromoCodePatch{
+		Active:         req.Active,
+		MaxUses:        req.MaxUses,
+		MaxUsesPerUser: req.MaxUsesPerUser,
+	}
+	if req.ValidUntil != nil && *req.ValidUntil == "" {
+		patch.ClearValidUntil = true
+	} else {
+		validUntil, err := parseOptionalTime(req.ValidUntil)
+		if err != nil {
+			h.logger.Error().Err(err).Str("promo_id", promoID).Msg("Failed to parse promo code validity")
+			http.Error(w, "Invalid valid_until. Use RFC3339 format (e.g., 2024-01-01T18:00:00Z) or an empty string to remove the expiry", http.StatusBadRequest)
+			return
+		}
+		patch.ValidUntil = validUntil
+	}
+	promo, err := h.usecase.UpdatePromoCode(r.Context(), promoID, patch)
+	if err != nil {
+		h.logger.Error().Err(err).Str("promo_id", promoID).Msg("Failed to update promo code")
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(promo); err != nil {
+		h.logger.Error().Err(err).Str("promo_id", promoID).Msg("Failed to encode promo code")
+	}
+}
+
+func (h *PromoHandler) DeletePromoCode(w http.ResponseWriter, r *http.Request) {
+	promoID := chi.URLParam(r, "id")
+	h.logger.Info().
+		Str("method", r.Method).
+		Str("path", r.URL.Path).
+		Str("promo_id", promoID).
+		Msg("Delete promo code request received")
+	if err := h.usecase.DeletePromoCode(r.Context(), promoID); err != nil {
+		h.logger.Error().Err(err).Str("promo_id", promoID).Msg("Failed to delete promo code")
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func parseOptionalTime(value *string) (*time.Time, error) {
+	if value == nil {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, *value)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, promoErr.ErrPromoNotFound):
+		http.Error(w, "Promo code not found", http.StatusNotFound)
+	case errors.Is(err, promoErr.ErrEventNotFound):
+		http.Error(w, "Event not found", http.StatusNotFound)
+	case errors.Is(err, promoErr.ErrPromoExists),
+		errors.Is(err, promoErr.ErrPromoInUse):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case errors.Is(err, promoErr.ErrInvalidCode),
+		errors.Is(err, promoErr.ErrInvalidKind),
+		errors.Is(err, promoErr.ErrInvalidValue),
+		errors.Is(err, promoErr.ErrInvalidCurrency),
+		errors.Is(err, promoErr.ErrInvalidLimit),
+		errors.Is(err, promoErr.ErrInvalidValidity):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
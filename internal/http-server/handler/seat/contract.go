@@ -0,0 +1,14 @@
+package seat
+
+import (
+	"context"
+
+	"event-booker/internal/domain"
+)
+
+type seatUsecase interface {
+	CreateLayout(ctx context.Context, input *domain.SeatLayout) (*domain.SeatLayout, error)
+	GetLayout(ctx context.Context, id string) (*domain.SeatLayout, error)
+	ListLayouts(ctx context.Context) ([]*domain.SeatLayout, error)
+	GetSeatMap(ctx context.Context, eventID string) ([]*domain.EventSeat, error)
+}
@@ -0,0 +1,13 @@
+package dto
+
+type CreateLayoutRequest struct {
+	Name  string        `json:"name"`
+	Seats []SeatRequest `json:"seats"`
+}
+
+type SeatRequest struct {
+	Section    string `json:"section"`
+	Row        string `json:"row"`
+	Number     int    `json:"number"`
+	Accessible bool   `json:"accessible,omitempty"`
+}
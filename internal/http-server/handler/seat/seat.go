@@ -0,0 +1,131 @@
+package seat
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"event-booker/internal/domain"
+	"event-booker/internal/http-server/handler/seat/dto"
+	seatErr "event-booker/internal/usecase/seat"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/wb-go/wbf/zlog"
+)
+
+type SeatHandler struct {
+	usecase seatUsecase
+	logger  *zlog.Zerolog
+}
+
+func NewSeatHandler(usecase seatUsecase, logger *zlog.Zerolog) *SeatHandler {
+	return &SeatHandler{usecase: usecase, logger: logger}
+}
+
+func (h *SeatHandler) CreateLayout(w http.ResponseWriter, r *http.Request) {
+	h.logger.Info().
+		Str("method", r.Method).
+		Str("path", r.URL.Path).
+		Msg("Create seat layout request received")
+	var req dto.CreateLayoutRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Failed to decode seat layout request")
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	input := &domain.SeatLayout{Name: req.Name, Seats: make([]*domain.Seat, 0, len(req.Seats))}
+	for _, seat := range req.Seats {
+		input.Seats = append(input.Seats, &domain.Seat{
+			Section:    seat.Section,
+			Row:        seat.Row,
+			Number:     seat.Number,
+			Accessible: seat.Accessible,
+		})
+	}
+	layout, err := h.usecase.CreateLayout(r.Context(), input)
+	if err != nil {
+		h.logger.Error().Err(err).Str("name", req.Name).Msg("Failed to create seat layout")
+		switch {
+		case errors.Is(err, seatErr.ErrInvalidLayoutName),
+			errors.Is(err, seatErr.ErrNoSeats),
+			errors.Is(err, seatErr.ErrTooManySeats),
+			errors.Is(err, seatErr.ErrInvalidSeat),
+			errors.Is(err, seatErr.ErrDuplicateSeat):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(layout); err != nil {
+		h.logger.Error().Err(err).Str("layout_id", layout.ID).Msg("Failed to encode seat layout")
+	}
+}
+
+func (h *SeatHandler) ListLayouts(w http.ResponseWriter, r *http.Request) {
+	h.logger.Info().
+		Str("method", r.Method).
+		Str("path", r.URL.Path).
+		Msg("List seat layouts request received")
+	layouts, err := h.usecase.ListLayouts(r.Context())
+	if err != nil {
+		h.logger.Error().Err(err).Msg("Failed to list seat layouts")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(layouts); err != nil {
+		h.logger.Error().Err(err).Msg("Failed to encode seat layouts")
+	}
+}
+
+func (h *SeatHandler) GetLayout(w http.ResponseWriter, r *http.Request) {
+	layoutID := chi.URLParam(r, "id")
+	h.logger.Info().
+		Str("method", r.Method).
+		Str("path", r.URL.Path).
+		Str("layout_id", layoutID).
+		Msg("Get seat layout request received")
+	layout, err := h.usecase.GetLayout(r.Context(), layoutID)
+	if err != nil {
+		h.logger.Error().Err(err).Str("layout_id", layoutID).Msg("Failed to get seat layout")
+		if errors.Is(err, seatErr.ErrLayoutNotFound) {
+			http.Error(w, "Seat layout not found", http.StatusNotFound)
+		} else {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(layout); err != nil {
+		h.logger.Error().Err(err).Str("layout_id", layoutID).Msg("Failed to encode seat layout")
+	}
+}
+
+func (h *SeatHandler) GetSeatMap(w http.ResponseWriter, r *http.Request) {
+	eventID := chi.URLParam(r, "id")
+	h.logger.Info().
+		Str("method", r.Method).
+		Str("path", r.URL.Path).
+		Str("event_id", eventID).
+		Msg("Get seat map request received")
+	seats, err := h.usecase.GetSeatMap(r.Context(), eventID)
+	if err != nil {
+		h.logger.Error().Err(err).Str("event_id", eventID).Msg("Failed to get seat map")
+		switch {
+		case errors.Is(err, seatErr.ErrEventNotFound):
+			http.Error(w, "Event not found", http.StatusNotFound)
+		case errors.Is(err, seatErr.ErrNotReservedSeating):
+			http.Error(w, "Event does not have reserved seating", http.StatusNotFound)
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(seats); err != nil {
+		h.logger.Error().Err(err).Str("event_id", eventID).Msg("Failed to encode seat map")
+	}
+}
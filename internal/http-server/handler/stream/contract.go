@@ -0,0 +1,17 @@
+package stream
+
+import (
+	"context"
+
+	"event-booker/internal/domain"
+	"event-booker/internal/realtime"
+)
+
+type broker interface {
+	Subscribe(eventID string) *realtime.Subscription
+	Unsubscribe(sub *realtime.Subscription)
+}
+
+type eventUsecase interface {
+	GetEvent(ctx context.Context, id string) (*domain.Event, error)
+}
@@ -0,0 +1,144 @@
+package stream
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"event-booker/internal/metrics"
+	"event-booker/internal/realtime"
+	eventErr "event-booker/internal/usecase/event"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/wb-go/wbf/zlog"
+)
+
+const (
+	availabilityEvent = "availability"
+	resyncEvent       = "resync"
+	// retryMillis is how long browsers wait before reconnecting a dropped
+	// stream.
+	retryMillis = 3000
+)
+
+// StreamHandler serves server-sent event streams of event availability and
+// status changes.
+type StreamHandler struct {
+	broker    broker
+	usecase   eventUsecase
+	heartbeat time.Duration
+	logger    *zlog.Zerolog
+}
+
+func NewStreamHandler(broker broker, usecase eventUsecase, heartbeat time.Duration, logger *zlog.Zerolog) *StreamHandler {
+	return &StreamHandler{broker: broker, usecase: usecase, heartbeat: heartbeat, logger: logger}
+}
+
+// StreamEvents streams changes of all events.
+func (h *StreamHandler) StreamEvents(w http.ResponseWriter, r *http.Request) {
+	h.logger.Info().
+		Str("method", r.Method).
+		Str("path", r.URL.Path).
+		Msg("Event stream request received")
+	h.serve(w, r, h.broker.Subscribe(""), nil)
+}
+
+// StreamEvent streams changes of one event, starting with its current state.
+func (h *StreamHandler) StreamEvent(w http.ResponseWriter, r *http.Request) {
+	eventID := chi.URLParam(r, "id")
+	h.logger.Info().
+		Str("method", r.Method).
+		Str("path", r.URL.Path).
+		Str("event_id", eventID).
+		Msg("Event stream request received")
+	// Subscribing before reading the snapshot means no change committed in
+	// between is missed; at worst the first change repeats the snapshot.
+	sub := h.broker.Subscribe(eventID)
+	event, err := h.usecase.GetEvent(r.Context(), eventID)
+	if err != nil {
+		h.broker.Unsubscribe(sub)
+		h.logger.Error().Err(err).Str("event_id", eventID).Msg("Failed to get event for stream")
+		if errors.Is(err, eventErr.ErrEventNotFound) {
+			http.Error(w, "Event not found", http.StatusNotFound)
+		} else {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+	h.serve(w, r, sub, &realtime.Change{
+		EventID:    event.ID,
+		Available:  event.Available,
+		TotalSeats: event.TotalSeats,
+		Status:     event.Status,
+	})
+}
+
+// serve writes messages from sub until the client goes away, the subscription
+// is dropped or writing fails. snapshot, when set, is sent first.
+func (h *StreamHandler) serve(w http.ResponseWriter, r *http.Request, sub *realtime.Subscription, snapshot *realtime.Change) {
+	defer h.broker.Unsubscribe(sub)
+
+	rc := http.NewResponseController(w)
+	// Streams outlive the server's write timeout by design.
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		h.logger.Warn().Err(err).Msg("Failed to clear write deadline for event stream")
+	}
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	metrics.StreamOpened()
+	defer metrics.StreamClosed()
+
+	if _, err := fmt.Fprintf(w, "retry: %d\n\n", retryMillis); err != nil {
+		return
+	}
+	if snapshot != nil {
+		if err := writeEvent(w, availabilityEvent, snapshot); err != nil {
+			return
+		}
+	}
+	if err := rc.Flush(); err != nil {
+		h.logger.Error().Err(err).Msg("Event stream does not support flushing")
+		return
+	}
+
+	heartbeat := time.NewTicker(h.heartbeat)
+	defer heartbeat.Stop()
+	for {
+		var err error
+		select {
+		case <-r.Context().Done():
+			return
+		case msg, ok := <-sub.C:
+			if !ok {
+				return
+			}
+			if msg.Resync {
+				err = writeEvent(w, resyncEvent, struct{}{})
+			} else {
+				err = writeEvent(w, availabilityEvent, msg.Change)
+			}
+		case <-heartbeat.C:
+			_, err = fmt.Fprint(w, ": heartbeat\n\n")
+		}
+		if err == nil {
+			err = rc.Flush()
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+func writeEvent(w http.ResponseWriter, name string, data interface{}) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", name, payload)
+	return err
+}
@@ -2,11 +2,15 @@ package user
 
 import (
 	"context"
+	"time"
 
 	"event-booker/internal/domain"
 )
 
 type userUsecase interface {
-	RegisterUser(ctx context.Context, email, telegram string, role domain.UserRole) (*domain.User, error)
-	GetUser(ctx context.Context, id string) (*domain.User, error)
+	RegisterUser(ctx context.Context, input *domain.User, password string, actor *domain.Principal) (*domain.User, error)
+	Login(ctx context.Context, email, password string) (string, time.Time, *domain.User, error)
+	GetUser(ctx context.Context, id string, actor *domain.Principal) (*domain.User, error)
+	GetNotificationPreferences(ctx context.Context, userID string, actor *domain.Principal) (*domain.NotificationPreferences, error)
+	UpdateNotificationPreferences(ctx context.Context, userID string, patch *domain.NotificationPreferencesPatch, actor *domain.Principal) (*domain.NotificationPreferences, error)
 }
@@ -1,9 +1,36 @@
 package dto
 
-import "event-booker/internal/domain"
+import (
+	"time"
+
+	"event-booker/internal/domain"
+)
 
 type RegisterRequest struct {
 	Email    string          `json:"email"`
 	Telegram string          `json:"telegram,omitempty"`
+	Password string          `json:"password"`
 	Role     domain.UserRole `json:"role"`
+	Locale   domain.Locale   `json:"locale,omitempty"`
+	Timezone string          `json:"timezone,omitempty"`
+}
+
+type LoginRequest struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+type LoginResponse struct {
+	Token     string       `json:"token"`
+	ExpiresAt time.Time    `json:"expires_at"`
+	User      *domain.User `json:"user"`
+}
+
+// UpdatePreferencesRequest carries a partial update; omitted fields keep their
+// current values.
+type UpdatePreferencesRequest struct {
+	Email     *bool `json:"email,omitempty"`
+	Telegram  *bool `json:"telegram,omitempty"`
+	Webhook   *bool `json:"webhook,omitempty"`
+	Reminders *bool `json:"reminders,omitempty"`
 }
@@ -5,7 +5,9 @@ import (
 	"errors"
 	"net/http"
 
+	"event-booker/internal/domain"
 	"event-booker/internal/http-server/handler/user/dto"
+	"event-booker/internal/http-server/middleware"
 	userErr "event-booker/internal/usecase/user"
 
 	"github.com/go-chi/chi/v5"
@@ -43,13 +45,31 @@ func (h *UserHandler) Register(w http.ResponseWriter, r *http.Request) {
 		Str("email", req.Email).
 		Str("role", string(req.Role)).
 		Msg("Registering new user")
-	user, err := h.usecase.RegisterUser(r.Context(), req.Email, req.Telegram, req.Role)
+	user, err := h.usecase.RegisterUser(r.Context(), &domain.User{
+		Email:    req.Email,
+		Telegram: req.Telegram,
+		Role:     req.Role,
+		Locale:   req.Locale,
+		Timezone: req.Timezone,
+	}, req.Password, middleware.PrincipalFromContext(r.Context()))
 	if err != nil {
 		h.logger.Error().
 			Err(err).
 			Str("email", req.Email).
 			Msg("Failed to register user")
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		switch {
+		case errors.Is(err, userErr.ErrInvalidRole),
+			errors.Is(err, userErr.ErrPasswordTooShort),
+			errors.Is(err, userErr.ErrInvalidLocale),
+			errors.Is(err, userErr.ErrInvalidTimezone):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		case errors.Is(err, userErr.ErrForbidden):
+			http.Error(w, "Only administrators can register administrators", http.StatusForbidden)
+		case errors.Is(err, userErr.ErrEmailTaken):
+			http.Error(w, "Email is already registered", http.StatusConflict)
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
 		return
 	}
 	h.logger.Info().
@@ -67,6 +87,53 @@ func (h *UserHandler) Register(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+func (h *UserHandler) Login(w http.ResponseWriter, r *http.Request) {
+	h.logger.Info().
+		Str("method", r.Method).
+		Str("path", r.URL.Path).
+		Msg("Login request received")
+	var req dto.LoginRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Error().
+			Err(err).
+			Msg("Failed to decode login request")
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if req.Email == "" || req.Password == "" {
+		http.Error(w, "Email and password are required", http.StatusBadRequest)
+		return
+	}
+	token, expiresAt, user, err := h.usecase.Login(r.Context(), req.Email, req.Password)
+	if err != nil {
+		h.logger.Warn().
+			Err(err).
+			Str("email", req.Email).
+			Msg("Login failed")
+		if errors.Is(err, userErr.ErrInvalidCredentials) {
+			http.Error(w, "Invalid email or password", http.StatusUnauthorized)
+		} else {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+	h.logger.Info().
+		Str("user_id", user.ID).
+		Str("role", string(user.Role)).
+		Msg("User logged in successfully")
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(dto.LoginResponse{
+		Token:     token,
+		ExpiresAt: expiresAt,
+		User:      user,
+	}); err != nil {
+		h.logger.Error().
+			Err(err).
+			Str("user_id", user.ID).
+			Msg("Failed to encode login response")
+	}
+}
+
 func (h *UserHandler) GetUser(w http.ResponseWriter, r *http.Request) {
 	userID := chi.URLParam(r, "id")
 	if userID == "" {
@@ -79,15 +146,18 @@ func (h *UserHandler) GetUser(w http.ResponseWriter, r *http.Request) {
 		Str("path", r.URL.Path).
 		Str("user_id", userID).
 		Msg("Get user request")
-	user, err := h.usecase.GetUser(r.Context(), userID)
+	user, err := h.usecase.GetUser(r.Context(), userID, middleware.PrincipalFromContext(r.Context()))
 	if err != nil {
 		h.logger.Error().
 			Err(err).
 			Str("user_id", userID).
 			Msg("Failed to get user")
-		if errors.Is(err, userErr.ErrUserNotFound) {
+		switch {
+		case errors.Is(err, userErr.ErrUserNotFound):
 			http.Error(w, "User not found", http.StatusNotFound)
-		} else {
+		case errors.Is(err, userErr.ErrForbidden):
+			http.Error(w, "Forbidden", http.StatusForbidden)
+		default:
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 		}
 		return
@@ -104,3 +174,82 @@ func (h *UserHandler) GetUser(w http.ResponseWriter, r *http.Request) {
 			Msg("Failed to encode user response")
 	}
 }
+
+func (h *UserHandler) GetNotificationPreferences(w http.ResponseWriter, r *http.Request) {
+	userID := chi.URLParam(r, "id")
+	h.logger.Info().
+		Str("method", r.Method).
+		Str("path", r.URL.Path).
+		Str("user_id", userID).
+		Msg("Get notification preferences request")
+	prefs, err := h.usecase.GetNotificationPreferences(r.Context(), userID, middleware.PrincipalFromContext(r.Context()))
+	if err != nil {
+		h.logger.Error().
+			Err(err).
+			Str("user_id", userID).
+			Msg("Failed to get notification preferences")
+		h.writePreferencesError(w, err)
+		return
+	}
+	h.writePreferences(w, prefs)
+}
+
+func (h *UserHandler) UpdateNotificationPreferences(w http.ResponseWriter, r *http.Request) {
+	userID := chi.URLParam(r, "id")
+	h.logger.Info().
+		Str("method", r.Method).
+		Str("path", r.URL.Path).
+		Str("user_id", userID).
+		Msg("Update notification preferences request")
+	var req dto.UpdatePreferencesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Error().
+			Err(err).
+			Msg("Failed to decode notification preferences request")
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	prefs, err := h.usecase.UpdateNotificationPreferences(r.Context(), userID, &domain.NotificationPreferencesPatch{
+		Email:     req.Email,
+		Telegram:  req.Telegram,
+		Webhook:   req.Webhook,
+		Reminders: req.Reminders,
+	}, middleware.PrincipalFromContext(r.Context()))
+	if err != nil {
+		h.logger.Error().
+			Err(err).
+			Str("user_id", userID).
+			Msg("Failed to update notification preferences")
+		h.writePreferencesError(w, err)
+		return
+	}
+	h.logger.Info().
+		Str("user_id", userID).
+		Bool("email", prefs.Email).
+		Bool("telegram", prefs.Telegram).
+		Bool("webhook", prefs.Webhook).
+		Bool("reminders", prefs.Reminders).
+		Msg("Notification preferences updated")
+	h.writePreferences(w, prefs)
+}
+
+func (h *UserHandler) writePreferencesError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, userErr.ErrUserNotFound):
+		http.Error(w, "User not found", http.StatusNotFound)
+	case errors.Is(err, userErr.ErrForbidden):
+		http.Error(w, "Forbidden", http.StatusForbidden)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (h *UserHandler) writePreferences(w http.ResponseWriter, prefs *domain.NotificationPreferences) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(prefs); err != nil {
+		h.logger.Error().
+			Err(err).
+			Str("user_id", prefs.UserID).
+			Msg("Failed to encode notification preferences")
+	}
+}
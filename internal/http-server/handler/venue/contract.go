@@ -0,0 +1,15 @@
+package venue
+
+import (
+	"context"
+
+	"event-booker/internal/domain"
+)
+
+type venueUsecase interface {
+	CreateVenue(ctx context.Context, input *domain.Venue) (*domain.Venue, error)
+	GetVenue(ctx context.Context, id string) (*domain.Venue, error)
+	ListVenues(ctx context.Context) ([]*domain.Venue, error)
+	UpdateVenue(ctx context.Context, id string, patch *domain.VenuePatch) (*domain.Venue, error)
+	DeleteVenue(ctx context.Context, id string) error
+}
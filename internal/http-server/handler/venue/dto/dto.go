@@ -0,0 +1,23 @@
+package dto
+
+// CreateVenueRequest describes a new venue. Timezone is an IANA name such as
+// Europe/Moscow; latitude and longitude are optional but go together.
+type CreateVenueRequest struct {
+	Name      string   `json:"name"`
+	Address   string   `json:"address"`
+	Timezone  string   `json:"timezone"`
+	Capacity  int      `json:"capacity"`
+	Latitude  *float64 `json:"latitude,omitempty"`
+	Longitude *float64 `json:"longitude,omitempty"`
+}
+
+// UpdateVenueRequest carries a partial update; omitted fields keep their
+// current values.
+type UpdateVenueRequest struct {
+	Name      *string  `json:"name,omitempty"`
+	Address   *string  `json:"address,omitempty"`
+	Timezone  *string  `json:"timezone,omitempty"`
+	Capacity  *int     `json:"capacity,omitempty"`
+	Latitude  *float64 `json:"latitude,omitempty"`
+	Longitude *float64 `json:"longitude,omitempty"`
+}
@@ -0,0 +1,146 @@
+package venue
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"event-booker/internal/domain"
+	"event-booker/internal/http-server/handler/venue/dto"
+	venueErr "event-booker/internal/usecase/venue"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/wb-go/wbf/zlog"
+)
+
+type VenueHandler struct {
+	usecase venueUsecase
+	logger  *zlog.Zerolog
+}
+
+func NewVenueHandler(usecase venueUsecase, logger *zlog.Zerolog) *VenueHandler {
+	return &VenueHandler{usecase: usecase, logger: logger}
+}
+
+func (h *VenueHandler) CreateVenue(w http.ResponseWriter, r *http.Request) {
+	h.logger.Info().
+		Str("method", r.Method).
+		Str("path", r.URL.Path).
+		Msg("Create venue request received")
+	var req dto.CreateVenueRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Failed to decode venue request")
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	venue, err := h.usecase.CreateVenue(r.Context(), &domain.Venue{
+		Name:      req.Name,
+		Address:   req.Address,
+		Timezone:  req.Timezone,
+		Capacity:  req.Capacity,
+		Latitude:  req.Latitude,
+		Longitude: req.Longitude,
+	})
+	if err != nil {
+		h.logger.Error().Err(err).Str("name", req.Name).Msg("Failed to create venue")
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(venue); err != nil {
+		h.logger.Error().Err(err).Str("venue_id", venue.ID).Msg("Failed to encode venue")
+	}
+}
+
+func (h *VenueHandler) ListVenues(w http.ResponseWriter, r *http.Request) {
+	venues, err := h.usecase.ListVenues(r.Context())
+	if err != nil {
+		h.logger.Error().Err(err).Msg("Failed to list venues")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(venues); err != nil {
+		h.logger.Error().Err(err).Msg("Failed to encode venues")
+	}
+}
+
+func (h *VenueHandler) GetVenue(w http.ResponseWriter, r *http.Request) {
+	venueID := chi.URLParam(r, "id")
+	venue, err := h.usecase.GetVenue(r.Context(), venueID)
+	if err != nil {
+		h.logger.Error().Err(err).Str("venue_id", venueID).Msg("Failed to get venue")
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(venue); err != nil {
+		h.logger.Error().Err(err).Str("venue_id", venueID).Msg("Failed to encode venue")
+	}
+}
+
+func (h *VenueHandler) UpdateVenue(w http.ResponseWriter, r *http.Request) {
+	venueID := chi.URLParam(r, "id")
+	h.logger.Info().
+		Str("method", r.Method).
+		Str("path", r.URL.Path).
+		Str("venue_id", venueID).
+		Msg("Update venue request received")
+	var req dto.UpdateVenueRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Error().Err(err).Str("venue_id", venueID).Msg("Failed to decode venue update")
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	venue, err := h.usecase.UpdateVenue(r.Context(), venueID, &domain.VenuePatch{
+		Name:      req.Name,
+		Address:   req.Address,
+		Timezone:  req.Timezone,
+		Capacity:  req.Capacity,
+		Latitude:  req.Latitude,
+		Longitude: req.Longitude,
+	})
+	if err != nil {
+		h.logger.Error().Err(err).Str("venue_id", venueID).Msg("Failed to update venue")
+		writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(venue); err != nil {
+		h.logger.Error().Err(err).Str("venue_id", venueID).Msg("Failed to encode venue")
+	}
+}
+
+func (h *VenueHandler) DeleteVenue(w http.ResponseWriter, r *http.Request) {
+	venueID := chi.URLParam(r, "id")
+	h.logger.Info().
+		Str("method", r.Method).
+		Str("path", r.URL.Path).
+		Str("venue_id", venueID).
+		Msg("Delete venue request received")
+	if err := h.usecase.DeleteVenue(r.Context(), venueID); err != nil {
+		h.logger.Error().Err(err).Str("venue_id", venueID).Msg("Failed to delete venue")
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, venueErr.ErrVenueNotFound):
+		http.Error(w, "Venue not found", http.StatusNotFound)
+	case errors.Is(err, venueErr.ErrVenueInUse),
+		errors.Is(err, venueErr.ErrCapacityBelowEvents):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case errors.Is(err, venueErr.ErrInvalidName),
+		errors.Is(err, venueErr.ErrInvalidAddress),
+		errors.Is(err, venueErr.ErrInvalidTimezone),
+		errors.Is(err, venueErr.ErrInvalidCapacity),
+		errors.Is(err, venueErr.ErrInvalidCoordinates):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
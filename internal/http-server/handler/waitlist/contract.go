@@ -0,0 +1,12 @@
+package waitlist
+
+import (
+	"context"
+
+	"event-booker/internal/domain"
+)
+
+type waitlistUsecase interface {
+	JoinWaitlist(ctx context.Context, eventID, userID string, quantity int, tierID string) (*domain.WaitlistEntry, error)
+	ListWaitlist(ctx context.Context, eventID string, actor *domain.Principal) ([]*domain.WaitlistEntry, error)
+}
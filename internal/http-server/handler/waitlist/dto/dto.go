@@ -0,0 +1,6 @@
+package dto
+
+type JoinWaitlistRequest struct {
+	Quantity int    `json:"quantity,omitempty"`
+	TierID   string `json:"tier_id,omitempty"`
+}
@@ -0,0 +1,127 @@
+package waitlist
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"event-booker/internal/http-server/handler/waitlist/dto"
+	"event-booker/internal/http-server/middleware"
+	waitlistErr "event-booker/internal/usecase/waitlist"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/wb-go/wbf/zlog"
+)
+
+type WaitlistHandler struct {
+	usecase waitlistUsecase
+	logger  *zlog.Zerolog
+}
+
+func NewWaitlistHandler(usecase waitlistUsecase, logger *zlog.Zerolog) *WaitlistHandler {
+	return &WaitlistHandler{usecase: usecase, logger: logger}
+}
+
+func (h *WaitlistHandler) Join(w http.ResponseWriter, r *http.Request) {
+	eventID := chi.URLParam(r, "id")
+	h.logger.Info().
+		Str("method", r.Method).
+		Str("path", r.URL.Path).
+		Str("event_id", eventID).
+		Msg("Join waitlist request received")
+	principal := middleware.PrincipalFromContext(r.Context())
+	var req dto.JoinWaitlistRequest
+	if r.ContentLength > 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			h.logger.Error().
+				Err(err).
+				Str("event_id", eventID).
+				Msg("Failed to decode waitlist request")
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+	}
+	if req.Quantity == 0 {
+		req.Quantity = 1
+	}
+	entry, err := h.usecase.JoinWaitlist(r.Context(), eventID, principal.UserID, req.Quantity, req.TierID)
+	if err != nil {
+		h.logger.Error().
+			Err(err).
+			Str("event_id", eventID).
+			Str("user_id", principal.UserID).
+			Msg("Join waitlist failed")
+		switch {
+		case errors.Is(err, waitlistErr.ErrEventNotFound):
+			http.Error(w, "Event not found", http.StatusNotFound)
+		case errors.Is(err, waitlistErr.ErrEventNotActive):
+			http.Error(w, "Event is not active", http.StatusConflict)
+		case errors.Is(err, waitlistErr.ErrSeatsAvailable):
+			http.Error(w, "Seats are available, book directly", http.StatusConflict)
+		case errors.Is(err, waitlistErr.ErrAlreadyWaitlisted):
+			http.Error(w, "Already on the waitlist", http.StatusConflict)
+		case errors.Is(err, waitlistErr.ErrAlreadyBooked):
+			http.Error(w, "Already holds a booking for this event", http.StatusConflict)
+		case errors.Is(err, waitlistErr.ErrReservedSeating):
+			http.Error(w, "Reserved-seating events have no waitlist", http.StatusConflict)
+		case errors.Is(err, waitlistErr.ErrTierNotFound):
+			http.Error(w, "Ticket tier not found for this event", http.StatusNotFound)
+		case errors.Is(err, waitlistErr.ErrTierNotOnSale):
+			http.Error(w, "Ticket tier is not on sale", http.StatusConflict)
+		case errors.Is(err, waitlistErr.ErrTierRequired):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		case errors.Is(err, waitlistErr.ErrInvalidQuantity),
+			errors.Is(err, waitlistErr.ErrQuantityTooLarge),
+			errors.Is(err, waitlistErr.ErrQuantityExceedsCap):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+	h.logger.Info().
+		Str("entry_id", entry.ID).
+		Str("event_id", eventID).
+		Str("user_id", entry.UserID).
+		Int("quantity", entry.Quantity).
+		Msg("Joined waitlist successfully")
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(entry); err != nil {
+		h.logger.Error().
+			Err(err).
+			Str("entry_id", entry.ID).
+			Msg("Failed to encode waitlist entry response")
+	}
+}
+
+func (h *WaitlistHandler) List(w http.ResponseWriter, r *http.Request) {
+	eventID := chi.URLParam(r, "id")
+	h.logger.Info().
+		Str("method", r.Method).
+		Str("path", r.URL.Path).
+		Str("event_id", eventID).
+		Msg("List waitlist request received")
+	entries, err := h.usecase.ListWaitlist(r.Context(), eventID, middleware.PrincipalFromContext(r.Context()))
+	if err != nil {
+		h.logger.Error().
+			Err(err).
+			Str("event_id", eventID).
+			Msg("Failed to list waitlist")
+		switch {
+		case errors.Is(err, waitlistErr.ErrEventNotFound):
+			http.Error(w, "Event not found", http.StatusNotFound)
+		case errors.Is(err, waitlistErr.ErrForbidden):
+			http.Error(w, "Forbidden", http.StatusForbidden)
+		default:
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(entries); err != nil {
+		h.logger.Error().
+			Err(err).
+			Msg("Failed to encode waitlist response")
+	}
+}
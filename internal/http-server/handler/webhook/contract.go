@@ -0,0 +1,22 @@
+package webhook
+
+import (
+	"context"
+
+	"event-booker/internal/domain"
+)
+
+type webhookUsecase interface {
+	CreateEndpoint(ctx context.Context, userID, url string, actor *domain.Principal) (*domain.WebhookEndpoint, error)
+	ListEndpoints(ctx context.Context, userID string, actor *domain.Principal) ([]*domain.WebhookEndpoint, error)
+	CreateOrganizerEndpoint(ctx context.Context, organizerID, url string, actor *domain.Principal) (*domain.WebhookEndpoint, error)
+	ListOrganizerEndpoints(ctx context.Context, organizerID string, actor *domain.Principal) ([]*domain.WebhookEndpoint, error)
+	DeleteEndpoint(ctx context.Context, id string, actor *domain.Principal) error
+	ListDeliveries(ctx context.Context, endpointID string, actor *domain.Principal) ([]*domain.WebhookDelivery, error)
+	CreateSubscription(ctx context.Context, input *domain.WebhookSubscription) (*domain.WebhookSubscription, error)
+	ListSubscriptions(ctx context.Context) ([]*domain.WebhookSubscription, error)
+	UpdateSubscription(ctx context.Context, id string, patch *domain.WebhookSubscriptionPatch) (*domain.WebhookSubscription, error)
+	DeleteSubscription(ctx context.Context, id string) error
+	ListSubscriptionDeliveries(ctx context.Context, id string) ([]*domain.WebhookDelivery, error)
+	PingSubscription(ctx context.Context, id string) (*domain.WebhookDelivery, error)
+}
@@ -0,0 +1,77 @@
+package dto
+
+import (
+	"time"
+
+	"event-booker/internal/domain"
+)
+
+type CreateEndpointRequest struct {
+	URL string `json:"url"`
+}
+
+// EndpointResponse includes the signing secret only in the response to the
+// request that created the endpoint.
+type EndpointResponse struct {
+	ID          string    `json:"id"`
+	UserID      *string   `json:"user_id,omitempty"`
+	OrganizerID *string   `json:"organizer_id,omitempty"`
+	URL         string    `json:"url"`
+	Secret      string    `json:"secret,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+func NewEndpointResponse(endpoint *domain.WebhookEndpoint, withSecret bool) EndpointResponse {
+	resp := EndpointResponse{
+		ID:          endpoint.ID,
+		UserID:      endpoint.UserID,
+		OrganizerID: endpoint.OrganizerID,
+		URL:         endpoint.URL,
+		CreatedAt:   endpoint.CreatedAt,
+	}
+	if withSecret {
+		resp.Secret = endpoint.Secret
+	}
+	return resp
+}
+
+type CreateSubscriptionRequest struct {
+	URL        string                   `json:"url"`
+	Secret     string                   `json:"secret,omitempty"`
+	EventTypes []domain.DomainEventType `json:"event_types"`
+}
+
+// UpdateSubscriptionRequest carries a partial update; omitted fields keep
+// their current values.
+type UpdateSubscriptionRequest struct {
+	URL        *string                   `json:"url,omitempty"`
+	EventTypes *[]domain.DomainEventType `json:"event_types,omitempty"`
+	Active     *bool                     `json:"active,omitempty"`
+}
+
+// SubscriptionResponse includes the signing secret only in the response to
+// the request that created the subscription.
+type SubscriptionResponse struct {
+	ID         string                   `json:"id"`
+	URL        string                   `json:"url"`
+	Secret     string                   `json:"secret,omitempty"`
+	EventTypes []domain.DomainEventType `json:"event_types"`
+	Active     bool                     `json:"active"`
+	CreatedAt  time.Time                `json:"created_at"`
+	UpdatedAt  time.Time                `json:"updated_at"`
+}
+
+func NewSubscriptionResponse(sub *domain.WebhookSubscription, withSecret bool) SubscriptionResponse {
+	resp := SubscriptionResponse{
+		ID:         sub.ID,
+		URL:        sub.URL,
+		EventTypes: sub.EventTypes,
+		Active:     sub.Active,
+		CreatedAt:  sub.CreatedAt,
+		UpdatedAt:  sub.UpdatedAt,
+	}
+	if withSecret {
+		resp.Secret = sub.Secret
+	}
+	return resp
+}
@@ -0,0 +1,294 @@
+package webhook
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"event-booker/internal/domain"
+	"event-booker/internal/http-server/handler/webhook/dto"
+	"event-booker/internal/http-server/middleware"
+	webhookErr "event-booker/internal/usecase/webhook"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/wb-go/wbf/zlog"
+)
+
+type WebhookHandler struct {
+	usecase webhookUsecase
+	logger  *zlog.Zerolog
+}
+
+func NewWebhookHandler(usecase webhookUsecase, logger *zlog.Zerolog) *WebhookHandler {
+	return &WebhookHandler{usecase: usecase, logger: logger}
+}
+
+func (h *WebhookHandler) CreateEndpoint(w http.ResponseWriter, r *http.Request) {
+	userID := chi.URLParam(r, "id")
+	h.logger.Info().
+		Str("method", r.Method).
+		Str("path", r.URL.Path).
+		Str("user_id", userID).
+		Msg("Create webhook endpoint request received")
+	h.createEndpoint(w, r, func(url string) (*domain.WebhookEndpoint, error) {
+		return h.usecase.CreateEndpoint(r.Context(), userID, url, middleware.PrincipalFromContext(r.Context()))
+	})
+}
+
+// CreateOrganizerEndpoint registers an endpoint for the domain events of the
+// organizer's events.
+func (h *WebhookHandler) CreateOrganizerEndpoint(w http.ResponseWriter, r *http.Request) {
+	organizerID := chi.URLParam(r, "id")
+	h.logger.Info().
+		Str("method", r.Method).
+		Str("path", r.URL.Path).
+		Str("organizer_id", organizerID).
+		Msg("Create organizer webhook endpoint request received")
+	h.createEndpoint(w, r, func(url string) (*domain.WebhookEndpoint, error) {
+		return h.usecase.CreateOrganizerEndpoint(r.Context(), organizerID, url, middleware.PrincipalFromContext(r.Context()))
+	})
+}
+
+func (h *WebhookHandler) createEndpoint(w http.ResponseWriter, r *http.Request, create func(url string) (*domain.WebhookEndpoint, error)) {
+	var req dto.CreateEndpointRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Failed to decode webhook endpoint request")
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	endpoint, err := create(req.URL)
+	if err != nil {
+		h.logger.Error().Err(err).Msg("Failed to create webhook endpoint")
+		h.writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(dto.NewEndpointResponse(endpoint, true)); err != nil {
+		h.logger.Error().Err(err).Msg("Failed to encode webhook endpoint")
+	}
+}
+
+func (h *WebhookHandler) ListEndpoints(w http.ResponseWriter, r *http.Request) {
+	userID := chi.URLParam(r, "id")
+	h.logger.Info().
+		Str("method", r.Method).
+		Str("path", r.URL.Path).
+		Str("user_id", userID).
+		Msg("List webhook endpoints request received")
+	endpoints, err := h.usecase.ListEndpoints(r.Context(), userID, middleware.PrincipalFromContext(r.Context()))
+	h.writeEndpoints(w, endpoints, err)
+}
+
+func (h *WebhookHandler) ListOrganizerEndpoints(w http.ResponseWriter, r *http.Request) {
+	organizerID := chi.URLParam(r, "id")
+	h.logger.Info().
+		Str("method", r.Method).
+		Str("path", r.URL.Path).
+		Str("organizer_id", organizerID).
+		Msg("List organizer webhook endpoints request received")
+	endpoints, err := h.usecase.ListOrganizerEndpoints(r.Context(), organizerID, middleware.PrincipalFromContext(r.Context()))
+	h.writeEndpoints(w, endpoints, err)
+}
+
+func (h *WebhookHandler) writeEndpoints(w http.ResponseWriter, endpoints []*domain.WebhookEndpoint, err error) {
+	if err != nil {
+		h.logger.Error().Err(err).Msg("Failed to list webhook endpoints")
+		h.writeError(w, err)
+		return
+	}
+	resp := make([]dto.EndpointResponse, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		resp = append(resp, dto.NewEndpointResponse(endpoint, false))
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		h.logger.Error().Err(err).Msg("Failed to encode webhook endpoints")
+	}
+}
+
+func (h *WebhookHandler) DeleteEndpoint(w http.ResponseWriter, r *http.Request) {
+	endpointID := chi.URLParam(r, "id")
+	h.logger.Info().
+		Str("method", r.Method).
+		Str("path", r.URL.Path).
+		Str("endpoint_id", endpointID).
+		Msg("Delete webhook endpoint request received")
+	if err := h.usecase.DeleteEndpoint(r.Context(), endpointID, middleware.PrincipalFromContext(r.Context())); err != nil {
+		h.logger.Error().Err(err).Str("endpoint_id", endpointID).Msg("Failed to delete webhook endpoint")
+		h.writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *WebhookHandler) ListDeliveries(w http.ResponseWriter, r *http.Request) {
+	endpointID := chi.URLParam(r, "id")
+	h.logger.Info().
+		Str("method", r.Method).
+		Str("path", r.URL.Path).
+		Str("endpoint_id", endpointID).
+		Msg("List webhook deliveries request received")
+	deliveries, err := h.usecase.ListDeliveries(r.Context(), endpointID, middleware.PrincipalFromContext(r.Context()))
+	if err != nil {
+		h.logger.Error().Err(err).Str("endpoint_id", endpointID).Msg("Failed to list webhook deliveries")
+		h.writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(deliveries); err != nil {
+		h.logger.Error().Err(err).Msg("Failed to encode webhook deliveries")
+	}
+}
+
+func (h *WebhookHandler) CreateSubscription(w http.ResponseWriter, r *http.Request) {
+	h.logger.Info().
+		Str("method", r.Method).
+		Str("path", r.URL.Path).
+		Msg("Create webhook subscription request received")
+	var req dto.CreateSubscriptionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Failed to decode webhook subscription request")
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	sub, err := h.usecase.CreateSubscription(r.Context(), &domain.WebhookSubscription{
+		URL:        req.URL,
+		Secret:     req.Secret,
+		EventTypes: req.EventTypes,
+	})
+	if err != nil {
+		h.logger.Error().Err(err).Msg("Failed to create webhook subscription")
+		h.writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(dto.NewSubscriptionResponse(sub, true)); err != nil {
+		h.logger.Error().Err(err).Msg("Failed to encode webhook subscription")
+	}
+}
+
+func (h *WebhookHandler) ListSubscriptions(w http.ResponseWriter, r *http.Request) {
+	h.logger.Info().
+		Str("method", r.Method).
+		Str("path", r.URL.Path).
+		Msg("List webhook subscriptions request received")
+	subs, err := h.usecase.ListSubscriptions(r.Context())
+	if err != nil {
+		h.logger.Error().Err(err).Msg("Failed to list webhook subscriptions")
+		h.writeError(w, err)
+		return
+	}
+	resp := make([]dto.SubscriptionResponse, 0, len(subs))
+	for _, sub := range subs {
+		resp = append(resp, dto.NewSubscriptionResponse(sub, false))
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		h.logger.Error().Err(err).Msg("Failed to encode webhook subscriptions")
+	}
+}
+
+func (h *WebhookHandler) UpdateSubscription(w http.ResponseWriter, r *http.Request) {
+	subscriptionID := chi.URLParam(r, "id")
+	h.logger.Info().
+		Str("method", r.Method).
+		Str("path", r.URL.Path).
+		Str("subscription_id", subscriptionID).
+		Msg("Update webhook subscription request received")
+	var req dto.UpdateSubscriptionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.Error().Err(err).Msg("Failed to decode webhook subscription update")
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	sub, err := h.usecase.UpdateSubscription(r.Context(), subscriptionID, &domain.WebhookSubscriptionPatch{
+		URL:        req.URL,
+		EventTypes: req.EventTypes,
+		Active:     req.Active,
+	})
+	if err != nil {
+		h.logger.Error().Err(err).Str("subscription_id", subscriptionID).Msg("Failed to update webhook subscription")
+		h.writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(dto.NewSubscriptionResponse(sub, false)); err != nil {
+		h.logger.Error().Err(err).Msg("Failed to encode webhook subscription")
+	}
+}
+
+func (h *WebhookHandler) DeleteSubscription(w http.ResponseWriter, r *http.Request) {
+	subscriptionID := chi.URLParam(r, "id")
+	h.logger.Info().
+		Str("method", r.Method).
+		Str("path", r.URL.Path).
+		Str("subscription_id", subscriptionID).
+		Msg("Delete webhook subscription request received")
+	if err := h.usecase.DeleteSubscription(r.Context(), subscriptionID); err != nil {
+		h.logger.Error().Err(err).Str("subscription_id", subscriptionID).Msg("Failed to delete webhook subscription")
+		h.writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *WebhookHandler) ListSubscriptionDeliveries(w http.ResponseWriter, r *http.Request) {
+	subscriptionID := chi.URLParam(r, "id")
+	h.logger.Info().
+		Str("method", r.Method).
+		Str("path", r.URL.Path).
+		Str("subscription_id", subscriptionID).
+		Msg("List webhook subscription deliveries request received")
+	deliveries, err := h.usecase.ListSubscriptionDeliveries(r.Context(), subscriptionID)
+	if err != nil {
+		h.logger.Error().Err(err).Str("subscription_id", subscriptionID).Msg("Failed to list webhook subscription deliveries")
+		h.writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(deliveries); err != nil {
+		h.logger.Error().Err(err).Msg("Failed to encode webhook deliveries")
+	}
+}
+
+func (h *WebhookHandler) PingSubscription(w http.ResponseWriter, r *http.Request) {
+	subscriptionID := chi.URLParam(r, "id")
+	h.logger.Info().
+		Str("method", r.Method).
+		Str("path", r.URL.Path).
+		Str("subscription_id", subscriptionID).
+		Msg("Ping webhook subscription request received")
+	delivery, err := h.usecase.PingSubscription(r.Context(), subscriptionID)
+	if err != nil {
+		h.logger.Error().Err(err).Str("subscription_id", subscriptionID).Msg("Failed to ping webhook subscription")
+		h.writeError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(delivery); err != nil {
+		h.logger.Error().Err(err).Msg("Failed to encode webhook delivery")
+	}
+}
+
+func (h *WebhookHandler) writeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, webhookErr.ErrEndpointNotFound):
+		http.Error(w, "Webhook endpoint not found", http.StatusNotFound)
+	case errors.Is(err, webhookErr.ErrSubscriptionNotFound):
+		http.Error(w, "Webhook subscription not found", http.StatusNotFound)
+	case errors.Is(err, webhookErr.ErrOrganizerNotFound):
+		http.Error(w, "Organizer not found", http.StatusNotFound)
+	case errors.Is(err, webhookErr.ErrForbidden):
+		http.Error(w, "Forbidden", http.StatusForbidden)
+	case errors.Is(err, webhookErr.ErrInvalidURL),
+		errors.Is(err, webhookErr.ErrInvalidEventType),
+		errors.Is(err, webhookErr.ErrSecretTooShort):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, webhookErr.ErrTooManyEndpoints):
+		http.Error(w, err.Error(), http.StatusConflict)
+	default:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,103 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"event-booker/internal/domain"
+)
+
+type tokenParser interface {
+	Parse(token string) (*domain.Principal, error)
+}
+
+type membershipLoader interface {
+	Memberships(ctx context.Context, userID string) (map[string]domain.OrganizerRole, error)
+}
+
+type principalKey struct{}
+
+func WithPrincipal(ctx context.Context, principal *domain.Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+func PrincipalFromContext(ctx context.Context) *domain.Principal {
+	principal, _ := ctx.Value(principalKey{}).(*domain.Principal)
+	return principal
+}
+
+// Authenticate attaches the principal from a Bearer token to the request context,
+// together with the caller's current organizer memberships.
+// Requests without a token pass through anonymously; a malformed or expired token is rejected.
+func Authenticate(tokens tokenParser, members membershipLoader) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			header := r.Header.Get("Authorization")
+			if header == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			token, ok := strings.CutPrefix(header, "Bearer ")
+			if !ok {
+				http.Error(w, "Invalid authorization header", http.StatusUnauthorized)
+				return
+			}
+			principal, err := tokens.Parse(strings.TrimSpace(token))
+			if err != nil {
+				http.Error(w, "Invalid or expired token", http.StatusUnauthorized)
+				return
+			}
+			principal.Memberships, err = members.Memberships(r.Context(), principal.UserID)
+			if err != nil {
+				http.Error(w, "Failed to load organizer memberships", http.StatusInternalServerError)
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(WithPrincipal(r.Context(), principal)))
+		})
+	}
+}
+
+func RequireAuth(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if PrincipalFromContext(r.Context()) == nil {
+			http.Error(w, "Authentication required", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func RequireRole(role domain.UserRole) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			principal := PrincipalFromContext(r.Context())
+			if principal == nil {
+				http.Error(w, "Authentication required", http.StatusUnauthorized)
+				return
+			}
+			if principal.Role != role {
+				http.Error(w, "Forbidden", http.StatusForbidden)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// RequireStaff admits admins and members of any organizer; the usecases then
+// check which organizer's data the caller may touch.
+func RequireStaff(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		principal := PrincipalFromContext(r.Context())
+		if principal == nil {
+			http.Error(w, "Authentication required", http.StatusUnauthorized)
+			return
+		}
+		if !principal.IsStaff() {
+			http.Error(w, "Forbidden", http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,129 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+
+	"event-booker/internal/domain"
+	idempotencyErr "event-booker/internal/usecase/idempotency"
+
+	"github.com/wb-go/wbf/zlog"
+)
+
+const (
+	IdempotencyKeyHeader      = "Idempotency-Key"
+	IdempotentReplayedHeader  = "Idempotent-Replayed"
+	maxIdempotencyKeyLength   = 255
+	maxIdempotentRequestBytes = 1 << 20
+)
+
+type idempotencyStore interface {
+	Begin(ctx context.Context, userID, key, fingerprint string) (*domain.IdempotencyRecord, error)
+	Complete(ctx context.Context, claim *domain.IdempotencyRecord, statusCode int, contentType string, body []byte) error
+	Release(ctx context.Context, claim *domain.IdempotencyRecord) error
+}
+
+// Idempotency replays the stored response when an authenticated client repeats
+// a request with the same Idempotency-Key. Reusing a key for a different method,
+// path or body is rejected with 422 and bodies over 1MB with 413. Server errors
+// are not stored so that the client can retry them. It must run after
+// RequireAuth.
+func Idempotency(store idempotencyStore) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(IdempotencyKeyHeader)
+			principal := PrincipalFromContext(r.Context())
+			if key == "" || principal == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if len(key) > maxIdempotencyKeyLength {
+				http.Error(w, "Idempotency-Key is too long", http.StatusBadRequest)
+				return
+			}
+			body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxIdempotentRequestBytes))
+			if err != nil {
+				var tooLarge *http.MaxBytesError
+				if errors.As(err, &tooLarge) {
+					http.Error(w, "Request body is too large", http.StatusRequestEntityTooLarge)
+					return
+				}
+				http.Error(w, "Invalid request body", http.StatusBadRequest)
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+			r.ContentLength = int64(len(body))
+			sum := sha256.New()
+			sum.Write([]byte(r.Method + " " + r.URL.Path + "\n"))
+			sum.Write(body)
+			fingerprint := hex.EncodeToString(sum.Sum(nil))
+
+			record, err := store.Begin(r.Context(), principal.UserID, key, fingerprint)
+			switch {
+			case errors.Is(err, idempotencyErr.ErrFingerprintMismatch):
+				http.Error(w, "Idempotency-Key was already used with a different request", http.StatusUnprocessableEntity)
+				return
+			case errors.Is(err, idempotencyErr.ErrRequestInProgress):
+				http.Error(w, "A request with this Idempotency-Key is still being processed", http.StatusConflict)
+				return
+			case err != nil:
+				zlog.Logger.Error().Err(err).Str("idempotency_key", key).Msg("Failed to check idempotency key")
+				http.Error(w, "Internal server error", http.StatusInternalServerError)
+				return
+			case record.CompletedAt != nil:
+				if record.ContentType != "" {
+					w.Header().Set("Content-Type", record.ContentType)
+				}
+				w.Header().Set(IdempotentReplayedHeader, "true")
+				w.WriteHeader(record.StatusCode)
+				w.Write(record.Body)
+				return
+			}
+
+			rec := &responseRecorder{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(rec, r)
+			// The request context may already be cancelled by now; the outcome
+			// still has to be persisted.
+			ctx := context.WithoutCancel(r.Context())
+			if rec.status >= http.StatusInternalServerError {
+				if err := store.Release(ctx, record); err != nil {
+					zlog.Logger.Error().Err(err).Str("idempotency_key", key).Msg("Failed to release idempotency key")
+				}
+				return
+			}
+			err = store.Complete(ctx, record, rec.status, rec.Header().Get("Content-Type"), rec.body.Bytes())
+			switch {
+			case errors.Is(err, idempotencyErr.ErrClaimLost):
+				zlog.Logger.Warn().Str("idempotency_key", key).Msg("Idempotency key was taken over, response not stored")
+			case err != nil:
+				zlog.Logger.Error().Err(err).Str("idempotency_key", key).Msg("Failed to store idempotent response")
+			}
+		})
+	}
+}
+
+type responseRecorder struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+	body        bytes.Buffer
+}
+
+func (r *responseRecorder) WriteHeader(status int) {
+	if !r.wroteHeader {
+		r.status = status
+		r.wroteHeader = true
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *responseRecorder) Write(p []byte) (int, error) {
+	r.wroteHeader = true
+	r.body.Write(p)
+	return r.ResponseWriter.Write(p)
+}
@@ -7,31 +7,75 @@ import (
 	"os"
 	"path/filepath"
 
+	"event-booker/internal/auth"
+	"event-booker/internal/domain"
 	"event-booker/internal/http-server/handler/booking"
 	"event-booker/internal/http-server/handler/event"
+	"event-booker/internal/http-server/handler/organizer"
+	"event-booker/internal/http-server/handler/outbox"
+	"event-booker/internal/http-server/handler/payment"
+	"event-booker/internal/http-server/handler/promo"
+	"event-booker/internal/http-server/handler/seat"
+	"event-booker/internal/http-server/handler/stream"
 	"event-booker/internal/http-server/handler/user"
+	"event-booker/internal/http-server/handler/venue"
+	"event-booker/internal/http-server/handler/waitlist"
+	"event-booker/internal/http-server/handler/webhook"
 	"event-booker/internal/http-server/middleware"
+	"event-booker/internal/metrics"
+	idempotency_uc "event-booker/internal/usecase/idempotency"
+	organizer_uc "event-booker/internal/usecase/organizer"
 
 	"github.com/go-chi/chi/v5"
 )
 
 type Handler struct {
-	EventHandler   *event.EventHandler
-	BookingHandler *booking.BookingHandler
-	UserHandler    *user.UserHandler
+	EventHandler     *event.EventHandler
+	BookingHandler   *booking.BookingHandler
+	UserHandler      *user.UserHandler
+	WaitlistHandler  *waitlist.WaitlistHandler
+	OutboxHandler    *outbox.OutboxHandler
+	PaymentHandler   *payment.PaymentHandler
+	WebhookHandler   *webhook.WebhookHandler
+	StreamHandler    *stream.StreamHandler
+	SeatHandler      *seat.SeatHandler
+	PromoHandler     *promo.PromoHandler
+	VenueHandler     *venue.VenueHandler
+	OrganizerHandler *organizer.OrganizerHandler
+	// FakeGateway serves the checkout pages of the built-in fake payment
+	// provider; nil when a real provider is configured.
+	FakeGateway http.Handler
 }
 
-func SetupRouter(h *Handler) http.Handler {
+func SetupRouter(h *Handler, tokens *auth.TokenManager, memberships *organizer_uc.OrganizerUsecase, idempotency *idempotency_uc.IdempotencyUsecase) http.Handler {
 	r := chi.NewRouter()
 	r.Use(middleware.LoggingMiddleware)
+	r.Use(middleware.Metrics)
+	r.Use(middleware.Authenticate(tokens, memberships))
+	requireAdmin := middleware.RequireRole(domain.RoleAdmin)
+	requireStaff := middleware.RequireStaff
+	idempotent := middleware.Idempotency(idempotency)
 	r.Route("/api", func(r chi.Router) {
+		r.Post("/auth/login", h.UserHandler.Login)
 		r.Route("/events", func(r chi.Router) {
 			r.Get("/", h.EventHandler.ListEvents)
-			r.Post("/", h.EventHandler.CreateEvent)
+			r.Get("/stream", h.StreamHandler.StreamEvents)
+			r.With(requireStaff).Get("/managed", h.EventHandler.ListManagedEvents)
 			r.Get("/{id}", h.EventHandler.GetEvent)
-			r.Delete("/{id}", h.EventHandler.DeleteEvent)
-			r.Post("/{id}/book", h.BookingHandler.Book)
-			r.Post("/{id}/confirm", func(w http.ResponseWriter, r *http.Request) {
+			r.Get("/{id}/stream", h.StreamHandler.StreamEvent)
+			r.Get("/{id}/seats", h.SeatHandler.GetSeatMap)
+			r.Get("/{id}/tiers", h.EventHandler.ListTiers)
+			r.With(requireStaff).Post("/", h.EventHandler.CreateEvent)
+			r.With(requireStaff).Patch("/{id}", h.EventHandler.UpdateEvent)
+			r.With(requireStaff).Delete("/{id}", h.EventHandler.DeleteEvent)
+			r.With(requireStaff).Put("/{id}/capacity", h.EventHandler.UpdateCapacity)
+			r.With(requireStaff).Post("/{id}/tiers", h.EventHandler.CreateTier)
+			r.With(requireStaff).Put("/{id}/tiers/{tierID}", h.EventHandler.UpdateTier)
+			r.With(requireStaff).Delete("/{id}/tiers/{tierID}", h.EventHandler.DeleteTier)
+			r.With(requireStaff).Get("/{id}/waitlist", h.WaitlistHandler.List)
+			r.With(middleware.RequireAuth, idempotent).Post("/{id}/book", h.BookingHandler.Book)
+			r.With(middleware.RequireAuth).Post("/{id}/waitlist", h.WaitlistHandler.Join)
+			r.With(middleware.RequireAuth, idempotent).Post("/{id}/confirm", func(w http.ResponseWriter, r *http.Request) {
 				var req struct {
 					BookingID string `json:"booking_id"`
 				}
@@ -46,18 +90,77 @@ func SetupRouter(h *Handler) http.Handler {
 				h.BookingHandler.Confirm(w, r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, map[string]string{"id": req.BookingID})))
 			})
 		})
+		r.Route("/venues", func(r chi.Router) {
+			r.Get("/", h.VenueHandler.ListVenues)
+			r.Get("/{id}", h.VenueHandler.GetVenue)
+			r.With(requireAdmin).Post("/", h.VenueHandler.CreateVenue)
+			r.With(requireAdmin).Patch("/{id}", h.VenueHandler.UpdateVenue)
+			r.With(requireAdmin).Delete("/{id}", h.VenueHandler.DeleteVenue)
+		})
+		r.Route("/organizers", func(r chi.Router) {
+			r.Get("/", h.OrganizerHandler.ListOrganizers)
+			r.Get("/{id}", h.OrganizerHandler.GetOrganizer)
+			r.With(requireAdmin).Post("/", h.OrganizerHandler.CreateOrganizer)
+			r.With(middleware.RequireAuth).Patch("/{id}", h.OrganizerHandler.UpdateOrganizer)
+			r.With(requireAdmin).Delete("/{id}", h.OrganizerHandler.DeleteOrganizer)
+			r.With(middleware.RequireAuth).Get("/{id}/members", h.OrganizerHandler.ListMembers)
+			r.With(middleware.RequireAuth).Put("/{id}/members/{userID}", h.OrganizerHandler.SetMember)
+			r.With(middleware.RequireAuth).Delete("/{id}/members/{userID}", h.OrganizerHandler.RemoveMember)
+			r.With(middleware.RequireAuth).Get("/{id}/webhooks", h.WebhookHandler.ListOrganizerEndpoints)
+			r.With(middleware.RequireAuth).Post("/{id}/webhooks", h.WebhookHandler.CreateOrganizerEndpoint)
+		})
+		r.Route("/series", func(r chi.Router) {
+			r.Get("/{id}", h.EventHandler.GetSeries)
+			r.With(requireStaff).Post("/", h.EventHandler.CreateSeries)
+			r.With(requireStaff).Patch("/{id}", h.EventHandler.UpdateSeries)
+			r.With(requireStaff).Delete("/{id}", h.EventHandler.CancelSeries)
+		})
 		r.Route("/bookings", func(r chi.Router) {
-			r.Get("/", h.BookingHandler.ListBookings)
-			r.Post("/{id}/confirm", h.BookingHandler.Confirm)
-			r.Delete("/{id}", h.BookingHandler.Cancel)
+			r.With(requireStaff).Get("/", h.BookingHandler.ListBookings)
+			r.With(middleware.RequireAuth, idempotent).Post("/{id}/confirm", h.BookingHandler.Confirm)
+			r.With(middleware.RequireAuth).Post("/{id}/pay", h.PaymentHandler.StartPayment)
+			r.With(middleware.RequireAuth, idempotent).Delete("/{id}", h.BookingHandler.Cancel)
+		})
+		r.Post("/payments/webhook", h.PaymentHandler.Webhook)
+		r.Route("/admin", func(r chi.Router) {
+			r.Use(requireAdmin)
+			r.Get("/outbox", h.OutboxHandler.ListMessages)
+			r.Post("/outbox/{id}/replay", h.OutboxHandler.ReplayMessage)
+			r.Get("/webhooks", h.WebhookHandler.ListSubscriptions)
+			r.Post("/webhooks", h.WebhookHandler.CreateSubscription)
+			r.Patch("/webhooks/{id}", h.WebhookHandler.UpdateSubscription)
+			r.Delete("/webhooks/{id}", h.WebhookHandler.DeleteSubscription)
+			r.Get("/webhooks/{id}/deliveries", h.WebhookHandler.ListSubscriptionDeliveries)
+			r.Post("/webhooks/{id}/ping", h.WebhookHandler.PingSubscription)
+			r.Get("/layouts", h.SeatHandler.ListLayouts)
+			r.Post("/layouts", h.SeatHandler.CreateLayout)
+			r.Get("/layouts/{id}", h.SeatHandler.GetLayout)
+			r.Get("/promo-codes", h.PromoHandler.ListPromoCodes)
+			r.Post("/promo-codes", h.PromoHandler.CreatePromoCode)
+			r.Patch("/promo-codes/{id}", h.PromoHandler.UpdatePromoCode)
+			r.Delete("/promo-codes/{id}", h.PromoHandler.DeletePromoCode)
 		})
 		r.Route("/users", func(r chi.Router) {
 			r.Post("/", h.UserHandler.Register)
-			r.Get("/{id}", h.UserHandler.GetUser)
+			r.With(middleware.RequireAuth).Get("/{id}", h.UserHandler.GetUser)
+			r.With(middleware.RequireAuth).Get("/{id}/bookings", h.BookingHandler.ListUserBookings)
+			r.With(middleware.RequireAuth).Get("/{id}/notification-preferences", h.UserHandler.GetNotificationPreferences)
+			r.With(middleware.RequireAuth).Patch("/{id}/notification-preferences", h.UserHandler.UpdateNotificationPreferences)
+			r.With(middleware.RequireAuth).Get("/{id}/webhooks", h.WebhookHandler.ListEndpoints)
+			r.With(middleware.RequireAuth).Post("/{id}/webhooks", h.WebhookHandler.CreateEndpoint)
+		})
+		r.Route("/webhooks", func(r chi.Router) {
+			r.Use(middleware.RequireAuth)
+			r.Delete("/{id}", h.WebhookHandler.DeleteEndpoint)
+			r.Get("/{id}/deliveries", h.WebhookHandler.ListDeliveries)
 		})
 	})
+	r.Handle("/metrics", metrics.Handler())
 	workDir, _ := os.Getwd()
 	r.Handle("/static/*", http.StripPrefix("/static/", http.FileServer(http.Dir(filepath.Join(workDir, "static")))))
+	if h.FakeGateway != nil {
+		r.Mount("/fake-pay", http.StripPrefix("/fake-pay", h.FakeGateway))
+	}
 	r.Get("/", serveIndex)
 	r.Get("/admin", serveAdmin)
 	return r
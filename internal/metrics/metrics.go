@@ -0,0 +1,113 @@
+// Package metrics holds the Prometheus collectors exposed on /metrics.
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "eventbooker"
+
+type BookingOutcome string
+
+const (
+	BookingCreated         BookingOutcome = "created"
+	BookingConfirmed       BookingOutcome = "confirmed"
+	BookingCancelled       BookingOutcome = "cancelled"
+	BookingExpired         BookingOutcome = "expired"
+	BookingRejectedNoSeats BookingOutcome = "rejected_no_seats"
+)
+
+var (
+	httpRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "HTTP request latency by chi route pattern.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	bookingsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "bookings_total",
+		Help:      "Booking outcomes.",
+	}, []string{"outcome"})
+
+	schedulerRunDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "scheduler_run_duration_seconds",
+		Help:      "Duration of scheduler job runs.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"job"})
+
+	expiredBookingsTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "scheduler_expired_bookings_total",
+		Help:      "Bookings cancelled by the expiration job.",
+	})
+
+	notificationsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "notifications_total",
+		Help:      "Notification deliveries by channel and result.",
+	}, []string{"channel", "result"})
+
+	streamClients = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "stream_clients",
+		Help:      "Open server-sent event streams.",
+	})
+)
+
+func init() {
+	for _, outcome := range []BookingOutcome{BookingCreated, BookingConfirmed, BookingCancelled, BookingExpired, BookingRejectedNoSeats} {
+		bookingsTotal.WithLabelValues(string(outcome))
+	}
+}
+
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+// RegisterDB exports connection pool statistics of db under the given name.
+func RegisterDB(db *sql.DB, name string) error {
+	return prometheus.Register(collectors.NewDBStatsCollector(db, name))
+}
+
+func ObserveHTTPRequest(method, route string, status int, duration time.Duration) {
+	httpRequestDuration.WithLabelValues(method, route, strconv.Itoa(status)).Observe(duration.Seconds())
+}
+
+func ObserveBooking(outcome BookingOutcome, count int) {
+	bookingsTotal.WithLabelValues(string(outcome)).Add(float64(count))
+}
+
+func ObserveSchedulerRun(job string, duration time.Duration) {
+	schedulerRunDuration.WithLabelValues(job).Observe(duration.Seconds())
+}
+
+func ObserveExpiredBookings(count int) {
+	expiredBookingsTotal.Add(float64(count))
+}
+
+func ObserveNotification(channel string, err error) {
+	result := "success"
+	if err != nil {
+		result = "failure"
+	}
+	notificationsTotal.WithLabelValues(channel, result).Inc()
+}
+
+func StreamOpened() {
+	streamClients.Inc()
+}
+
+func StreamClosed() {
+	streamClients.Dec()
+}
@@ -0,0 +1,63 @@
+// Package notification defines the contract between the notification
+// dispatcher and the delivery channels.
+package notification
+
+import (
+	"time"
+
+	"event-booker/internal/domain"
+)
+
+// Channel delivers notifications over one medium. Reachable reports whether
+// the user has an address on the channel at all.
+type Channel interface {
+	Name() domain.NotificationChannel
+	Reachable(user *domain.User) bool
+	NotifyBookingCreated(user *domain.User, booking *domain.Booking, event *domain.Event) error
+	NotifyPaymentPending(user *domain.User, booking *domain.Booking, event *domain.Event) error
+	NotifyBookingConfirmed(user *domain.User, booking *domain.Booking, event *domain.Event) error
+	NotifyBookingExpired(user *domain.User, booking *domain.Booking, event *domain.Event) error
+	NotifyBookingCancelled(user *domain.User, booking *domain.Booking, event *domain.Event) error
+	NotifyEventCancelled(user *domain.User, booking *domain.Booking, event *domain.Event, reason string) error
+	NotifyWaitlistPromotion(user *domain.User, booking *domain.Booking, event *domain.Event) error
+	NotifyEventRescheduled(user *domain.User, event *domain.Event, previousDate time.Time) error
+	NotifyEventReminder(user *domain.User, booking *domain.Booking, event *domain.Event) error
+}
+
+// Registry holds the channels notifications can be sent over, in registration
+// order.
+type Registry struct {
+	channels []Channel
+}
+
+func NewRegistry(channels ...Channel) *Registry {
+	r := &Registry{}
+	for _, ch := range channels {
+		r.Register(ch)
+	}
+	return r
+}
+
+// Register adds ch, replacing any channel registered under the same name.
+func (r *Registry) Register(ch Channel) {
+	for i, existing := range r.channels {
+		if existing.Name() == ch.Name() {
+			r.channels[i] = ch
+			return
+		}
+	}
+	r.channels = append(r.channels, ch)
+}
+
+func (r *Registry) Channels() []Channel {
+	return r.channels
+}
+
+func (r *Registry) Get(name domain.NotificationChannel) (Channel, bool) {
+	for _, ch := range r.channels {
+		if ch.Name() == name {
+			return ch, true
+		}
+	}
+	return nil, false
+}
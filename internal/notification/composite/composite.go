@@ -2,34 +2,46 @@ package composite
 
 import (
 	"event-booker/internal/domain"
-	"event-booker/internal/notification/email"
-	"event-booker/internal/notification/telegram"
+	"event-booker/internal/notification"
 	"fmt"
+	"slices"
 )
 
+// CompositeNotifier fans a notification out to the registered channels the
+// user can be reached on, as selected by their notification preferences.
 type CompositeNotifier struct {
-	email    *email.Notifier
-	telegram *telegram.Notifier
+	registry *notification.Registry
 }
 
-func NewCompositeNotifier(email *email.Notifier, telegram *telegram.Notifier) *CompositeNotifier {
-	return &CompositeNotifier{email: email, telegram: telegram}
+func NewCompositeNotifier(registry *notification.Registry) *CompositeNotifier {
+	return &CompositeNotifier{registry: registry}
 }
 
-func (c *CompositeNotifier) NotifyCancellation(user *domain.User, booking *domain.Booking) error {
-	var errs []error
-	if user.Email != "" {
-		if err := c.email.NotifyCancellation(user, booking); err != nil {
-			errs = append(errs, err)
+// Send calls notify for every channel selected for the user except those in
+// delivered, which already received the notification on an earlier attempt.
+// It returns delivered extended with the channels that succeeded this time,
+// so a retry after a partial failure only resends to the failed channels.
+func (c *CompositeNotifier) Send(user *domain.User, kind domain.NotificationKind, delivered []domain.NotificationChannel, notify func(ch notification.Channel) error) ([]domain.NotificationChannel, error) {
+	var reachable []domain.NotificationChannel
+	for _, ch := range c.registry.Channels() {
+		if ch.Reachable(user) {
+			reachable = append(reachable, ch.Name())
 		}
 	}
-	if user.Telegram != "" {
-		if err := c.telegram.NotifyCancellation(user, booking); err != nil {
-			errs = append(errs, err)
+	var errs []error
+	for _, name := range user.NotificationChannels(kind, reachable) {
+		if slices.Contains(delivered, name) {
+			continue
+		}
+		ch, _ := c.registry.Get(name)
+		if err := notify(ch); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+			continue
 		}
+		delivered = append(delivered, name)
 	}
 	if len(errs) > 0 {
-		return fmt.Errorf("notification errors: %v", errs)
+		return delivered, fmt.Errorf("notification errors: %v", errs)
 	}
-	return nil
+	return delivered, nil
 }
@@ -1,27 +1,160 @@
 package email
 
 import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
 	"event-booker/internal/config"
 	"event-booker/internal/domain"
+	"event-booker/internal/metrics"
 	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
 	"net/smtp"
+	"net/textproto"
+	"strings"
+	"time"
 )
 
 type Notifier struct {
-	cfg *config.Config
+	cfg       *config.Config
+	templates *renderer
 }
 
-func NewNotifier(cfg *config.Config) *Notifier {
-	return &Notifier{cfg: cfg}
+// NewNotifier parses the email templates, preferring files found in the
+// configured templates directory over the built-in ones.
+func NewNotifier(cfg *config.Config) (*Notifier, error) {
+	templates, err := newRenderer(cfg.EmailConfig.TemplatesDir)
+	if err != nil {
+		return nil, err
+	}
+	return &Notifier{cfg: cfg, templates: templates}, nil
 }
 
-func (n *Notifier) NotifyCancellation(user *domain.User, booking *domain.Booking) error {
+func (n *Notifier) Name() domain.NotificationChannel {
+	return domain.ChannelEmail
+}
+
+func (n *Notifier) Reachable(user *domain.User) bool {
+	return user.Email != ""
+}
+
+func (n *Notifier) NotifyBookingCreated(user *domain.User, booking *domain.Booking, event *domain.Event) error {
+	return n.notify(domain.NotificationBookingCreated, &message{User: user, Booking: booking, Event: event})
+}
+
+func (n *Notifier) NotifyPaymentPending(user *domain.User, booking *domain.Booking, event *domain.Event) error {
+	return n.notify(domain.NotificationPaymentPending, &message{User: user, Booking: booking, Event: event})
+}
+
+func (n *Notifier) NotifyBookingConfirmed(user *domain.User, booking *domain.Booking, event *domain.Event) error {
+	return n.notify(domain.NotificationBookingConfirmed, &message{User: user, Booking: booking, Event: event})
+}
+
+func (n *Notifier) NotifyBookingExpired(user *domain.User, booking *domain.Booking, event *domain.Event) error {
+	return n.notify(domain.NotificationBookingExpired, &message{User: user, Booking: booking, Event: event})
+}
+
+func (n *Notifier) NotifyBookingCancelled(user *domain.User, booking *domain.Booking, event *domain.Event) error {
+	return n.notify(domain.NotificationBookingCancelled, &message{User: user, Booking: booking, Event: event})
+}
+
+func (n *Notifier) NotifyEventCancelled(user *domain.User, booking *domain.Booking, event *domain.Event, reason string) error {
+	return n.notify(domain.NotificationEventCancelled, &message{User: user, Booking: booking, Event: event, Reason: reason})
+}
+
+func (n *Notifier) NotifyWaitlistPromotion(user *domain.User, booking *domain.Booking, event *domain.Event) error {
+	return n.notify(domain.NotificationWaitlistPromoted, &message{User: user, Booking: booking, Event: event})
+}
+
+func (n *Notifier) NotifyEventRescheduled(user *domain.User, event *domain.Event, previousDate time.Time) error {
+	return n.notify(domain.NotificationEventRescheduled, &message{User: user, Event: event, PreviousDate: previousDate})
+}
+
+func (n *Notifier) NotifyEventReminder(user *domain.User, booking *domain.Booking, event *domain.Event) error {
+	return n.notify(domain.NotificationEventReminder, &message{User: user, Booking: booking, Event: event})
+}
+
+func (n *Notifier) notify(kind domain.NotificationKind, msg *message) error {
+	baseURL := strings.TrimSuffix(n.cfg.EmailConfig.BaseURL, "/")
+	msg.Links = links{
+		Manage: baseURL + "/",
+		Event:  baseURL + "/?event=" + msg.Event.ID,
+	}
+	content, err := n.templates.render(kind, msg)
+	if err != nil {
+		return err
+	}
+	return n.send(msg.User, content)
+}
+
+func (n *Notifier) send(user *domain.User, content *rendered) error {
+	msg, err := n.compose(user, content)
+	if err != nil {
+		return err
+	}
 	auth := smtp.PlainAuth("", n.cfg.EmailConfig.SMTPUser, n.cfg.EmailConfig.SMTPPassword, n.cfg.EmailConfig.SMTPHost)
-	to := []string{user.Email}
-	msg := []byte("To: " + user.Email + "\r\n" +
-		"Subject: Booking Cancelled\r\n" +
-		"\r\n" +
-		"Your booking for event " + booking.EventID + " has been cancelled due to expiration.\r\n")
 	addr := fmt.Sprintf("%s:%d", n.cfg.EmailConfig.SMTPHost, n.cfg.EmailConfig.SMTPPort)
-	return smtp.SendMail(addr, auth, n.cfg.EmailConfig.FromEmail, to, msg)
+	err = smtp.SendMail(addr, auth, n.cfg.EmailConfig.FromEmail, []string{user.Email}, msg)
+	metrics.ObserveNotification("email", err)
+	return err
+}
+
+// compose builds a multipart/alternative message carrying the plain-text and
+// HTML bodies, both quoted-printable encoded.
+func (n *Notifier) compose(user *domain.User, content *rendered) ([]byte, error) {
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	for _, part := range []struct {
+		contentType string
+		text        string
+	}{
+		{"text/plain; charset=utf-8", content.text},
+		{"text/html; charset=utf-8", content.html},
+	} {
+		w, err := mw.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		qp := quotedprintable.NewWriter(w)
+		if _, err := qp.Write([]byte(part.text)); err != nil {
+			return nil, err
+		}
+		if err := qp.Close(); err != nil {
+			return nil, err
+		}
+	}
+	if err := mw.Close(); err != nil {
+		return nil, err
+	}
+
+	var msg bytes.Buffer
+	header := func(key, value string) {
+		msg.WriteString(key + ": " + value + "\r\n")
+	}
+	header("From", n.cfg.EmailConfig.FromEmail)
+	header("To", user.Email)
+	subject := strings.NewReplacer("\r", " ", "\n", " ").Replace(content.subject)
+	header("Subject", mime.QEncoding.Encode("utf-8", subject))
+	header("Date", time.Now().Format(time.RFC1123Z))
+	header("Message-ID", messageID(n.cfg.EmailConfig.FromEmail))
+	header("MIME-Version", "1.0")
+	header("Content-Type", "multipart/alternative; boundary="+mw.Boundary())
+	msg.WriteString("\r\n")
+	msg.Write(body.Bytes())
+	return msg.Bytes(), nil
+}
+
+func messageID(from string) string {
+	domainPart := "event-booker"
+	if i := strings.LastIndex(from, "@"); i >= 0 {
+		domainPart = from[i+1:]
+	}
+	b := make([]byte, 16)
+	rand.Read(b)
+	return "<" + hex.EncodeToString(b) + "@" + domainPart + ">"
 }
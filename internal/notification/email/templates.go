@@ -0,0 +1,165 @@
+package email
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"event-booker/internal/domain"
+)
+
+//go:embed templates
+var embedded embed.FS
+
+var kinds = []domain.NotificationKind{
+	domain.NotificationBookingCreated,
+	domain.NotificationPaymentPending,
+	domain.NotificationBookingConfirmed,
+	domain.NotificationBookingExpired,
+	domain.NotificationBookingCancelled,
+	domain.NotificationEventCancelled,
+	domain.NotificationWaitlistPromoted,
+	domain.NotificationEventRescheduled,
+	domain.NotificationEventReminder,
+}
+
+var locales = []domain.Locale{domain.LocaleEnglish, domain.LocaleRussian}
+
+var dateLayouts = map[domain.Locale]string{
+	domain.LocaleEnglish: "Mon, 2 Jan 2006 15:04 MST",
+	domain.LocaleRussian: "02.01.2006 15:04 MST",
+}
+
+// message is the data passed to the templates.
+type message struct {
+	User         *domain.User
+	Booking      *domain.Booking
+	Event        *domain.Event
+	Reason       string
+	PreviousDate time.Time
+	Links        links
+	locale       domain.Locale
+	location     *time.Location
+}
+
+type links struct {
+	Manage string
+	Event  string
+}
+
+// Date formats t in the recipient's time zone using their locale's layout.
+func (m *message) Date(t time.Time) string {
+	return t.In(m.location).Format(dateLayouts[m.locale])
+}
+
+type rendered struct {
+	subject string
+	text    string
+	html    string
+}
+
+type templateSet struct {
+	text *texttemplate.Template
+	html *htmltemplate.Template
+}
+
+// renderer holds one parsed template set per locale and notification kind.
+// Every template is read from overrideDir when a file with the same relative
+// path exists there and from the embedded defaults otherwise.
+type renderer struct {
+	sets map[domain.Locale]map[domain.NotificationKind]*templateSet
+}
+
+func newRenderer(overrideDir string) (*renderer, error) {
+	r := &renderer{sets: make(map[domain.Locale]map[domain.NotificationKind]*templateSet)}
+	for _, locale := range locales {
+		layout, err := readTemplate(overrideDir, filepath.Join(string(locale), "layout.html"))
+		if err != nil {
+			return nil, err
+		}
+		r.sets[locale] = make(map[domain.NotificationKind]*templateSet)
+		for _, kind := range kinds {
+			set, err := parseSet(overrideDir, locale, kind, layout)
+			if err != nil {
+				return nil, err
+			}
+			r.sets[locale][kind] = set
+		}
+	}
+	return r, nil
+}
+
+func parseSet(overrideDir string, locale domain.Locale, kind domain.NotificationKind, layout []byte) (*templateSet, error) {
+	name := filepath.Join(string(locale), string(kind))
+	textSrc, err := readTemplate(overrideDir, name+".txt")
+	if err != nil {
+		return nil, err
+	}
+	text, err := texttemplate.New(name + ".txt").Option("missingkey=error").Parse(string(textSrc))
+	if err != nil {
+		return nil, fmt.Errorf("parse template %s.txt: %w", name, err)
+	}
+	htmlSrc, err := readTemplate(overrideDir, name+".html")
+	if err != nil {
+		return nil, err
+	}
+	html, err := htmltemplate.New("layout").Parse(string(layout))
+	if err != nil {
+		return nil, fmt.Errorf("parse template %s/layout.html: %w", locale, err)
+	}
+	if _, err := html.New(name + ".html").Parse(string(htmlSrc)); err != nil {
+		return nil, fmt.Errorf("parse template %s.html: %w", name, err)
+	}
+	return &templateSet{text: text, html: html}, nil
+}
+
+func readTemplate(overrideDir, name string) ([]byte, error) {
+	if overrideDir != "" {
+		src, err := os.ReadFile(filepath.Join(overrideDir, name))
+		if err == nil {
+			return src, nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("read template override %s: %w", name, err)
+		}
+	}
+	src, err := embedded.ReadFile("templates/" + filepath.ToSlash(name))
+	if err != nil {
+		return nil, fmt.Errorf("read template %s: %w", name, err)
+	}
+	return src, nil
+}
+
+// render produces the subject and both bodies of a message in the recipient's
+// locale, falling back to English for unsupported locales.
+func (r *renderer) render(kind domain.NotificationKind, msg *message) (*rendered, error) {
+	locale := msg.User.Locale
+	if !locale.Valid() {
+		locale = domain.DefaultLocale
+	}
+	set, ok := r.sets[locale][kind]
+	if !ok {
+		return nil, fmt.Errorf("no email template for %q", kind)
+	}
+	msg.locale = locale
+	msg.location = msg.User.Location()
+	var subject, text, html bytes.Buffer
+	if err := set.text.ExecuteTemplate(&subject, "subject", msg); err != nil {
+		return nil, fmt.Errorf("render %s subject: %w", kind, err)
+	}
+	if err := set.text.ExecuteTemplate(&text, "text", msg); err != nil {
+		return nil, fmt.Errorf("render %s text: %w", kind, err)
+	}
+	if err := set.html.ExecuteTemplate(&html, "layout", msg); err != nil {
+		return nil, fmt.Errorf("render %s html: %w", kind, err)
+	}
+	return &rendered{subject: strings.TrimSpace(subject.String()), text: text.String(), html: html.String()}, nil
+}
@@ -0,0 +1,24 @@
+package outbox
+
+import (
+	"context"
+	"time"
+
+	"event-booker/internal/domain"
+	"event-booker/internal/notification"
+)
+
+type outboxRepository interface {
+	ClaimDue(ctx context.Context, limit int, leaseUntil time.Time) ([]*domain.OutboxMessage, error)
+	MarkSent(ctx context.Context, id string, attempts int, delivered []domain.NotificationChannel) error
+	MarkRetry(ctx context.Context, id string, attempts int, delivered []domain.NotificationChannel, nextAttemptAt time.Time, lastError string) error
+	MarkDead(ctx context.Context, id string, attempts int, delivered []domain.NotificationChannel, lastError string) error
+}
+
+type userRepository interface {
+	GetByID(ctx context.Context, id string) (*domain.User, error)
+}
+
+type notifier interface {
+	Send(user *domain.User, kind domain.NotificationKind, delivered []domain.NotificationChannel, notify func(ch notification.Channel) error) ([]domain.NotificationChannel, error)
+}
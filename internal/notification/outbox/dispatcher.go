@@ -0,0 +1,177 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"event-booker/internal/config"
+	"event-booker/internal/domain"
+	"event-booker/internal/notification"
+
+	"github.com/wb-go/wbf/zlog"
+)
+
+// Dispatcher delivers notifications written to the outbox table by the usecases.
+// Failed deliveries are retried with exponential backoff and dead-lettered once
+// the attempt limit is reached.
+type Dispatcher struct {
+	repo     outboxRepository
+	userRepo userRepository
+	notifier notifier
+	cfg      *config.Config
+	logger   *zlog.Zerolog
+	cancel   context.CancelFunc
+	wg       sync.WaitGroup
+}
+
+func NewDispatcher(repo outboxRepository, userRepo userRepository, notifier notifier, cfg *config.Config, logger *zlog.Zerolog) *Dispatcher {
+	return &Dispatcher{
+		repo:     repo,
+		userRepo: userRepo,
+		notifier: notifier,
+		cfg:      cfg,
+		logger:   logger,
+	}
+}
+
+func (d *Dispatcher) Start(ctx context.Context) {
+	ctx, d.cancel = context.WithCancel(ctx)
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+		ticker := time.NewTicker(d.cfg.Outbox.PollInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				d.dispatchBatch(ctx)
+			}
+		}
+	}()
+	d.logger.Info().Msg("Outbox dispatcher started")
+}
+
+func (d *Dispatcher) Stop() {
+	if d.cancel != nil {
+		d.cancel()
+	}
+	d.wg.Wait()
+}
+
+func (d *Dispatcher) dispatchBatch(ctx context.Context) {
+	messages, err := d.repo.ClaimDue(ctx, d.cfg.Outbox.BatchSize, time.Now().Add(d.cfg.Outbox.Lease))
+	if err != nil {
+		d.logger.Error().Err(err).Msg("Failed to claim outbox messages")
+		return
+	}
+	for _, msg := range messages {
+		if ctx.Err() != nil {
+			return
+		}
+		d.dispatch(ctx, msg)
+	}
+}
+
+func (d *Dispatcher) dispatch(ctx context.Context, msg *domain.OutboxMessage) {
+	attempts := msg.Attempts + 1
+	delivered, deliverErr := d.deliver(ctx, msg)
+	if deliverErr == nil {
+		if err := d.repo.MarkSent(ctx, msg.ID, attempts, delivered); err != nil {
+			d.logger.Error().Err(err).Str("message_id", msg.ID).Msg("Failed to mark outbox message sent")
+		}
+		return
+	}
+	if attempts >= d.cfg.Outbox.MaxAttempts {
+		d.logger.Error().
+			Err(deliverErr).
+			Str("message_id", msg.ID).
+			Str("kind", string(msg.Notification.Kind)).
+			Int("attempts", attempts).
+			Msg("Outbox message dead-lettered")
+		if err := d.repo.MarkDead(ctx, msg.ID, attempts, delivered, deliverErr.Error()); err != nil {
+			d.logger.Error().Err(err).Str("message_id", msg.ID).Msg("Failed to dead-letter outbox message")
+		}
+		return
+	}
+	nextAttemptAt := time.Now().Add(d.backoff(attempts))
+	d.logger.Warn().
+		Err(deliverErr).
+		Str("message_id", msg.ID).
+		Str("kind", string(msg.Notification.Kind)).
+		Int("attempts", attempts).
+		Time("next_attempt_at", nextAttemptAt).
+		Msg("Outbox delivery failed, will retry")
+	if err := d.repo.MarkRetry(ctx, msg.ID, attempts, delivered, nextAttemptAt, deliverErr.Error()); err != nil {
+		d.logger.Error().Err(err).Str("message_id", msg.ID).Msg("Failed to reschedule outbox message")
+	}
+}
+
+// deliver sends the message over the channels that have not received it yet
+// and returns the full set of channels it has now been delivered over.
+func (d *Dispatcher) deliver(ctx context.Context, msg *domain.OutboxMessage) ([]domain.NotificationChannel, error) {
+	n := &msg.Notification
+	user, err := d.userRepo.GetByID(ctx, n.UserID)
+	if err != nil {
+		return msg.Delivered, fmt.Errorf("get user %s: %w", n.UserID, err)
+	}
+	notify, err := channelCall(user, n)
+	if err != nil {
+		return msg.Delivered, err
+	}
+	return d.notifier.Send(user, n.Kind, msg.Delivered, notify)
+}
+
+// channelCall picks the channel method that delivers a notification of n's kind.
+func channelCall(user *domain.User, n *domain.Notification) (func(ch notification.Channel) error, error) {
+	if n.Kind == domain.NotificationEventRescheduled {
+		if n.Event == nil || n.PreviousDate == nil {
+			return nil, fmt.Errorf("incomplete %s notification", n.Kind)
+		}
+		return func(ch notification.Channel) error {
+			return ch.NotifyEventRescheduled(user, n.Event, *n.PreviousDate)
+		}, nil
+	}
+	if n.Booking == nil {
+		return nil, fmt.Errorf("incomplete %s notification", n.Kind)
+	}
+	booking := n.Booking
+	event := n.Event
+	if event == nil {
+		event = &domain.Event{ID: booking.EventID, Name: booking.EventID}
+	}
+	switch n.Kind {
+	case domain.NotificationBookingCreated:
+		return func(ch notification.Channel) error { return ch.NotifyBookingCreated(user, booking, event) }, nil
+	case domain.NotificationPaymentPending:
+		return func(ch notification.Channel) error { return ch.NotifyPaymentPending(user, booking, event) }, nil
+	case domain.NotificationBookingConfirmed:
+		return func(ch notification.Channel) error { return ch.NotifyBookingConfirmed(user, booking, event) }, nil
+	case domain.NotificationBookingExpired:
+		return func(ch notification.Channel) error { return ch.NotifyBookingExpired(user, booking, event) }, nil
+	case domain.NotificationBookingCancelled:
+		return func(ch notification.Channel) error { return ch.NotifyBookingCancelled(user, booking, event) }, nil
+	case domain.NotificationEventCancelled:
+		return func(ch notification.Channel) error { return ch.NotifyEventCancelled(user, booking, event, n.Reason) }, nil
+	case domain.NotificationWaitlistPromoted:
+		return func(ch notification.Channel) error { return ch.NotifyWaitlistPromotion(user, booking, event) }, nil
+	case domain.NotificationEventReminder:
+		return func(ch notification.Channel) error { return ch.NotifyEventReminder(user, booking, event) }, nil
+	default:
+		return nil, fmt.Errorf("unknown notification kind %q", n.Kind)
+	}
+}
+
+func (d *Dispatcher) backoff(attempts int) time.Duration {
+	delay := d.cfg.Outbox.BaseBackoff
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= d.cfg.Outbox.MaxBackoff {
+			return d.cfg.Outbox.MaxBackoff
+		}
+	}
+	return delay
+}
@@ -2,9 +2,11 @@ package telegram
 
 import (
 	"event-booker/internal/domain"
+	"event-booker/internal/metrics"
 	"fmt"
 	"net/http"
 	"net/url"
+	"time"
 )
 
 type Notifier struct {
@@ -15,11 +17,75 @@ func NewNotifier(token string) *Notifier {
 	return &Notifier{token: token}
 }
 
-func (n *Notifier) NotifyCancellation(user *domain.User, booking *domain.Booking) error {
+func (n *Notifier) Name() domain.NotificationChannel {
+	return domain.ChannelTelegram
+}
+
+func (n *Notifier) Reachable(user *domain.User) bool {
+	return user.Telegram != ""
+}
+
+func (n *Notifier) NotifyBookingCreated(user *domain.User, booking *domain.Booking, event *domain.Event) error {
+	text := fmt.Sprintf("Booking %s created: %d seat(s) at %s on %s.",
+		booking.ID, booking.Quantity, event.Name, event.Date.Format(time.RFC1123))
+	if booking.Status == domain.BookingPending {
+		text += fmt.Sprintf(" Please complete it before %s.", booking.ExpiresAt.Format(time.RFC1123))
+	}
+	return n.send(user, text)
+}
+
+func (n *Notifier) NotifyPaymentPending(user *domain.User, booking *domain.Booking, event *domain.Event) error {
+	return n.send(user, fmt.Sprintf("Your booking %s for %s is awaiting payment. Please pay before %s or the seats will be released.",
+		booking.ID, event.Name, booking.ExpiresAt.Format(time.RFC1123)))
+}
+
+func (n *Notifier) NotifyBookingConfirmed(user *domain.User, booking *domain.Booking, event *domain.Event) error {
+	return n.send(user, fmt.Sprintf("Booking %s confirmed: %d seat(s) at %s on %s.",
+		booking.ID, booking.Quantity, event.Name, event.Date.Format(time.RFC1123)))
+}
+
+func (n *Notifier) NotifyBookingExpired(user *domain.User, booking *domain.Booking, event *domain.Event) error {
+	return n.send(user, fmt.Sprintf("Your booking %s for %s was not completed in time and has been cancelled.", booking.ID, event.Name))
+}
+
+func (n *Notifier) NotifyBookingCancelled(user *domain.User, booking *domain.Booking, event *domain.Event) error {
+	return n.send(user, fmt.Sprintf("Your booking %s for %s has been cancelled as requested.", booking.ID, event.Name))
+}
+
+func (n *Notifier) NotifyEventCancelled(user *domain.User, booking *domain.Booking, event *domain.Event, reason string) error {
+	text := fmt.Sprintf("%s on %s has been cancelled, and your booking %s with it.",
+		event.Name, event.Date.Format(time.RFC1123), booking.ID)
+	if reason != "" {
+		text += " Reason: " + reason + "."
+	}
+	return n.send(user, text)
+}
+
+func (n *Notifier) NotifyWaitlistPromotion(user *domain.User, booking *domain.Booking, event *domain.Event) error {
+	return n.send(user, fmt.Sprintf("A seat for %s has become available. Your booking %s is reserved until %s, please confirm it before then.",
+		event.Name, booking.ID, booking.ExpiresAt.Format(time.RFC1123)))
+}
+
+func (n *Notifier) NotifyEventRescheduled(user *domain.User, event *domain.Event, previousDate time.Time) error {
+	return n.send(user, fmt.Sprintf("The event %s has been moved from %s to %s. Your booking remains valid.",
+		event.Name, previousDate.Format(time.RFC1123), event.Date.Format(time.RFC1123)))
+}
+
+func (n *Notifier) NotifyEventReminder(user *domain.User, booking *domain.Booking, event *domain.Event) error {
+	return n.send(user, fmt.Sprintf("Reminder: %s starts at %s. You have %d seat(s) booked (booking %s).",
+		event.Name, event.Date.Format(time.RFC1123), booking.Quantity, booking.ID))
+}
+
+func (n *Notifier) send(user *domain.User, text string) error {
 	if user.Telegram == "" {
 		return nil
 	}
-	text := fmt.Sprintf("Your booking for event %s has been cancelled due to expiration.", booking.EventID)
+	err := n.post(user, text)
+	metrics.ObserveNotification("telegram", err)
+	return err
+}
+
+func (n *Notifier) post(user *domain.User, text string) error {
 	u := fmt.Sprintf("https://api.telegram.org/bot%s/sendMessage?chat_id=%s&text=%s",
 		n.token, user.Telegram, url.QueryEscape(text))
 	resp, err := http.Get(u)
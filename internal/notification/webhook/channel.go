@@ -0,0 +1,112 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"event-booker/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+// enqueueTimeout bounds the database work done while handing a notification to
+// the channel; the notifier contract carries no context.
+const enqueueTimeout = 10 * time.Second
+
+// Payload is the JSON body posted to webhook endpoints and subscriptions. Type
+// is the notification kind or domain event type.
+type Payload struct {
+	ID        string      `json:"id"`
+	Type      string      `json:"type"`
+	CreatedAt time.Time   `json:"created_at"`
+	Data      interface{} `json:"data"`
+}
+
+func newPayload(typ string, data interface{}) ([]byte, error) {
+	return json.Marshal(Payload{
+		ID:        uuid.NewString(),
+		Type:      typ,
+		CreatedAt: time.Now().UTC(),
+		Data:      data,
+	})
+}
+
+type PayloadData struct {
+	UserID       string     `json:"user_id"`
+	Booking      *Booking   `json:"booking,omitempty"`
+	Event        *Event     `json:"event,omitempty"`
+	Reason       string     `json:"reason,omitempty"`
+	PreviousDate *time.Time `json:"previous_date,omitempty"`
+}
+
+// Channel records a delivery for each of the user's webhook endpoints; the
+// Deliverer sends them and retries failures independently of other channels.
+type Channel struct {
+	repo endpointRepository
+}
+
+func NewChannel(repo endpointRepository) *Channel {
+	return &Channel{repo: repo}
+}
+
+func (c *Channel) Name() domain.NotificationChannel {
+	return domain.ChannelWebhook
+}
+
+// Reachable reports whether the user registered any endpoint. A failed lookup
+// counts as reachable so that the enqueue is attempted and its error retried.
+func (c *Channel) Reachable(user *domain.User) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), enqueueTimeout)
+	defer cancel()
+	ok, err := c.repo.HasEndpoints(ctx, user.ID)
+	return ok || err != nil
+}
+
+func (c *Channel) NotifyBookingCreated(user *domain.User, booking *domain.Booking, event *domain.Event) error {
+	return c.enqueue(user, domain.NotificationBookingCreated, PayloadData{Booking: toBooking(booking), Event: toEvent(event)})
+}
+
+func (c *Channel) NotifyPaymentPending(user *domain.User, booking *domain.Booking, event *domain.Event) error {
+	return c.enqueue(user, domain.NotificationPaymentPending, PayloadData{Booking: toBooking(booking), Event: toEvent(event)})
+}
+
+func (c *Channel) NotifyBookingConfirmed(user *domain.User, booking *domain.Booking, event *domain.Event) error {
+	return c.enqueue(user, domain.NotificationBookingConfirmed, PayloadData{Booking: toBooking(booking), Event: toEvent(event)})
+}
+
+func (c *Channel) NotifyBookingExpired(user *domain.User, booking *domain.Booking, event *domain.Event) error {
+	return c.enqueue(user, domain.NotificationBookingExpired, PayloadData{Booking: toBooking(booking), Event: toEvent(event)})
+}
+
+func (c *Channel) NotifyBookingCancelled(user *domain.User, booking *domain.Booking, event *domain.Event) error {
+	return c.enqueue(user, domain.NotificationBookingCancelled, PayloadData{Booking: toBooking(booking), Event: toEvent(event)})
+}
+
+func (c *Channel) NotifyEventCancelled(user *domain.User, booking *domain.Booking, event *domain.Event, reason string) error {
+	return c.enqueue(user, domain.NotificationEventCancelled, PayloadData{Booking: toBooking(booking), Event: toEvent(event), Reason: reason})
+}
+
+func (c *Channel) NotifyWaitlistPromotion(user *domain.User, booking *domain.Booking, event *domain.Event) error {
+	return c.enqueue(user, domain.NotificationWaitlistPromoted, PayloadData{Booking: toBooking(booking), Event: toEvent(event)})
+}
+
+func (c *Channel) NotifyEventRescheduled(user *domain.User, event *domain.Event, previousDate time.Time) error {
+	return c.enqueue(user, domain.NotificationEventRescheduled, PayloadData{Event: toEvent(event), PreviousDate: &previousDate})
+}
+
+func (c *Channel) NotifyEventReminder(user *domain.User, booking *domain.Booking, event *domain.Event) error {
+	return c.enqueue(user, domain.NotificationEventReminder, PayloadData{Booking: toBooking(booking), Event: toEvent(event)})
+}
+
+func (c *Channel) enqueue(user *domain.User, kind domain.NotificationKind, data PayloadData) error {
+	data.UserID = user.ID
+	payload, err := newPayload(string(kind), data)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), enqueueTimeout)
+	defer cancel()
+	_, err = c.repo.EnqueueForUser(ctx, user.ID, kind, payload)
+	return err
+}
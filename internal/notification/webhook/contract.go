@@ -0,0 +1,28 @@
+package webhook
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"event-booker/internal/domain"
+)
+
+type endpointRepository interface {
+	HasEndpoints(ctx context.Context, userID string) (bool, error)
+	EnqueueForUser(ctx context.Context, userID string, kind domain.NotificationKind, payload []byte) (int64, error)
+}
+
+type deliveryRepository interface {
+	ClaimDue(ctx context.Context, limit int, leaseUntil time.Time) ([]*domain.WebhookDelivery, error)
+	MarkDelivered(ctx context.Context, id string, attempts int, responseStatus int) error
+	MarkRetry(ctx context.Context, id string, attempts int, responseStatus *int, nextAttemptAt time.Time, lastError string) error
+	MarkFailed(ctx context.Context, id string, attempts int, responseStatus *int, lastError string) error
+	CreateSubscriptionDelivery(ctx context.Context, subscriptionID string, eventType domain.DomainEventType, payload []byte) (*domain.WebhookDelivery, error)
+	GetDelivery(ctx context.Context, id string) (*domain.WebhookDelivery, error)
+}
+
+type subscriptionRepository interface {
+	EnqueueForSubscriptions(ctx context.Context, tx *sql.Tx, eventType domain.DomainEventType, payload []byte) error
+	EnqueueForOrganizer(ctx context.Context, tx *sql.Tx, organizerID string, eventType domain.DomainEventType, payload []byte) error
+}
@@ -0,0 +1,194 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"event-booker/internal/config"
+	"event-booker/internal/domain"
+	"event-booker/internal/metrics"
+
+	"github.com/wb-go/wbf/zlog"
+)
+
+const (
+	SignatureHeader = "X-Webhook-Signature"
+	TimestampHeader = "X-Webhook-Timestamp"
+	DeliveryHeader  = "X-Webhook-Delivery"
+	EventHeader     = "X-Webhook-Event"
+
+	// maxLoggedResponse caps how much of a failed response body is kept in the
+	// delivery log.
+	maxLoggedResponse = 512
+)
+
+// Sign returns the value of the signature header for body sent at timestamp:
+// "sha256=" followed by the hex HMAC-SHA256 of "<timestamp>.<body>".
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Deliverer posts pending webhook deliveries to their endpoints. Failed
+// deliveries are retried with exponential backoff and marked failed once the
+// attempt limit is reached; every attempt's outcome is kept in the delivery
+// log.
+type Deliverer struct {
+	repo   deliveryRepository
+	client *http.Client
+	cfg    *config.Config
+	logger *zlog.Zerolog
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewDeliverer(repo deliveryRepository, cfg *config.Config, logger *zlog.Zerolog) *Deliverer {
+	return &Deliverer{
+		repo:   repo,
+		client: newClient(cfg.Webhooks.Timeout, cfg.Webhooks.AllowPrivateNetworks),
+		cfg:    cfg,
+		logger: logger,
+	}
+}
+
+func (d *Deliverer) Start(ctx context.Context) {
+	ctx, d.cancel = context.WithCancel(ctx)
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+		ticker := time.NewTicker(d.cfg.Webhooks.PollInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				d.deliverBatch(ctx)
+			}
+		}
+	}()
+	d.logger.Info().Msg("Webhook deliverer started")
+}
+
+func (d *Deliverer) Stop() {
+	if d.cancel != nil {
+		d.cancel()
+	}
+	d.wg.Wait()
+}
+
+func (d *Deliverer) deliverBatch(ctx context.Context) {
+	deliveries, err := d.repo.ClaimDue(ctx, d.cfg.Webhooks.BatchSize, time.Now().Add(d.cfg.Webhooks.Lease))
+	if err != nil {
+		d.logger.Error().Err(err).Msg("Failed to claim webhook deliveries")
+		return
+	}
+	for _, delivery := range deliveries {
+		if ctx.Err() != nil {
+			return
+		}
+		d.Deliver(ctx, delivery)
+	}
+}
+
+// Deliver makes one attempt to send a claimed delivery and records the outcome,
+// scheduling a retry on failure.
+func (d *Deliverer) Deliver(ctx context.Context, delivery *domain.WebhookDelivery) {
+	attempts := delivery.Attempts + 1
+	status, postErr := d.post(ctx, delivery)
+	metrics.ObserveNotification("webhook", postErr)
+	if postErr == nil {
+		if err := d.repo.MarkDelivered(ctx, delivery.ID, attempts, *status); err != nil {
+			d.logger.Error().Err(err).Str("delivery_id", delivery.ID).Msg("Failed to mark webhook delivered")
+		}
+		return
+	}
+	if attempts >= d.cfg.Webhooks.MaxAttempts {
+		d.logger.Error().
+			Err(postErr).
+			Str("delivery_id", delivery.ID).
+			Str("kind", delivery.Kind).
+			Int("attempts", attempts).
+			Msg("Webhook delivery failed permanently")
+		if err := d.repo.MarkFailed(ctx, delivery.ID, attempts, status, postErr.Error()); err != nil {
+			d.logger.Error().Err(err).Str("delivery_id", delivery.ID).Msg("Failed to mark webhook delivery failed")
+		}
+		return
+	}
+	nextAttemptAt := time.Now().Add(d.backoff(attempts))
+	d.logger.Warn().
+		Err(postErr).
+		Str("delivery_id", delivery.ID).
+		Str("kind", delivery.Kind).
+		Int("attempts", attempts).
+		Time("next_attempt_at", nextAttemptAt).
+		Msg("Webhook delivery failed, will retry")
+	if err := d.repo.MarkRetry(ctx, delivery.ID, attempts, status, nextAttemptAt, postErr.Error()); err != nil {
+		d.logger.Error().Err(err).Str("delivery_id", delivery.ID).Msg("Failed to reschedule webhook delivery")
+	}
+}
+
+// Ping sends a test delivery to a subscription right away and returns it with
+// the outcome recorded. A failed ping is retried like any other delivery.
+func (d *Deliverer) Ping(ctx context.Context, subscriptionID string) (*domain.WebhookDelivery, error) {
+	payload, err := newPayload(string(domain.DomainPing), map[string]string{"subscription_id": subscriptionID})
+	if err != nil {
+		return nil, err
+	}
+	delivery, err := d.repo.CreateSubscriptionDelivery(ctx, subscriptionID, domain.DomainPing, payload)
+	if err != nil {
+		return nil, err
+	}
+	d.Deliver(ctx, delivery)
+	return d.repo.GetDelivery(ctx, delivery.ID)
+}
+
+// post sends the delivery and returns the response status, if a response was
+// received, and an error unless the endpoint answered with a 2xx status.
+func (d *Deliverer) post(ctx context.Context, delivery *domain.WebhookDelivery) (*int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return nil, err
+	}
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "event-booker-webhooks")
+	req.Header.Set(DeliveryHeader, delivery.ID)
+	req.Header.Set(EventHeader, delivery.Kind)
+	req.Header.Set(TimestampHeader, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(SignatureHeader, Sign(delivery.Secret, timestamp, delivery.Payload))
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	status := resp.StatusCode
+	if status < 200 || status > 299 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxLoggedResponse))
+		return &status, fmt.Errorf("endpoint responded %d: %s", status, bytes.TrimSpace(body))
+	}
+	return &status, nil
+}
+
+func (d *Deliverer) backoff(attempts int) time.Duration {
+	delay := d.cfg.Webhooks.BaseBackoff
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= d.cfg.Webhooks.MaxBackoff {
+			return d.cfg.Webhooks.MaxBackoff
+		}
+	}
+	return delay
+}
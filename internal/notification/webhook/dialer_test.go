@@ -0,0 +1,57 @@
+package webhook
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+	"time"
+)
+
+func TestBlockedAddr(t *testing.T) {
+	tests := []struct {
+		addr string
+		want bool
+	}{
+		{"127.0.0.1", true},
+		{"10.1.2.3", true},
+		{"172.16.0.1", true},
+		{"192.168.1.1", true},
+		{"169.254.169.254", true},
+		{"100.64.0.1", true},
+		{"0.0.0.0", true},
+		{"224.0.0.1", true},
+		{"::1", true},
+		{"fe80::1", true},
+		{"fd00::1", true},
+		{"::ffff:127.0.0.1", true},
+		{"::ffff:169.254.169.254", true},
+		{"93.184.216.34", false},
+		{"8.8.8.8", false},
+		{"2606:4700:4700::1111", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.addr, func(t *testing.T) {
+			if got := blockedAddr(netip.MustParseAddr(tt.addr)); got != tt.want {
+				t.Errorf("blockedAddr(%s) = %v, want %v", tt.addr, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewClientRefusesLoopback(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	_, err := newClient(time.Second, false).Get(srv.URL)
+	if !errors.Is(err, ErrBlockedAddress) {
+		t.Fatalf("request to %s: got error %v, want %v", srv.URL, err, ErrBlockedAddress)
+	}
+
+	resp, err := newClient(time.Second, true).Get(srv.URL)
+	if err != nil {
+		t.Fatalf("request with private networks allowed: %v", err)
+	}
+	resp.Body.Close()
+}
@@ -0,0 +1,92 @@
+package webhook
+
+import (
+	"time"
+
+	"event-booker/internal/domain"
+)
+
+// Event is the representation of an event in webhook bodies. It is part of
+// the public webhook contract and decoupled from domain.Event on purpose, so
+// internal fields can change without breaking integrators.
+type Event struct {
+	ID                string    `json:"id"`
+	Name              string    `json:"name"`
+	Date              time.Time `json:"date"`
+	Status            string    `json:"status,omitempty"`
+	TotalSeats        int       `json:"total_seats"`
+	Available         int       `json:"available"`
+	MaxPerBooking     int       `json:"max_per_booking,omitempty"`
+	BookingTTLSeconds int64     `json:"booking_ttl_seconds,omitempty"`
+	RequiresPayment   bool      `json:"requires_payment"`
+	Price             int64     `json:"price,omitempty"`
+	Currency          string    `json:"currency,omitempty"`
+	LayoutID          *string   `json:"layout_id,omitempty"`
+	SeriesID          *string   `json:"series_id,omitempty"`
+	VenueID           *string   `json:"venue_id,omitempty"`
+	OrganizerID       *string   `json:"organizer_id,omitempty"`
+}
+
+// Booking is the representation of a booking in webhook bodies. Amounts are
+// in minor units of the ticket currency.
+type Booking struct {
+	ID          string     `json:"id"`
+	EventID     string     `json:"event_id"`
+	UserID      string     `json:"user_id"`
+	Quantity    int        `json:"quantity"`
+	Status      string     `json:"status"`
+	CreatedAt   time.Time  `json:"created_at"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+	ConfirmedAt *time.Time `json:"confirmed_at,omitempty"`
+	TierID      *string    `json:"tier_id,omitempty"`
+	PromoCodeID *string    `json:"promo_code_id,omitempty"`
+	Discount    int64      `json:"discount,omitempty"`
+	SeatIDs     []string   `json:"seat_ids,omitempty"`
+}
+
+func toEvent(e *domain.Event) *Event {
+	if e == nil {
+		return nil
+	}
+	return &Event{
+		ID:                e.ID,
+		Name:              e.Name,
+		Date:              e.Date,
+		Status:            string(e.Status),
+		TotalSeats:        e.TotalSeats,
+		Available:         e.Available,
+		MaxPerBooking:     e.MaxPerBooking,
+		BookingTTLSeconds: int64(e.BookingTTL / time.Second),
+		RequiresPayment:   e.RequiresPayment,
+		Price:             e.Price,
+		Currency:          e.Currency,
+		LayoutID:          e.LayoutID,
+		SeriesID:          e.SeriesID,
+		VenueID:           e.VenueID,
+		OrganizerID:       e.OrganizerID,
+	}
+}
+
+func toBooking(b *domain.Booking) *Booking {
+	if b == nil {
+		return nil
+	}
+	out := &Booking{
+		ID:          b.ID,
+		EventID:     b.EventID,
+		UserID:      b.UserID,
+		Quantity:    b.Quantity,
+		Status:      string(b.Status),
+		CreatedAt:   b.CreatedAt,
+		ConfirmedAt: b.ConfirmedAt,
+		TierID:      b.TierID,
+		PromoCodeID: b.PromoCodeID,
+		Discount:    b.Discount,
+		SeatIDs:     b.SeatIDs,
+	}
+	if !b.ExpiresAt.IsZero() {
+		expiresAt := b.ExpiresAt
+		out.ExpiresAt = &expiresAt
+	}
+	return out
+}
@@ -0,0 +1,44 @@
+package webhook
+
+import (
+	"context"
+	"database/sql"
+
+	"event-booker/internal/domain"
+)
+
+type EventData struct {
+	Event   *Event   `json:"event,omitempty"`
+	Booking *Booking `json:"booking,omitempty"`
+	Reason  string   `json:"reason,omitempty"`
+}
+
+// Publisher turns domain events into deliveries to the matching webhook
+// subscriptions and to the endpoints of the event's organizer. Publishing
+// happens inside the caller's transaction, so integrators never hear about
+// changes that were rolled back.
+type Publisher struct {
+	repo subscriptionRepository
+}
+
+func NewPublisher(repo subscriptionRepository) *Publisher {
+	return &Publisher{repo: repo}
+}
+
+func (p *Publisher) Publish(ctx context.Context, tx *sql.Tx, event *domain.DomainEvent) error {
+	payload, err := NewEventPayload(event)
+	if err != nil {
+		return err
+	}
+	if err := p.repo.EnqueueForSubscriptions(ctx, tx, event.Type, payload); err != nil {
+		return err
+	}
+	if event.Event == nil || event.Event.OrganizerID == nil {
+		return nil
+	}
+	return p.repo.EnqueueForOrganizer(ctx, tx, *event.Event.OrganizerID, event.Type, payload)
+}
+
+func NewEventPayload(event *domain.DomainEvent) ([]byte, error) {
+	return newPayload(string(event.Type), EventData{Event: toEvent(event.Event), Booking: toBooking(event.Booking), Reason: event.Reason})
+}
@@ -0,0 +1,105 @@
+package webhook
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"testing"
+
+	"event-booker/internal/domain"
+)
+
+type enqueued struct {
+	organizerID string
+	eventType   domain.DomainEventType
+	payload     []byte
+}
+
+type fakeSubscriptionRepo struct {
+	subscriptions []enqueued
+	organizers    []enqueued
+}
+
+func (r *fakeSubscriptionRepo) EnqueueForSubscriptions(_ context.Context, _ *sql.Tx, eventType domain.DomainEventType, payload []byte) error {
+	r.subscriptions = append(r.subscriptions, enqueued{eventType: eventType, payload: payload})
+	return nil
+}
+
+func (r *fakeSubscriptionRepo) EnqueueForOrganizer(_ context.Context, _ *sql.Tx, organizerID string, eventType domain.DomainEventType, payload []byte) error {
+	r.organizers = append(r.organizers, enqueued{organizerID: organizerID, eventType: eventType, payload: payload})
+	return nil
+}
+
+func TestPublishDeliversToEventOrganizer(t *testing.T) {
+	organizerID := "org-1"
+	tests := []struct {
+		name          string
+		event         *domain.DomainEvent
+		wantOrganizer string
+	}{
+		{
+			name: "booking of an organizer's event",
+			event: &domain.DomainEvent{
+				Type:    domain.DomainBookingCreated,
+				Event:   &domain.Event{ID: "event-1", OrganizerID: &organizerID},
+				Booking: &domain.Booking{ID: "booking-1", EventID: "event-1"},
+			},
+			wantOrganizer: organizerID,
+		},
+		{
+			name: "cancellation of an organizer's event",
+			event: &domain.DomainEvent{
+				Type:   domain.DomainEventCancelled,
+				Event:  &domain.Event{ID: "event-1", OrganizerID: &organizerID},
+				Reason: "venue closed",
+			},
+			wantOrganizer: organizerID,
+		},
+		{
+			name: "event without an organizer",
+			event: &domain.DomainEvent{
+				Type:    domain.DomainBookingCancelled,
+				Event:   &domain.Event{ID: "event-2"},
+				Booking: &domain.Booking{ID: "booking-2", EventID: "event-2"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeSubscriptionRepo{}
+			if err := NewPublisher(repo).Publish(context.Background(), nil, tt.event); err != nil {
+				t.Fatal(err)
+			}
+			if len(repo.subscriptions) != 1 || repo.subscriptions[0].eventType != tt.event.Type {
+				t.Errorf("subscription deliveries = %+v, want one %s", repo.subscriptions, tt.event.Type)
+			}
+			if tt.wantOrganizer == "" {
+				if len(repo.organizers) != 0 {
+					t.Errorf("organizer deliveries = %+v, want none", repo.organizers)
+				}
+				return
+			}
+			if len(repo.organizers) != 1 {
+				t.Fatalf("organizer deliveries = %+v, want one", repo.organizers)
+			}
+			got := repo.organizers[0]
+			if got.organizerID != tt.wantOrganizer || got.eventType != tt.event.Type {
+				t.Errorf("organizer delivery to %s of %s, want %s of %s", got.organizerID, got.eventType, tt.wantOrganizer, tt.event.Type)
+			}
+			var body struct {
+				Type string `json:"type"`
+				Data struct {
+					Event struct {
+						OrganizerID string `json:"organizer_id"`
+					} `json:"event"`
+				} `json:"data"`
+			}
+			if err := json.Unmarshal(got.payload, &body); err != nil {
+				t.Fatal(err)
+			}
+			if body.Type != string(tt.event.Type) || body.Data.Event.OrganizerID != organizerID {
+				t.Errorf("organizer payload = %s", got.payload)
+			}
+		})
+	}
+}
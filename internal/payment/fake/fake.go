@@ -0,0 +1,210 @@
+// Package fake implements an in-memory payment provider. It signs webhooks the
+// same way a real gateway would, so the full confirmation flow can be exercised
+// locally through its HTTP checkout page or driven directly from code.
+package fake
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"event-booker/internal/payment"
+
+	"github.com/google/uuid"
+)
+
+const ProviderName = "fake"
+
+type intentState string
+
+const (
+	intentPending    intentState = "pending"
+	intentAuthorized intentState = "authorized"
+	intentCaptured   intentState = "captured"
+	intentFailed     intentState = "failed"
+)
+
+type intent struct {
+	payment.Intent
+	state    intentState
+	refunded int64
+}
+
+type Provider struct {
+	secret       []byte
+	checkoutBase string
+	webhookURL   string
+	client       *http.Client
+
+	mu      sync.Mutex
+	intents map[string]*intent
+	refunds map[string]*payment.Refund
+}
+
+// NewProvider creates a fake gateway. checkoutBase is the public URL the
+// checkout handler is mounted at; webhookURL is where completed payments are
+// reported. An empty webhookURL disables outgoing webhooks.
+func NewProvider(secret, checkoutBase, webhookURL string) *Provider {
+	return &Provider{
+		secret:       []byte(secret),
+		checkoutBase: checkoutBase,
+		webhookURL:   webhookURL,
+		client:       &http.Client{Timeout: 10 * time.Second},
+		intents:      make(map[string]*intent),
+		refunds:      make(map[string]*payment.Refund),
+	}
+}
+
+func (p *Provider) Name() string {
+	return ProviderName
+}
+
+func (p *Provider) CreateIntent(ctx context.Context, req payment.IntentRequest) (*payment.Intent, error) {
+	id := "pi_" + uuid.NewString()
+	in := &intent{
+		Intent: payment.Intent{
+			ID:          id,
+			Amount:      req.Amount,
+			Currency:    req.Currency,
+			CheckoutURL: fmt.Sprintf("%s/checkout/%s", p.checkoutBase, id),
+		},
+		state: intentPending,
+	}
+	p.mu.Lock()
+	p.intents[id] = in
+	p.mu.Unlock()
+	result := in.Intent
+	return &result, nil
+}
+
+func (p *Provider) Capture(ctx context.Context, intentID string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	in, ok := p.intents[intentID]
+	if !ok {
+		return payment.ErrIntentNotFound
+	}
+	switch in.state {
+	case intentCaptured:
+		return nil
+	case intentAuthorized:
+		in.state = intentCaptured
+		return nil
+	default:
+		return payment.ErrInvalidIntent
+	}
+}
+
+func (p *Provider) Refund(ctx context.Context, intentID string, amount int64, idempotencyKey string) (*payment.Refund, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if refund, ok := p.refunds[idempotencyKey]; ok {
+		result := *refund
+		return &result, nil
+	}
+	in, ok := p.intents[intentID]
+	if !ok {
+		return nil, payment.ErrIntentNotFound
+	}
+	if in.state != intentCaptured || amount <= 0 || in.refunded+amount > in.Amount {
+		return nil, payment.ErrInvalidIntent
+	}
+	in.refunded += amount
+	refund := &payment.Refund{ID: "re_" + uuid.NewString(), IntentID: intentID, Amount: amount}
+	p.refunds[idempotencyKey] = refund
+	result := *refund
+	return &result, nil
+}
+
+func (p *Provider) VerifyWebhook(payload []byte, signature string) (*payment.WebhookEvent, error) {
+	if !hmac.Equal([]byte(p.sign(payload)), []byte(signature)) {
+		return nil, payment.ErrInvalidSignature
+	}
+	var event payment.WebhookEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return nil, payment.ErrInvalidSignature
+	}
+	return &event, nil
+}
+
+// Succeed authorizes the intent and reports payment.succeeded to the webhook URL.
+func (p *Provider) Succeed(ctx context.Context, intentID string) error {
+	return p.complete(ctx, intentID, intentAuthorized, payment.EventPaymentSucceeded)
+}
+
+// Fail declines the intent and reports payment.failed to the webhook URL.
+func (p *Provider) Fail(ctx context.Context, intentID string) error {
+	return p.complete(ctx, intentID, intentFailed, payment.EventPaymentFailed)
+}
+
+// SignedWebhook builds a webhook body and its signature without sending it,
+// which lets callers feed the webhook endpoint directly.
+func (p *Provider) SignedWebhook(intentID string, eventType payment.WebhookEventType) ([]byte, string, error) {
+	p.mu.Lock()
+	in, ok := p.intents[intentID]
+	p.mu.Unlock()
+	if !ok {
+		return nil, "", payment.ErrIntentNotFound
+	}
+	payload, err := json.Marshal(payment.WebhookEvent{
+		ID:       "evt_" + uuid.NewString(),
+		Type:     eventType,
+		IntentID: intentID,
+		Amount:   in.Amount,
+		Currency: in.Currency,
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	return payload, p.sign(payload), nil
+}
+
+func (p *Provider) complete(ctx context.Context, intentID string, state intentState, eventType payment.WebhookEventType) error {
+	p.mu.Lock()
+	in, ok := p.intents[intentID]
+	if !ok {
+		p.mu.Unlock()
+		return payment.ErrIntentNotFound
+	}
+	if in.state != intentPending {
+		p.mu.Unlock()
+		return payment.ErrInvalidIntent
+	}
+	in.state = state
+	p.mu.Unlock()
+	if p.webhookURL == "" {
+		return nil
+	}
+	payload, signature, err := p.SignedWebhook(intentID, eventType)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.webhookURL, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(payment.SignatureHeader, signature)
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("webhook rejected with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (p *Provider) sign(payload []byte) string {
+	mac := hmac.New(sha256.New, p.secret)
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,59 @@
+package fake
+
+import (
+	"fmt"
+	"html/template"
+	"net/http"
+)
+
+var checkoutPage = template.Must(template.New("checkout").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head><meta charset="UTF-8"><title>Fake checkout</title></head>
+<body style="font-family: sans-serif; max-width: 480px; margin: 40px auto;">
+<h2>Fake payment gateway</h2>
+<p>Intent <code>{{.ID}}</code></p>
+<p>Amount: <strong>{{.Amount}}</strong> {{.Currency}} (minor units)</p>
+<form method="post" action="{{.ID}}/succeed" style="display:inline"><button type="submit">Pay</button></form>
+<form method="post" action="{{.ID}}/fail" style="display:inline"><button type="submit">Decline</button></form>
+</body>
+</html>`))
+
+// Handler serves a local stand-in for the hosted checkout page. Mount it at
+// the checkoutBase passed to NewProvider.
+func (p *Provider) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /checkout/{id}", func(w http.ResponseWriter, r *http.Request) {
+		p.mu.Lock()
+		in, ok := p.intents[r.PathValue("id")]
+		var view struct {
+			ID       string
+			Amount   int64
+			Currency string
+		}
+		if ok {
+			view.ID, view.Amount, view.Currency = in.ID, in.Amount, in.Currency
+		}
+		p.mu.Unlock()
+		if !ok {
+			http.Error(w, "Payment intent not found", http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		checkoutPage.Execute(w, view)
+	})
+	mux.HandleFunc("POST /checkout/{id}/succeed", func(w http.ResponseWriter, r *http.Request) {
+		if err := p.Succeed(r.Context(), r.PathValue("id")); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		fmt.Fprintln(w, "Payment succeeded, you can close this page.")
+	})
+	mux.HandleFunc("POST /checkout/{id}/fail", func(w http.ResponseWriter, r *http.Request) {
+		if err := p.Fail(r.Context(), r.PathValue("id")); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		fmt.Fprintln(w, "Payment declined.")
+	})
+	return mux
+}
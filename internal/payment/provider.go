@@ -0,0 +1,61 @@
+// Package payment defines the contract between the booking flow and an
+// external payment gateway.
+package payment
+
+import (
+	"context"
+	"errors"
+)
+
+// SignatureHeader carries the provider's HMAC signature of a webhook body.
+const SignatureHeader = "X-Payment-Signature"
+
+var (
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+	ErrIntentNotFound   = errors.New("payment intent not found")
+	ErrInvalidIntent    = errors.New("payment intent is in an invalid state")
+)
+
+type Provider interface {
+	Name() string
+	CreateIntent(ctx context.Context, req IntentRequest) (*Intent, error)
+	Capture(ctx context.Context, intentID string) error
+	// Refund returns money from a captured intent. Calls repeating an
+	// idempotencyKey return the original refund instead of refunding again.
+	Refund(ctx context.Context, intentID string, amount int64, idempotencyKey string) (*Refund, error)
+	VerifyWebhook(payload []byte, signature string) (*WebhookEvent, error)
+}
+
+type IntentRequest struct {
+	BookingID string
+	Amount    int64
+	Currency  string
+}
+
+type Intent struct {
+	ID          string
+	Amount      int64
+	Currency    string
+	CheckoutURL string
+}
+
+type Refund struct {
+	ID       string
+	IntentID string
+	Amount   int64
+}
+
+type WebhookEventType string
+
+const (
+	EventPaymentSucceeded WebhookEventType = "payment.succeeded"
+	EventPaymentFailed    WebhookEventType = "payment.failed"
+)
+
+type WebhookEvent struct {
+	ID       string           `json:"id"`
+	Type     WebhookEventType `json:"type"`
+	IntentID string           `json:"intent_id"`
+	Amount   int64            `json:"amount"`
+	Currency string           `json:"currency"`
+}
@@ -0,0 +1,181 @@
+// Package realtime fans committed event changes out to live subscribers.
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"event-booker/internal/config"
+	"event-booker/internal/domain"
+
+	"github.com/lib/pq"
+	"github.com/wb-go/wbf/zlog"
+)
+
+const (
+	// Channel is the Postgres notification channel the events table trigger
+	// publishes on.
+	Channel = "event_changes"
+
+	minReconnectInterval = time.Second
+	maxReconnectInterval = time.Minute
+	// pingInterval keeps the dedicated listener connection from being
+	// silently dropped while no notifications arrive.
+	pingInterval = 90 * time.Second
+)
+
+// Change is the availability and status of an event after a committed update.
+// Status is "deleted" once the event is gone.
+type Change struct {
+	EventID    string             `json:"event_id"`
+	Available  int                `json:"available"`
+	TotalSeats int                `json:"total_seats"`
+	Status     domain.EventStatus `json:"status"`
+}
+
+// Message is one server-sent event. Resync tells the subscriber that changes
+// may have been missed and its view should be reloaded.
+type Message struct {
+	Change *Change
+	Resync bool
+}
+
+// Subscription receives the messages for one event, or for all events when
+// EventID is empty. C is closed when the subscriber falls too far behind; it
+// should reconnect and start over from a fresh snapshot.
+type Subscription struct {
+	EventID string
+	C       chan Message
+}
+
+// Broker listens for event changes on a dedicated connection, so every app
+// replica sees the changes committed by all of them.
+type Broker struct {
+	dsn    string
+	cfg    *config.Config
+	logger *zlog.Zerolog
+
+	mu     sync.Mutex
+	subs   map[*Subscription]struct{}
+	closed bool
+
+	listener *pq.Listener
+	cancel   context.CancelFunc
+	wg       sync.WaitGroup
+}
+
+func NewBroker(dsn string, cfg *config.Config, logger *zlog.Zerolog) *Broker {
+	return &Broker{
+		dsn:    dsn,
+		cfg:    cfg,
+		logger: logger,
+		subs:   make(map[*Subscription]struct{}),
+	}
+}
+
+func (b *Broker) Start(ctx context.Context) {
+	ctx, b.cancel = context.WithCancel(ctx)
+	b.listener = pq.NewListener(b.dsn, minReconnectInterval, maxReconnectInterval, func(ev pq.ListenerEventType, err error) {
+		if err != nil {
+			b.logger.Error().Err(err).Int("event", int(ev)).Msg("Event change listener connection problem")
+		}
+	})
+	if err := b.listener.Listen(Channel); err != nil {
+		b.logger.Error().Err(err).Str("channel", Channel).Msg("Failed to listen for event changes")
+	}
+	b.wg.Add(1)
+	go func() {
+		defer b.wg.Done()
+		ticker := time.NewTicker(pingInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case n := <-b.listener.Notify:
+				b.handle(n)
+			case <-ticker.C:
+				if err := b.listener.Ping(); err != nil {
+					b.logger.Warn().Err(err).Msg("Event change listener ping failed")
+				}
+			}
+		}
+	}()
+	b.logger.Info().Msg("Event change broker started")
+}
+
+func (b *Broker) Stop() {
+	if b.cancel != nil {
+		b.cancel()
+	}
+	b.wg.Wait()
+	if b.listener != nil {
+		b.listener.Close()
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.closed = true
+	for sub := range b.subs {
+		close(sub.C)
+		delete(b.subs, sub)
+	}
+}
+
+// Subscribe registers a subscriber for one event, or for all events when
+// eventID is empty. Callers must Unsubscribe when done. Once the broker is
+// stopped the returned subscription is already closed.
+func (b *Broker) Subscribe(eventID string) *Subscription {
+	sub := &Subscription{EventID: eventID, C: make(chan Message, b.cfg.Stream.ClientBuffer)}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		close(sub.C)
+		return sub
+	}
+	b.subs[sub] = struct{}{}
+	return sub
+}
+
+func (b *Broker) Unsubscribe(sub *Subscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, ok := b.subs[sub]; ok {
+		close(sub.C)
+		delete(b.subs, sub)
+	}
+}
+
+// handle fans a notification out. A nil notification means the listener
+// reconnected and notifications sent meanwhile are lost.
+func (b *Broker) handle(n *pq.Notification) {
+	if n == nil {
+		b.logger.Warn().Msg("Event change listener reconnected, asking subscribers to resync")
+		b.publish(Message{Resync: true})
+		return
+	}
+	var change Change
+	if err := json.Unmarshal([]byte(n.Extra), &change); err != nil {
+		b.logger.Error().Err(err).Str("payload", n.Extra).Msg("Failed to decode event change")
+		return
+	}
+	b.publish(Message{Change: &change})
+}
+
+func (b *Broker) publish(msg Message) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for sub := range b.subs {
+		if msg.Change != nil && sub.EventID != "" && sub.EventID != msg.Change.EventID {
+			continue
+		}
+		select {
+		case sub.C <- msg:
+		default:
+			b.logger.Warn().Str("event_id", sub.EventID).Msg("Dropping slow event stream subscriber")
+			close(sub.C)
+			delete(b.subs, sub)
+		}
+	}
+}
@@ -0,0 +1,247 @@
+// Package recurrence implements the subset of RFC 5545 recurrence rules used
+// for event series: weekly or monthly repetition bounded by COUNT or UNTIL.
+package recurrence
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MaxOccurrences caps how many occurrences a single rule may produce.
+const MaxOccurrences = 200
+
+// maxPeriods bounds the number of weeks or months scanned, so a rule whose
+// days never match cannot loop forever.
+const maxPeriods = 1200
+
+var (
+	ErrInvalidRule        = errors.New("invalid recurrence rule")
+	ErrTooManyOccurrences = errors.New("recurrence rule produces too many occurrences")
+)
+
+type Frequency string
+
+const (
+	Weekly  Frequency = "WEEKLY"
+	Monthly Frequency = "MONTHLY"
+)
+
+// Rule is a parsed RRULE. Exactly one of Count and Until bounds it.
+type Rule struct {
+	Freq       Frequency
+	Interval   int
+	ByDay      []time.Weekday
+	ByMonthDay []int
+	Count      int
+	Until      *time.Time
+}
+
+var weekdays = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
+
+// Parse reads a rule such as "FREQ=WEEKLY;BYDAY=TU,TH;COUNT=10". An optional
+// "RRULE:" prefix is accepted. UNTIL takes a UTC date-time (20250131T180000Z)
+// or a date (20250131), the latter meaning the end of that day in UTC.
+func Parse(s string) (*Rule, error) {
+	s = strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(s)), "RRULE:")
+	rule := &Rule{Interval: 1}
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(s, ";") {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok || value == "" {
+			return nil, fmt.Errorf("%w: malformed part %q", ErrInvalidRule, part)
+		}
+		if seen[key] {
+			return nil, fmt.Errorf("%w: %s given twice", ErrInvalidRule, key)
+		}
+		seen[key] = true
+		var err error
+		switch key {
+		case "FREQ":
+			rule.Freq = Frequency(value)
+			if rule.Freq != Weekly && rule.Freq != Monthly {
+				err = errors.New("FREQ must be WEEKLY or MONTHLY")
+			}
+		case "INTERVAL":
+			rule.Interval, err = parseInt(value, 1, 52)
+		case "COUNT":
+			rule.Count, err = parseInt(value, 1, MaxOccurrences)
+		case "UNTIL":
+			rule.Until, err = parseUntil(value)
+		case "BYDAY":
+			for _, day := range strings.Split(value, ",") {
+				wd, ok := weekdays[day]
+				if !ok {
+					err = fmt.Errorf("unknown weekday %q", day)
+					break
+				}
+				rule.ByDay = append(rule.ByDay, wd)
+			}
+		case "BYMONTHDAY":
+			for _, day := range strings.Split(value, ",") {
+				var d int
+				if d, err = parseInt(day, 1, 31); err != nil {
+					break
+				}
+				rule.ByMonthDay = append(rule.ByMonthDay, d)
+			}
+		default:
+			err = fmt.Errorf("unsupported part %s", key)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidRule, err)
+		}
+	}
+	switch {
+	case rule.Freq == "":
+		return nil, fmt.Errorf("%w: FREQ is required", ErrInvalidRule)
+	case (rule.Count == 0) == (rule.Until == nil):
+		return nil, fmt.Errorf("%w: exactly one of COUNT and UNTIL is required", ErrInvalidRule)
+	case rule.Freq == Weekly && len(rule.ByMonthDay) > 0:
+		return nil, fmt.Errorf("%w: BYMONTHDAY needs FREQ=MONTHLY", ErrInvalidRule)
+	case rule.Freq == Monthly && len(rule.ByDay) > 0:
+		return nil, fmt.Errorf("%w: BYDAY needs FREQ=WEEKLY", ErrInvalidRule)
+	}
+	return rule, nil
+}
+
+func parseInt(s string, min, max int) (int, error) {
+	n, err := strconv.Atoi(s)
+	if err != nil || n < min || n > max {
+		return 0, fmt.Errorf("%q must be a number between %d and %d", s, min, max)
+	}
+	return n, nil
+}
+
+func parseUntil(s string) (*time.Time, error) {
+	if t, err := time.Parse("20060102T150405Z", s); err == nil {
+		return &t, nil
+	}
+	t, err := time.Parse("20060102", s)
+	if err != nil {
+		return nil, fmt.Errorf("UNTIL %q must look like 20250131T180000Z or 20250131", s)
+	}
+	t = t.Add(24*time.Hour - time.Second)
+	return &t, nil
+}
+
+// String returns the canonical form of the rule.
+func (r *Rule) String() string {
+	parts := []string{"FREQ=" + string(r.Freq)}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if len(r.ByDay) > 0 {
+		days := make([]string, 0, len(r.ByDay))
+		for _, wd := range r.ByDay {
+			days = append(days, strings.ToUpper(wd.String()[:2]))
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	if len(r.ByMonthDay) > 0 {
+		days := make([]string, 0, len(r.ByMonthDay))
+		for _, d := range r.ByMonthDay {
+			days = append(days, strconv.Itoa(d))
+		}
+		parts = append(parts, "BYMONTHDAY="+strings.Join(days, ","))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if r.Until != nil {
+		parts = append(parts, "UNTIL="+r.Until.UTC().Format("20060102T150405Z"))
+	}
+	return strings.Join(parts, ";")
+}
+
+// Occurrences expands the rule from start, which fixes the time of day, the
+// location and, unless BYDAY or BYMONTHDAY say otherwise, the weekday or day
+// of month. Start itself is included only if it matches the rule. Days that do
+// not exist in a month (e.g. the 31st) are skipped, as RFC 5545 prescribes.
+func (r *Rule) Occurrences(start time.Time) ([]time.Time, error) {
+	var dates []time.Time
+	add := func(t time.Time) (bool, error) {
+		if t.Before(start) {
+			return false, nil
+		}
+		if r.Until != nil && t.After(*r.Until) {
+			return true, nil
+		}
+		if len(dates) == MaxOccurrences {
+			return true, ErrTooManyOccurrences
+		}
+		dates = append(dates, t)
+		return r.Count > 0 && len(dates) == r.Count, nil
+	}
+	h, m, s := start.Clock()
+	loc := start.Location()
+	switch r.Freq {
+	case Weekly:
+		offsets := weekdayOffsets(r.ByDay, start.Weekday())
+		monday := start.Day() - mondayOffset(start.Weekday())
+		for period := 0; period < maxPeriods; period++ {
+			week := monday + period*r.Interval*7
+			for _, offset := range offsets {
+				done, err := add(time.Date(start.Year(), start.Month(), week+offset, h, m, s, start.Nanosecond(), loc))
+				if err != nil || done {
+					return dates, err
+				}
+			}
+		}
+	case Monthly:
+		days := append([]int(nil), r.ByMonthDay...)
+		if len(days) == 0 {
+			days = []int{start.Day()}
+		}
+		sort.Ints(days)
+		days = slices.Compact(days)
+		for period := 0; period < maxPeriods; period++ {
+			month := start.Month() + time.Month(period*r.Interval)
+			last := time.Date(start.Year(), month+1, 0, 0, 0, 0, 0, loc).Day()
+			for _, day := range days {
+				if day > last {
+					continue
+				}
+				done, err := add(time.Date(start.Year(), month, day, h, m, s, start.Nanosecond(), loc))
+				if err != nil || done {
+					return dates, err
+				}
+			}
+		}
+	}
+	return dates, nil
+}
+
+// mondayOffset returns the number of days from Monday to wd.
+func mondayOffset(wd time.Weekday) int {
+	return (int(wd) + 6) % 7
+}
+
+func weekdayOffsets(days []time.Weekday, fallback time.Weekday) []int {
+	if len(days) == 0 {
+		days = []time.Weekday{fallback}
+	}
+	seen := make(map[int]bool, len(days))
+	var offsets []int
+	for _, wd := range days {
+		offset := mondayOffset(wd)
+		if !seen[offset] {
+			seen[offset] = true
+			offsets = append(offsets, offset)
+		}
+	}
+	sort.Ints(offsets)
+	return offsets
+}
@@ -0,0 +1,241 @@
+package recurrence
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	until := time.Date(2025, 1, 31, 18, 0, 0, 0, time.UTC)
+	endOfDay := time.Date(2025, 1, 31, 23, 59, 59, 0, time.UTC)
+	tests := []struct {
+		in   string
+		want *Rule
+	}{
+		{"FREQ=WEEKLY;COUNT=3", &Rule{Freq: Weekly, Interval: 1, Count: 3}},
+		{"rrule:freq=weekly;byday=tu,th;count=10", &Rule{Freq: Weekly, Interval: 1, ByDay: []time.Weekday{time.Tuesday, time.Thursday}, Count: 10}},
+		{" RRULE:FREQ=MONTHLY;INTERVAL=2;BYMONTHDAY=1,15;UNTIL=20250131T180000Z ", &Rule{Freq: Monthly, Interval: 2, ByMonthDay: []int{1, 15}, Until: &until}},
+		{"FREQ=MONTHLY;UNTIL=20250131", &Rule{Freq: Monthly, Interval: 1, Until: &endOfDay}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := Parse(tt.in)
+			if err != nil {
+				t.Fatalf("Parse(%q): %v", tt.in, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse(%q) = %+v, want %+v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []string{
+		"",
+		"FREQ=WEEKLY",
+		"COUNT=3",
+		"FREQ=DAILY;COUNT=3",
+		"FREQ=WEEKLY;COUNT=3;UNTIL=20250131",
+		"FREQ=WEEKLY;COUNT=0",
+		"FREQ=WEEKLY;COUNT=201",
+		"FREQ=WEEKLY;COUNT=x",
+		"FREQ=WEEKLY;INTERVAL=0;COUNT=3",
+		"FREQ=WEEKLY;INTERVAL=53;COUNT=3",
+		"FREQ=WEEKLY;COUNT=3;COUNT=4",
+		"FREQ=WEEKLY;COUNT=3;",
+		"FREQ=WEEKLY;COUNT",
+		"FREQ=WEEKLY;COUNT=",
+		"FREQ=WEEKLY;BYDAY=XX;COUNT=3",
+		"FREQ=WEEKLY;BYDAY=MO,;COUNT=3",
+		"FREQ=WEEKLY;BYMONTHDAY=1;COUNT=3",
+		"FREQ=MONTHLY;BYDAY=MO;COUNT=3",
+		"FREQ=MONTHLY;BYMONTHDAY=0;COUNT=3",
+		"FREQ=MONTHLY;BYMONTHDAY=32;COUNT=3",
+		"FREQ=MONTHLY;UNTIL=2025-01-31",
+		"FREQ=MONTHLY;BYSETPOS=1;COUNT=3",
+	}
+	for _, in := range tests {
+		t.Run(in, func(t *testing.T) {
+			if _, err := Parse(in); !errors.Is(err, ErrInvalidRule) {
+				t.Errorf("Parse(%q) error = %v, want %v", in, err, ErrInvalidRule)
+			}
+		})
+	}
+}
+
+func TestStringRoundTrip(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"FREQ=WEEKLY;COUNT=3", "FREQ=WEEKLY;COUNT=3"},
+		{"FREQ=WEEKLY;INTERVAL=1;COUNT=3", "FREQ=WEEKLY;COUNT=3"},
+		{"rrule:freq=weekly;count=2;byday=th,tu;interval=2", "FREQ=WEEKLY;INTERVAL=2;BYDAY=TH,TU;COUNT=2"},
+		{"FREQ=MONTHLY;BYMONTHDAY=31,1;UNTIL=20250131T180000Z", "FREQ=MONTHLY;BYMONTHDAY=31,1;UNTIL=20250131T180000Z"},
+		{"FREQ=MONTHLY;UNTIL=20250131", "FREQ=MONTHLY;UNTIL=20250131T235959Z"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			rule, err := Parse(tt.in)
+			if err != nil {
+				t.Fatalf("Parse(%q): %v", tt.in, err)
+			}
+			got := rule.String()
+			if got != tt.want {
+				t.Fatalf("String() = %q, want %q", got, tt.want)
+			}
+			again, err := Parse(got)
+			if err != nil {
+				t.Fatalf("Parse(%q): %v", got, err)
+			}
+			if !reflect.DeepEqual(again, rule) {
+				t.Errorf("Parse(String()) = %+v, want %+v", again, rule)
+			}
+		})
+	}
+}
+
+func TestOccurrences(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// A Wednesday.
+	start := time.Date(2025, 1, 15, 19, 30, 0, 0, time.UTC)
+	date := func(y int, m time.Month, d int) time.Time {
+		return time.Date(y, m, d, 19, 30, 0, 0, time.UTC)
+	}
+	tests := []struct {
+		name  string
+		rule  string
+		start time.Time
+		want  []time.Time
+	}{
+		{
+			name: "weekly on start weekday",
+			rule: "FREQ=WEEKLY;COUNT=3",
+			want: []time.Time{date(2025, 1, 15), date(2025, 1, 22), date(2025, 1, 29)},
+		},
+		{
+			name: "weekly with interval across a month end",
+			rule: "FREQ=WEEKLY;INTERVAL=2;COUNT=3",
+			want: []time.Time{date(2025, 1, 15), date(2025, 1, 29), date(2025, 2, 12)},
+		},
+		{
+			name: "byday in week order, skipping days before start",
+			rule: "FREQ=WEEKLY;BYDAY=FR,MO,WE;COUNT=5",
+			want: []time.Time{date(2025, 1, 15), date(2025, 1, 17), date(2025, 1, 20), date(2025, 1, 22), date(2025, 1, 24)},
+		},
+		{
+			name: "byday excluding start",
+			rule: "FREQ=WEEKLY;BYDAY=TU;COUNT=2",
+			want: []time.Time{date(2025, 1, 21), date(2025, 1, 28)},
+		},
+		{
+			name: "byday sunday ends the week",
+			rule: "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,SU;COUNT=4",
+			want: []time.Time{date(2025, 1, 19), date(2025, 1, 27), date(2025, 2, 2), date(2025, 2, 10)},
+		},
+		{
+			name: "duplicate byday",
+			rule: "FREQ=WEEKLY;BYDAY=WE,WE;COUNT=2",
+			want: []time.Time{date(2025, 1, 15), date(2025, 1, 22)},
+		},
+		{
+			name: "until is inclusive",
+			rule: "FREQ=WEEKLY;UNTIL=20250129T193000Z",
+			want: []time.Time{date(2025, 1, 15), date(2025, 1, 22), date(2025, 1, 29)},
+		},
+		{
+			name: "until date covers the whole day",
+			rule: "FREQ=WEEKLY;UNTIL=20250129",
+			want: []time.Time{date(2025, 1, 15), date(2025, 1, 22), date(2025, 1, 29)},
+		},
+		{
+			name: "until before start",
+			rule: "FREQ=WEEKLY;UNTIL=20250101",
+			want: nil,
+		},
+		{
+			name:  "monthly skips the 31st in short months",
+			rule:  "FREQ=MONTHLY;COUNT=4",
+			start: date(2025, 1, 31),
+			want:  []time.Time{date(2025, 1, 31), date(2025, 3, 31), date(2025, 5, 31), date(2025, 7, 31)},
+		},
+		{
+			name:  "monthly on the 29th in a leap year",
+			rule:  "FREQ=MONTHLY;BYMONTHDAY=29;COUNT=3",
+			start: date(2024, 1, 1),
+			want:  []time.Time{date(2024, 1, 29), date(2024, 2, 29), date(2024, 3, 29)},
+		},
+		{
+			name: "monthly bymonthday sorted and skipping days before start",
+			rule: "FREQ=MONTHLY;BYMONTHDAY=20,1,10;COUNT=4",
+			want: []time.Time{date(2025, 1, 20), date(2025, 2, 1), date(2025, 2, 10), date(2025, 2, 20)},
+		},
+		{
+			name: "duplicate bymonthday",
+			rule: "FREQ=MONTHLY;BYMONTHDAY=15,15;COUNT=2",
+			want: []time.Time{date(2025, 1, 15), date(2025, 2, 15)},
+		},
+		{
+			name: "monthly with interval across a year end",
+			rule: "FREQ=MONTHLY;INTERVAL=5;COUNT=3",
+			want: []time.Time{date(2025, 1, 15), date(2025, 6, 15), date(2025, 11, 15)},
+		},
+		{
+			name:  "monthly interval with the 31st",
+			rule:  "FREQ=MONTHLY;INTERVAL=2;BYMONTHDAY=31;UNTIL=20251231",
+			start: date(2025, 1, 1),
+			want:  []time.Time{date(2025, 1, 31), date(2025, 3, 31), date(2025, 5, 31), date(2025, 7, 31)},
+		},
+		{
+			name:  "keeps wall clock time across a DST change",
+			rule:  "FREQ=WEEKLY;COUNT=2",
+			start: time.Date(2025, 3, 27, 19, 0, 0, 0, berlin),
+			want:  []time.Time{time.Date(2025, 3, 27, 19, 0, 0, 0, berlin), time.Date(2025, 4, 3, 19, 0, 0, 0, berlin)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := Parse(tt.rule)
+			if err != nil {
+				t.Fatalf("Parse(%q): %v", tt.rule, err)
+			}
+			from := tt.start
+			if from.IsZero() {
+				from = start
+			}
+			got, err := rule.Occurrences(from)
+			if err != nil {
+				t.Fatalf("Occurrences: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Occurrences(%v) =\n  %v\nwant\n  %v", from, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOccurrencesLimit(t *testing.T) {
+	start := time.Date(2025, 1, 15, 19, 30, 0, 0, time.UTC)
+	rule, err := Parse("FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR,SA,SU;COUNT=200")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := rule.Occurrences(start)
+	if err != nil || len(got) != MaxOccurrences {
+		t.Fatalf("COUNT=%d: got %d occurrences, error %v", MaxOccurrences, len(got), err)
+	}
+
+	rule, err = Parse("FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR,SA,SU;UNTIL=20260101")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := rule.Occurrences(start); !errors.Is(err, ErrTooManyOccurrences) {
+		t.Errorf("a year of daily occurrences: error = %v, want %v", err, ErrTooManyOccurrences)
+	}
+}
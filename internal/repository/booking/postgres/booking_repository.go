@@ -3,11 +3,14 @@ package booking_postgres
 import (
 	"context"
 	"database/sql"
+	"fmt"
+	"strings"
 	"time"
 
 	"event-booker/internal/domain"
 	"event-booker/internal/repository"
 
+	"github.com/lib/pq"
 	"github.com/wb-go/wbf/dbpg"
 	"github.com/wb-go/wbf/retry"
 )
@@ -23,20 +26,24 @@ func NewBookingRepository(db *dbpg.DB, retries retry.Strategy) *BookingRepositor
 
 func (r *BookingRepository) Create(ctx context.Context, tx *sql.Tx, booking *domain.Booking) error {
 	query := `
-INSERT INTO bookings (id, event_id, user_id, status, created_at, expires_at, confirmed_at)
-VALUES ($1, $2, $3, $4, $5, $6, $7)
+INSERT INTO bookings (id, event_id, user_id, quantity, status, created_at, expires_at, confirmed_at, tier_id, promo_code_id, discount)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 `
+	var err error
 	if tx != nil {
-		_, err := tx.ExecContext(ctx, query, booking.ID, booking.EventID, booking.UserID, booking.Status, booking.CreatedAt, booking.ExpiresAt, booking.ConfirmedAt)
-		return err
+		_, err = tx.ExecContext(ctx, query, booking.ID, booking.EventID, booking.UserID, booking.Quantity, booking.Status, booking.CreatedAt, booking.ExpiresAt, booking.ConfirmedAt, booking.TierID, booking.PromoCodeID, booking.Discount)
+	} else {
+		_, err = r.db.ExecWithRetry(ctx, r.retries, query, booking.ID, booking.EventID, booking.UserID, booking.Quantity, booking.Status, booking.CreatedAt, booking.ExpiresAt, booking.ConfirmedAt, booking.TierID, booking.PromoCodeID, booking.Discount)
+	}
+	if repository.IsUniqueViolation(err) {
+		return repository.ErrAlreadyExists
 	}
-	_, err := r.db.ExecWithRetry(ctx, r.retries, query, booking.ID, booking.EventID, booking.UserID, booking.Status, booking.CreatedAt, booking.ExpiresAt, booking.ConfirmedAt)
 	return err
 }
 
 func (r *BookingRepository) GetByID(ctx context.Context, id string) (*domain.Booking, error) {
 	query := `
-SELECT id, event_id, user_id, status, created_at, expires_at, confirmed_at
+SELECT id, event_id, user_id, quantity, status, created_at, expires_at, confirmed_at, tier_id, promo_code_id, discount
 FROM bookings WHERE id = $1
 `
 	row, err := r.db.QueryRowWithRetry(ctx, r.retries, query, id)
@@ -44,7 +51,23 @@ FROM bookings WHERE id = $1
 		return nil, err
 	}
 	var booking domain.Booking
-	err = row.Scan(&booking.ID, &booking.EventID, &booking.UserID, &booking.Status, &booking.CreatedAt, &booking.ExpiresAt, &booking.ConfirmedAt)
+	err = row.Scan(&booking.ID, &booking.EventID, &booking.UserID, &booking.Quantity, &booking.Status, &booking.CreatedAt, &booking.ExpiresAt, &booking.ConfirmedAt, &booking.TierID, &booking.PromoCodeID, &booking.Discount)
+	if err == sql.ErrNoRows {
+		return nil, repository.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &booking, nil
+}
+
+func (r *BookingRepository) GetForUpdate(ctx context.Context, tx *sql.Tx, id string) (*domain.Booking, error) {
+	query := `
+SELECT id, event_id, user_id, quantity, status, created_at, expires_at, confirmed_at, tier_id, promo_code_id, discount
+FROM bookings WHERE id = $1 FOR UPDATE
+`
+	var booking domain.Booking
+	err := tx.QueryRowContext(ctx, query, id).Scan(&booking.ID, &booking.EventID, &booking.UserID, &booking.Quantity, &booking.Status, &booking.CreatedAt, &booking.ExpiresAt, &booking.ConfirmedAt, &booking.TierID, &booking.PromoCodeID, &booking.Discount)
 	if err == sql.ErrNoRows {
 		return nil, repository.ErrNotFound
 	}
@@ -66,6 +89,52 @@ UPDATE bookings SET status = $1, confirmed_at = $2 WHERE id = $3
 	return err
 }
 
+func (r *BookingRepository) HasActiveBooking(ctx context.Context, tx *sql.Tx, eventID, userID string) (bool, error) {
+	query := `
+SELECT EXISTS (SELECT 1 FROM bookings WHERE event_id = $1 AND user_id = $2 AND status <> 'cancelled')
+`
+	var exists bool
+	if tx != nil {
+		err := tx.QueryRowContext(ctx, query, eventID, userID).Scan(&exists)
+		return exists, err
+	}
+	row, err := r.db.QueryRowWithRetry(ctx, r.retries, query, eventID, userID)
+	if err != nil {
+		return false, err
+	}
+	err = row.Scan(&exists)
+	return exists, err
+}
+
+// GetReservedSeats sums the seats held by pending and confirmed bookings.
+func (r *BookingRepository) GetReservedSeats(ctx context.Context, tx *sql.Tx, eventID string) (int, error) {
+	query := `
+SELECT COALESCE(SUM(quantity), 0) FROM bookings WHERE event_id = $1 AND status IN ('pending', 'confirmed')
+`
+	var seats int
+	if tx != nil {
+		err := tx.QueryRowContext(ctx, query, eventID).Scan(&seats)
+		return seats, err
+	}
+	row, err := r.db.QueryRowWithRetry(ctx, r.retries, query, eventID)
+	if err != nil {
+		return 0, err
+	}
+	err = row.Scan(&seats)
+	return seats, err
+}
+
+// GetReservedTierSeats sums the seats of a ticket tier held by pending and
+// confirmed bookings.
+func (r *BookingRepository) GetReservedTierSeats(ctx context.Context, tx *sql.Tx, tierID string) (int, error) {
+	query := `
+SELECT COALESCE(SUM(quantity), 0) FROM bookings WHERE tier_id = $1 AND status IN ('pending', 'confirmed')
+`
+	var seats int
+	err := tx.QueryRowContext(ctx, query, tierID).Scan(&seats)
+	return seats, err
+}
+
 func (r *BookingRepository) Delete(ctx context.Context, tx *sql.Tx, id string) error {
 	query := `DELETE FROM bookings WHERE id = $1`
 	if tx != nil {
@@ -78,7 +147,7 @@ func (r *BookingRepository) Delete(ctx context.Context, tx *sql.Tx, id string) e
 
 func (r *BookingRepository) GetExpired(ctx context.Context, now time.Time) ([]*domain.Booking, error) {
 	query := `
-SELECT id, event_id, user_id, status, created_at, expires_at, confirmed_at
+SELECT id, event_id, user_id, quantity, status, created_at, expires_at, confirmed_at, tier_id, promo_code_id, discount
 FROM bookings WHERE status = 'pending' AND expires_at < $1
 `
 	rows, err := r.db.QueryWithRetry(ctx, r.retries, query, now)
@@ -89,7 +158,7 @@ FROM bookings WHERE status = 'pending' AND expires_at < $1
 	var bookings []*domain.Booking
 	for rows.Next() {
 		var b domain.Booking
-		err := rows.Scan(&b.ID, &b.EventID, &b.UserID, &b.Status, &b.CreatedAt, &b.ExpiresAt, &b.ConfirmedAt)
+		err := rows.Scan(&b.ID, &b.EventID, &b.UserID, &b.Quantity, &b.Status, &b.CreatedAt, &b.ExpiresAt, &b.ConfirmedAt, &b.TierID, &b.PromoCodeID, &b.Discount)
 		if err != nil {
 			return nil, err
 		}
@@ -103,7 +172,7 @@ FROM bookings WHERE status = 'pending' AND expires_at < $1
 
 func (r *BookingRepository) GetByEventID(ctx context.Context, eventID string) ([]*domain.Booking, error) {
 	query := `
-SELECT id, event_id, user_id, status, created_at, expires_at, confirmed_at
+SELECT id, event_id, user_id, quantity, status, created_at, expires_at, confirmed_at, tier_id, promo_code_id, discount
 FROM bookings WHERE event_id = $1
 `
 	rows, err := r.db.QueryWithRetry(ctx, r.retries, query, eventID)
@@ -114,7 +183,7 @@ FROM bookings WHERE event_id = $1
 	var bookings []*domain.Booking
 	for rows.Next() {
 		var b domain.Booking
-		err := rows.Scan(&b.ID, &b.EventID, &b.UserID, &b.Status, &b.CreatedAt, &b.ExpiresAt, &b.ConfirmedAt)
+		err := rows.Scan(&b.ID, &b.EventID, &b.UserID, &b.Quantity, &b.Status, &b.CreatedAt, &b.ExpiresAt, &b.ConfirmedAt, &b.TierID, &b.PromoCodeID, &b.Discount)
 		if err != nil {
 			return nil, err
 		}
@@ -126,15 +195,52 @@ FROM bookings WHERE event_id = $1
 	return bookings, nil
 }
 
-func (r *BookingRepository) GetAll(ctx context.Context) ([]*domain.Booking, error) {
+// List returns one page of bookings matching filter, ordered by creation time
+// with the booking ID as tie-breaker so that cursors are stable.
+func (r *BookingRepository) List(ctx context.Context, filter domain.BookingFilter) (*domain.Page[*domain.Booking], error) {
+	var conds []string
+	var args []interface{}
+	arg := func(v interface{}) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+	if filter.Status != "" {
+		conds = append(conds, "status = "+arg(filter.Status))
+	}
+	if filter.EventID != "" {
+		conds = append(conds, "event_id = "+arg(filter.EventID))
+	}
+	if filter.UserID != "" {
+		conds = append(conds, "user_id = "+arg(filter.UserID))
+	}
+	if filter.OrganizerIDs != nil {
+		conds = append(conds, "event_id IN (SELECT id FROM events WHERE organizer_id = ANY("+arg(pq.Array(filter.OrganizerIDs))+"))")
+	}
+	if filter.CreatedFrom != nil {
+		conds = append(conds, "created_at >= "+arg(*filter.CreatedFrom))
+	}
+	if filter.CreatedTo != nil {
+		conds = append(conds, "created_at < "+arg(*filter.CreatedTo))
+	}
+	op, dir := "<", "DESC"
+	if filter.Sort == domain.BookingSortCreatedAsc {
+		op, dir = ">", "ASC"
+	}
+	if filter.Cursor != "" {
+		value, id, err := repository.DecodeCursor(filter.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		conds = append(conds, fmt.Sprintf("(created_at, id) %s (%s::timestamptz, %s)", op, arg(value), arg(id)))
+	}
 	query := `
-SELECT b.id, b.event_id, b.user_id, b.status, b.created_at, b.expires_at, b.confirmed_at, e.name as event_name, u.email as user_email
-FROM bookings b
-JOIN events e ON b.event_id = e.id
-JOIN users u ON b.user_id = u.id
-ORDER BY b.created_at DESC
-`
-	rows, err := r.db.QueryWithRetry(ctx, r.retries, query)
+SELECT id, event_id, user_id, quantity, status, created_at, expires_at, confirmed_at, tier_id, promo_code_id, discount
+FROM bookings`
+	if len(conds) > 0 {
+		query += "\nWHERE " + strings.Join(conds, " AND ")
+	}
+	query += fmt.Sprintf("\nORDER BY created_at %s, id %s\nLIMIT %s", dir, dir, arg(filter.Limit+1))
+	rows, err := r.db.QueryWithRetry(ctx, r.retries, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -142,9 +248,7 @@ ORDER BY b.created_at DESC
 	var bookings []*domain.Booking
 	for rows.Next() {
 		var b domain.Booking
-		var eventName string
-		var userEmail string
-		err := rows.Scan(&b.ID, &b.EventID, &b.UserID, &b.Status, &b.CreatedAt, &b.ExpiresAt, &b.ConfirmedAt, &eventName, &userEmail)
+		err := rows.Scan(&b.ID, &b.EventID, &b.UserID, &b.Quantity, &b.Status, &b.CreatedAt, &b.ExpiresAt, &b.ConfirmedAt, &b.TierID, &b.PromoCodeID, &b.Discount)
 		if err != nil {
 			return nil, err
 		}
@@ -153,5 +257,71 @@ ORDER BY b.created_at DESC
 	if err := rows.Err(); err != nil {
 		return nil, err
 	}
-	return bookings, nil
+	page := &domain.Page[*domain.Booking]{Items: bookings}
+	if len(bookings) > filter.Limit {
+		page.Items = bookings[:filter.Limit]
+		last := page.Items[len(page.Items)-1]
+		page.NextCursor = repository.EncodeCursor(last.CreatedAt.Format(time.RFC3339Nano), last.ID)
+	}
+	return page, nil
+}
+
+// ListByUser returns one page of the user's bookings joined with their events,
+// newest first. Upcoming and past refer to the event date and exclude cancelled
+// bookings.
+func (r *BookingRepository) ListByUser(ctx context.Context, filter domain.UserBookingFilter) (*domain.Page[*domain.UserBooking], error) {
+	var args []interface{}
+	arg := func(v interface{}) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+	conds := []string{"b.user_id = " + arg(filter.UserID)}
+	switch filter.Scope {
+	case domain.UserBookingsUpcoming:
+		conds = append(conds, "b.status <> 'cancelled'", "e.date > "+arg(filter.Now))
+	case domain.UserBookingsPast:
+		conds = append(conds, "b.status <> 'cancelled'", "e.date <= "+arg(filter.Now))
+	case domain.UserBookingsCancelled:
+		conds = append(conds, "b.status = 'cancelled'")
+	}
+	if filter.Cursor != "" {
+		value, id, err := repository.DecodeCursor(filter.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		conds = append(conds, fmt.Sprintf("(b.created_at, b.id) < (%s::timestamptz, %s)", arg(value), arg(id)))
+	}
+	query := `
+SELECT b.id, b.event_id, b.user_id, b.quantity, b.status, b.created_at, b.expires_at, b.confirmed_at, b.tier_id, b.promo_code_id, b.discount,
+       e.name, e.date, e.status
+FROM bookings b
+JOIN events e ON e.id = b.event_id
+WHERE ` + strings.Join(conds, " AND ")
+	query += fmt.Sprintf("\nORDER BY b.created_at DESC, b.id DESC\nLIMIT %s", arg(filter.Limit+1))
+	rows, err := r.db.QueryWithRetry(ctx, r.retries, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var bookings []*domain.UserBooking
+	for rows.Next() {
+		ub := domain.UserBooking{Booking: &domain.Booking{}}
+		b := ub.Booking
+		err := rows.Scan(&b.ID, &b.EventID, &b.UserID, &b.Quantity, &b.Status, &b.CreatedAt, &b.ExpiresAt, &b.ConfirmedAt, &b.TierID, &b.PromoCodeID, &b.Discount,
+			&ub.EventName, &ub.EventDate, &ub.EventStatus)
+		if err != nil {
+			return nil, err
+		}
+		bookings = append(bookings, &ub)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	page := &domain.Page[*domain.UserBooking]{Items: bookings}
+	if len(bookings) > filter.Limit {
+		page.Items = bookings[:filter.Limit]
+		last := page.Items[len(page.Items)-1]
+		page.NextCursor = repository.EncodeCursor(last.CreatedAt.Format(time.RFC3339Nano), last.ID)
+	}
+	return page, nil
 }
@@ -1,5 +1,32 @@
 package repository
 
-import "errors"
+import (
+	"errors"
 
-var ErrNotFound = errors.New("not found")
+	"github.com/lib/pq"
+)
+
+var (
+	ErrNotFound          = errors.New("not found")
+	ErrInsufficientSeats = errors.New("insufficient seats")
+	ErrAlreadyExists     = errors.New("already exists")
+	ErrInvalidCursor     = errors.New("invalid cursor")
+	ErrSeatUnavailable   = errors.New("seat unavailable")
+	ErrInUse             = errors.New("still referenced")
+	ErrLimitReached      = errors.New("limit reached")
+)
+
+const (
+	uniqueViolationCode     = "23505"
+	foreignKeyViolationCode = "23503"
+)
+
+func IsUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == uniqueViolationCode
+}
+
+func IsForeignKeyViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == foreignKeyViolationCode
+}
@@ -3,6 +3,7 @@ package event_postgres
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"strings"
@@ -11,6 +12,7 @@ import (
 	"event-booker/internal/domain"
 	"event-booker/internal/repository"
 
+	"github.com/lib/pq"
 	"github.com/wb-go/wbf/dbpg"
 	"github.com/wb-go/wbf/retry"
 )
@@ -24,20 +26,24 @@ func NewEventRepository(db *dbpg.DB, retries retry.Strategy) *EventRepository {
 	return &EventRepository{db: db, retries: retries}
 }
 
-func (r *EventRepository) Create(ctx context.Context, event *domain.Event) error {
+func (r *EventRepository) Create(ctx context.Context, tx *sql.Tx, event *domain.Event) error {
 	query := `
-INSERT INTO events (id, name, date, total_seats, available, booking_ttl, requires_payment, status, created_at, updated_at)
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+INSERT INTO events (id, name, date, total_seats, available, max_per_booking, booking_ttl, requires_payment, price, currency, cancellation_policy, status, created_at, updated_at, layout_id, series_id, venue_id, organizer_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)
 `
-	_, err := r.db.ExecWithRetry(ctx, r.retries, query,
-		event.ID, event.Name, event.Date, event.TotalSeats, event.Available,
-		event.BookingTTL, event.RequiresPayment, event.Status, event.CreatedAt, event.UpdatedAt)
+	policy, err := json.Marshal(event.Cancellation)
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, query,
+		event.ID, event.Name, event.Date, event.TotalSeats, event.Available, event.MaxPerBooking,
+		event.BookingTTL, event.RequiresPayment, event.Price, event.Currency, policy, event.Status, event.CreatedAt, event.UpdatedAt, event.LayoutID, event.SeriesID, event.VenueID, event.OrganizerID)
 	return err
 }
 
 func (r *EventRepository) GetByID(ctx context.Context, id string) (*domain.Event, error) {
 	query := `
-SELECT id, name, date, total_seats, available, booking_ttl, requires_payment, status, created_at, updated_at
+SELECT id, name, date, total_seats, available, max_per_booking, booking_ttl, requires_payment, price, currency, cancellation_policy, status, created_at, updated_at, layout_id, series_id, venue_id, organizer_id
 FROM events WHERE id = $1
 `
 	row, err := r.db.QueryRowWithRetry(ctx, r.retries, query, id)
@@ -47,17 +53,26 @@ FROM events WHERE id = $1
 	var event domain.Event
 	var ttlStr string
 	var statusStr string
+	var policyRaw []byte
 	err = row.Scan(
 		&event.ID,
 		&event.Name,
 		&event.Date,
 		&event.TotalSeats,
 		&event.Available,
+		&event.MaxPerBooking,
 		&ttlStr,
 		&event.RequiresPayment,
+		&event.Price,
+		&event.Currency,
+		&policyRaw,
 		&statusStr,
 		&event.CreatedAt,
 		&event.UpdatedAt,
+		&event.LayoutID,
+		&event.SeriesID,
+		&event.VenueID,
+		&event.OrganizerID,
 	)
 	if err == sql.ErrNoRows {
 		return nil, repository.ErrNotFound
@@ -75,12 +90,15 @@ FROM events WHERE id = $1
 	}
 	event.BookingTTL = time.Duration(h)*time.Hour + time.Duration(m)*time.Minute + time.Duration(s)*time.Second
 	event.Status = domain.EventStatus(statusStr)
+	if err := json.Unmarshal(policyRaw, &event.Cancellation); err != nil {
+		return nil, fmt.Errorf("failed to parse cancellation_policy: %w", err)
+	}
 	return &event, nil
 }
 
 func (r *EventRepository) GetForUpdate(ctx context.Context, tx *sql.Tx, id string) (*domain.Event, error) {
 	query := `
-SELECT id, name, date, total_seats, available, booking_ttl, requires_payment, status, created_at, updated_at
+SELECT id, name, date, total_seats, available, max_per_booking, booking_ttl, requires_payment, price, currency, cancellation_policy, status, created_at, updated_at, layout_id, series_id, venue_id, organizer_id
 FROM events WHERE id = $1 FOR UPDATE
 `
 	var row *sql.Row
@@ -96,17 +114,26 @@ FROM events WHERE id = $1 FOR UPDATE
 	var event domain.Event
 	var ttlStr string
 	var statusStr string
+	var policyRaw []byte
 	err := row.Scan(
 		&event.ID,
 		&event.Name,
 		&event.Date,
 		&event.TotalSeats,
 		&event.Available,
+		&event.MaxPerBooking,
 		&ttlStr,
 		&event.RequiresPayment,
+		&event.Price,
+		&event.Currency,
+		&policyRaw,
 		&statusStr,
 		&event.CreatedAt,
 		&event.UpdatedAt,
+		&event.LayoutID,
+		&event.SeriesID,
+		&event.VenueID,
+		&event.OrganizerID,
 	)
 	if err == sql.ErrNoRows {
 		return nil, repository.ErrNotFound
@@ -124,16 +151,85 @@ FROM events WHERE id = $1 FOR UPDATE
 	}
 	event.BookingTTL = time.Duration(h)*time.Hour + time.Duration(m)*time.Minute + time.Duration(s)*time.Second
 	event.Status = domain.EventStatus(statusStr)
+	if err := json.Unmarshal(policyRaw, &event.Cancellation); err != nil {
+		return nil, fmt.Errorf("failed to parse cancellation_policy: %w", err)
+	}
 	return &event, nil
 }
 
-func (r *EventRepository) GetAll(ctx context.Context) ([]*domain.Event, error) {
+type eventSortSpec struct {
+	column string
+	cast   string
+	desc   bool
+	value  func(e *domain.Event) string
+}
+
+var eventSorts = map[domain.EventSort]eventSortSpec{
+	domain.EventSortDateAsc:     {column: "date", cast: "timestamptz", value: func(e *domain.Event) string { return e.Date.Format(time.RFC3339Nano) }},
+	domain.EventSortDateDesc:    {column: "date", cast: "timestamptz", desc: true, value: func(e *domain.Event) string { return e.Date.Format(time.RFC3339Nano) }},
+	domain.EventSortCreatedDesc: {column: "created_at", cast: "timestamptz", desc: true, value: func(e *domain.Event) string { return e.CreatedAt.Format(time.RFC3339Nano) }},
+	domain.EventSortNameAsc:     {column: "name", cast: "text", value: func(e *domain.Event) string { return e.Name }},
+}
+
+// List returns one page of events matching filter, ordered by the requested
+// sort with the event ID as tie-breaker so that cursors are stable.
+func (r *EventRepository) List(ctx context.Context, filter domain.EventFilter) (*domain.Page[*domain.Event], error) {
+	sort, ok := eventSorts[filter.Sort]
+	if !ok {
+		sort = eventSorts[domain.EventSortDateAsc]
+	}
+	var conds []string
+	var args []interface{}
+	arg := func(v interface{}) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+	if filter.Status != "" {
+		conds = append(conds, "status = "+arg(filter.Status))
+	}
+	if filter.DateFrom != nil {
+		conds = append(conds, "date >= "+arg(*filter.DateFrom))
+	}
+	if filter.DateTo != nil {
+		conds = append(conds, "date < "+arg(*filter.DateTo))
+	}
+	if filter.RequiresPayment != nil {
+		conds = append(conds, "requires_payment = "+arg(*filter.RequiresPayment))
+	}
+	if filter.SeriesID != "" {
+		conds = append(conds, "series_id = "+arg(filter.SeriesID))
+	}
+	if filter.VenueID != "" {
+		conds = append(conds, "venue_id = "+arg(filter.VenueID))
+	}
+	if filter.OrganizerID != "" {
+		conds = append(conds, "organizer_id = "+arg(filter.OrganizerID))
+	}
+	if filter.OrganizerIDs != nil {
+		conds = append(conds, "organizer_id = ANY("+arg(pq.Array(filter.OrganizerIDs))+")")
+	}
+	if filter.Search != "" {
+		conds = append(conds, "name ILIKE "+arg(repository.LikePattern(filter.Search)))
+	}
+	op, dir := ">", "ASC"
+	if sort.desc {
+		op, dir = "<", "DESC"
+	}
+	if filter.Cursor != "" {
+		value, id, err := repository.DecodeCursor(filter.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		conds = append(conds, fmt.Sprintf("(%s, id) %s (%s::%s, %s)", sort.column, op, arg(value), sort.cast, arg(id)))
+	}
 	query := `
-SELECT id, name, date, total_seats, available, booking_ttl, requires_payment, status, created_at, updated_at
-FROM events
-ORDER BY date ASC, created_at DESC
-`
-	rows, err := r.db.QueryWithRetry(ctx, r.retries, query)
+SELECT id, name, date, total_seats, available, max_per_booking, booking_ttl, requires_payment, price, currency, cancellation_policy, status, created_at, updated_at, layout_id, series_id, venue_id, organizer_id
+FROM events`
+	if len(conds) > 0 {
+		query += "\nWHERE " + strings.Join(conds, " AND ")
+	}
+	query += fmt.Sprintf("\nORDER BY %s %s, id %s\nLIMIT %s", sort.column, dir, dir, arg(filter.Limit+1))
+	rows, err := r.db.QueryWithRetry(ctx, r.retries, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -143,17 +239,26 @@ ORDER BY date ASC, created_at DESC
 		var event domain.Event
 		var ttlStr string
 		var statusStr string
+		var policyRaw []byte
 		err := rows.Scan(
 			&event.ID,
 			&event.Name,
 			&event.Date,
 			&event.TotalSeats,
 			&event.Available,
+			&event.MaxPerBooking,
 			&ttlStr,
 			&event.RequiresPayment,
+			&event.Price,
+			&event.Currency,
+			&policyRaw,
 			&statusStr,
 			&event.CreatedAt,
 			&event.UpdatedAt,
+			&event.LayoutID,
+			&event.SeriesID,
+			&event.VenueID,
+			&event.OrganizerID,
 		)
 		if err != nil {
 			return nil, err
@@ -168,33 +273,74 @@ ORDER BY date ASC, created_at DESC
 		}
 		event.BookingTTL = time.Duration(h)*time.Hour + time.Duration(m)*time.Minute + time.Duration(s)*time.Second
 		event.Status = domain.EventStatus(statusStr)
+		if err := json.Unmarshal(policyRaw, &event.Cancellation); err != nil {
+			return nil, fmt.Errorf("failed to parse cancellation_policy: %w", err)
+		}
 		events = append(events, &event)
 	}
 	if err := rows.Err(); err != nil {
 		return nil, err
 	}
-	return events, nil
+	page := &domain.Page[*domain.Event]{Items: events}
+	if len(events) > filter.Limit {
+		page.Items = events[:filter.Limit]
+		last := page.Items[len(page.Items)-1]
+		page.NextCursor = repository.EncodeCursor(sort.value(last), last.ID)
+	}
+	return page, nil
 }
 
 func (r *EventRepository) Update(ctx context.Context, tx *sql.Tx, event *domain.Event) error {
 	query := `
 UPDATE events
-SET name = $1, date = $2, total_seats = $3, available = $4,
-    booking_ttl = $5, requires_payment = $6, status = $7, updated_at = $8
-WHERE id = $9
+SET name = $1, date = $2, total_seats = $3, available = $4, max_per_booking = $5,
+    booking_ttl = $6, requires_payment = $7, price = $8, currency = $9, cancellation_policy = $10,
+    status = $11, updated_at = $12, venue_id = $13, organizer_id = $14
+WHERE id = $15
 `
+	policy, err := json.Marshal(event.Cancellation)
+	if err != nil {
+		return err
+	}
 	if tx != nil {
 		_, err := tx.ExecContext(ctx, query,
-			event.Name, event.Date, event.TotalSeats, event.Available,
-			event.BookingTTL, event.RequiresPayment, event.Status, event.UpdatedAt, event.ID)
+			event.Name, event.Date, event.TotalSeats, event.Available, event.MaxPerBooking,
+			event.BookingTTL, event.RequiresPayment, event.Price, event.Currency, policy, event.Status, event.UpdatedAt, event.VenueID, event.OrganizerID, event.ID)
 		return err
 	}
-	_, err := r.db.ExecWithRetry(ctx, r.retries, query,
-		event.Name, event.Date, event.TotalSeats, event.Available,
-		event.BookingTTL, event.RequiresPayment, event.Status, event.UpdatedAt, event.ID)
+	_, err = r.db.ExecWithRetry(ctx, r.retries, query,
+		event.Name, event.Date, event.TotalSeats, event.Available, event.MaxPerBooking,
+		event.BookingTTL, event.RequiresPayment, event.Price, event.Currency, policy, event.Status, event.UpdatedAt, event.VenueID, event.OrganizerID, event.ID)
 	return err
 }
 
+// CompletePast marks active events whose date is not after now as completed and
+// returns their IDs.
+func (r *EventRepository) CompletePast(ctx context.Context, tx *sql.Tx, now time.Time) ([]string, error) {
+	query := `
+UPDATE events SET status = 'completed', updated_at = NOW()
+WHERE status = 'active' AND date <= $1
+RETURNING id
+`
+	rows, err := tx.QueryContext(ctx, query, now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
 func (r *EventRepository) Delete(ctx context.Context, eventID string) error {
 	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
@@ -214,22 +360,34 @@ func (r *EventRepository) Delete(ctx context.Context, eventID string) error {
 	return tx.Commit()
 }
 
-func (r *EventRepository) DecrementAvailableSeats(ctx context.Context, tx *sql.Tx, id string) error {
-	query := `UPDATE events SET available = available - 1, updated_at = NOW() WHERE id = $1 AND available > 0`
+func (r *EventRepository) DecrementAvailableSeats(ctx context.Context, tx *sql.Tx, id string, seats int) error {
+	query := `UPDATE events SET available = available - $2, updated_at = NOW() WHERE id = $1 AND available >= $2`
+	var res sql.Result
+	var err error
 	if tx != nil {
-		_, err := tx.ExecContext(ctx, query, id)
+		res, err = tx.ExecContext(ctx, query, id, seats)
+	} else {
+		res, err = r.db.ExecWithRetry(ctx, r.retries, query, id, seats)
+	}
+	if err != nil {
 		return err
 	}
-	_, err := r.db.ExecWithRetry(ctx, r.retries, query, id)
-	return err
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return repository.ErrInsufficientSeats
+	}
+	return nil
 }
 
-func (r *EventRepository) IncrementAvailableSeats(ctx context.Context, tx *sql.Tx, id string) error {
-	query := `UPDATE events SET available = available + 1, updated_at = NOW() WHERE id = $1`
+func (r *EventRepository) IncrementAvailableSeats(ctx context.Context, tx *sql.Tx, id string, seats int) error {
+	query := `UPDATE events SET available = LEAST(total_seats, available + $2), updated_at = NOW() WHERE id = $1`
 	if tx != nil {
-		_, err := tx.ExecContext(ctx, query, id)
+		_, err := tx.ExecContext(ctx, query, id, seats)
 		return err
 	}
-	_, err := r.db.ExecWithRetry(ctx, r.retries, query, id)
+	_, err := r.db.ExecWithRetry(ctx, r.retries, query, id, seats)
 	return err
 }
@@ -0,0 +1,63 @@
+package event_postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"event-booker/internal/domain"
+	"event-booker/internal/repository"
+)
+
+func (r *EventRepository) CreateSeries(ctx context.Context, tx *sql.Tx, series *domain.EventSeries) error {
+	query := `
+INSERT INTO event_series (id, name, recurrence, starts_at, organizer_id, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+`
+	_, err := tx.ExecContext(ctx, query,
+		series.ID, series.Name, series.Recurrence, series.Start, series.OrganizerID, series.CreatedAt, series.UpdatedAt)
+	return err
+}
+
+func (r *EventRepository) GetSeries(ctx context.Context, id string) (*domain.EventSeries, error) {
+	query := `
+SELECT id, name, recurrence, starts_at, organizer_id, created_at, updated_at
+FROM event_series WHERE id = $1
+`
+	row, err := r.db.QueryRowWithRetry(ctx, r.retries, query, id)
+	if err != nil {
+		return nil, err
+	}
+	var series domain.EventSeries
+	err = row.Scan(&series.ID, &series.Name, &series.Recurrence, &series.Start, &series.OrganizerID, &series.CreatedAt, &series.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, repository.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &series, nil
+}
+
+// ListSeriesEventIDs returns the IDs of the series' occurrences dated at or
+// after from, in date order.
+func (r *EventRepository) ListSeriesEventIDs(ctx context.Context, tx *sql.Tx, seriesID string, from time.Time) ([]string, error) {
+	query := `SELECT id FROM events WHERE series_id = $1 AND date >= $2 ORDER BY date, id`
+	rows, err := tx.QueryContext(ctx, query, seriesID, from)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
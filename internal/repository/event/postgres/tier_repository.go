@@ -0,0 +1,161 @@
+package event_postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"event-booker/internal/domain"
+	"event-booker/internal/repository"
+)
+
+const tierColumns = `id, event_id, name, price, currency, quota, available, sales_start, sales_end, created_at, updated_at`
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanTier(row rowScanner) (*domain.TicketTier, error) {
+	var tier domain.TicketTier
+	err := row.Scan(
+		&tier.ID,
+		&tier.EventID,
+		&tier.Name,
+		&tier.Price,
+		&tier.Currency,
+		&tier.Quota,
+		&tier.Available,
+		&tier.SalesStart,
+		&tier.SalesEnd,
+		&tier.CreatedAt,
+		&tier.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, repository.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &tier, nil
+}
+
+func (r *EventRepository) CreateTier(ctx context.Context, tx *sql.Tx, tier *domain.TicketTier) error {
+	query := `
+INSERT INTO ticket_tiers (` + tierColumns + `)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+`
+	_, err := tx.ExecContext(ctx, query,
+		tier.ID, tier.EventID, tier.Name, tier.Price, tier.Currency, tier.Quota, tier.Available,
+		tier.SalesStart, tier.SalesEnd, tier.CreatedAt, tier.UpdatedAt)
+	if repository.IsUniqueViolation(err) {
+		return repository.ErrAlreadyExists
+	}
+	return err
+}
+
+func (r *EventRepository) GetTier(ctx context.Context, id string) (*domain.TicketTier, error) {
+	query := `SELECT ` + tierColumns + ` FROM ticket_tiers WHERE id = $1`
+	row, err := r.db.QueryRowWithRetry(ctx, r.retries, query, id)
+	if err != nil {
+		return nil, err
+	}
+	return scanTier(row)
+}
+
+func (r *EventRepository) GetTierForUpdate(ctx context.Context, tx *sql.Tx, id string) (*domain.TicketTier, error) {
+	query := `SELECT ` + tierColumns + ` FROM ticket_tiers WHERE id = $1 FOR UPDATE`
+	return scanTier(tx.QueryRowContext(ctx, query, id))
+}
+
+// ListTiers returns the tiers of an event ordered by price and name. With a
+// non-nil tx the read joins the caller's transaction.
+func (r *EventRepository) ListTiers(ctx context.Context, tx *sql.Tx, eventID string) ([]*domain.TicketTier, error) {
+	query := `SELECT ` + tierColumns + ` FROM ticket_tiers WHERE event_id = $1 ORDER BY price, name`
+	var rows *sql.Rows
+	var err error
+	if tx != nil {
+		rows, err = tx.QueryContext(ctx, query, eventID)
+	} else {
+		rows, err = r.db.QueryWithRetry(ctx, r.retries, query, eventID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	tiers := []*domain.TicketTier{}
+	for rows.Next() {
+		tier, err := scanTier(rows)
+		if err != nil {
+			return nil, err
+		}
+		tiers = append(tiers, tier)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return tiers, nil
+}
+
+func (r *EventRepository) HasTiers(ctx context.Context, tx *sql.Tx, eventID string) (bool, error) {
+	query := `SELECT EXISTS (SELECT 1 FROM ticket_tiers WHERE event_id = $1)`
+	var exists bool
+	err := tx.QueryRowContext(ctx, query, eventID).Scan(&exists)
+	return exists, err
+}
+
+func (r *EventRepository) UpdateTier(ctx context.Context, tx *sql.Tx, tier *domain.TicketTier) error {
+	query := `
+UPDATE ticket_tiers
+SET name = $1, price = $2, currency = $3, quota = $4, available = $5,
+    sales_start = $6, sales_end = $7, updated_at = $8
+WHERE id = $9
+`
+	_, err := tx.ExecContext(ctx, query,
+		tier.Name, tier.Price, tier.Currency, tier.Quota, tier.Available,
+		tier.SalesStart, tier.SalesEnd, tier.UpdatedAt, tier.ID)
+	if repository.IsUniqueViolation(err) {
+		return repository.ErrAlreadyExists
+	}
+	return err
+}
+
+// DeleteTier removes a tier; it fails with ErrInUse while bookings or waitlist
+// entries still reference it.
+func (r *EventRepository) DeleteTier(ctx context.Context, tx *sql.Tx, id string) error {
+	res, err := tx.ExecContext(ctx, `DELETE FROM ticket_tiers WHERE id = $1`, id)
+	if repository.IsForeignKeyViolation(err) {
+		return repository.ErrInUse
+	}
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+func (r *EventRepository) DecrementTierSeats(ctx context.Context, tx *sql.Tx, tierID string, seats int) error {
+	query := `UPDATE ticket_tiers SET available = available - $2, updated_at = NOW() WHERE id = $1 AND available >= $2`
+	res, err := tx.ExecContext(ctx, query, tierID, seats)
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return repository.ErrInsufficientSeats
+	}
+	return nil
+}
+
+func (r *EventRepository) IncrementTierSeats(ctx context.Context, tx *sql.Tx, tierID string, seats int) error {
+	query := `UPDATE ticket_tiers SET available = LEAST(quota, available + $2), updated_at = NOW() WHERE id = $1`
+	_, err := tx.ExecContext(ctx, query, tierID, seats)
+	return err
+}
@@ -0,0 +1,119 @@
+package idempotency_postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"event-booker/internal/domain"
+	"event-booker/internal/repository"
+
+	"github.com/wb-go/wbf/dbpg"
+	"github.com/wb-go/wbf/retry"
+)
+
+type IdempotencyRepository struct {
+	db      *dbpg.DB
+	retries retry.Strategy
+}
+
+func NewIdempotencyRepository(db *dbpg.DB, retries retry.Strategy) *IdempotencyRepository {
+	return &IdempotencyRepository{db: db, retries: retries}
+}
+
+// Reserve inserts an in-progress record and reports whether it was created;
+// false means a record with the same user and key already exists.
+func (r *IdempotencyRepository) Reserve(ctx context.Context, record *domain.IdempotencyRecord) (bool, error) {
+	query := `
+INSERT INTO idempotency_keys (user_id, key, fingerprint, created_at)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (user_id, key) DO NOTHING
+`
+	res, err := r.db.ExecWithRetry(ctx, r.retries, query, record.UserID, record.Key, record.Fingerprint, record.CreatedAt)
+	if err != nil {
+		return false, err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected == 1, nil
+}
+
+func (r *IdempotencyRepository) Get(ctx context.Context, userID, key string) (*domain.IdempotencyRecord, error) {
+	query := `
+SELECT user_id, key, fingerprint, status_code, content_type, response_body, created_at, completed_at
+FROM idempotency_keys WHERE user_id = $1 AND key = $2
+`
+	row, err := r.db.QueryRowWithRetry(ctx, r.retries, query, userID, key)
+	if err != nil {
+		return nil, err
+	}
+	var record domain.IdempotencyRecord
+	err = row.Scan(&record.UserID, &record.Key, &record.Fingerprint, &record.StatusCode, &record.ContentType,
+		&record.Body, &record.CreatedAt, &record.CompletedAt)
+	if err == sql.ErrNoRows {
+		return nil, repository.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+// TakeOver hands the in-progress record claimed at claimedAt to a new request
+// and reports whether it did. It fails when the record was completed or
+// claimed again in the meantime.
+func (r *IdempotencyRepository) TakeOver(ctx context.Context, record *domain.IdempotencyRecord, claimedAt time.Time) (bool, error) {
+	query := `
+UPDATE idempotency_keys SET created_at = $3
+WHERE user_id = $1 AND key = $2 AND fingerprint = $4 AND completed_at IS NULL AND created_at = $5
+`
+	res, err := r.db.ExecWithRetry(ctx, r.retries, query, record.UserID, record.Key, record.CreatedAt, record.Fingerprint, claimedAt)
+	if err != nil {
+		return false, err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected == 1, nil
+}
+
+// Complete stores the response of the request that claimed the record at
+// record.CreatedAt and reports whether it did. It fails when the claim was
+// taken over by another request or the record was removed.
+func (r *IdempotencyRepository) Complete(ctx context.Context, record *domain.IdempotencyRecord) (bool, error) {
+	query := `
+UPDATE idempotency_keys
+SET status_code = $3, content_type = $4, response_body = $5, completed_at = $6
+WHERE user_id = $1 AND key = $2 AND created_at = $7 AND completed_at IS NULL
+`
+	res, err := r.db.ExecWithRetry(ctx, r.retries, query, record.UserID, record.Key, record.StatusCode,
+		record.ContentType, record.Body, record.CompletedAt, record.CreatedAt)
+	if err != nil {
+		return false, err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected == 1, nil
+}
+
+// Delete removes the record if it was still claimed at record.CreatedAt, so a
+// request never removes a record that has since been taken over.
+func (r *IdempotencyRepository) Delete(ctx context.Context, record *domain.IdempotencyRecord) error {
+	query := `DELETE FROM idempotency_keys WHERE user_id = $1 AND key = $2 AND created_at = $3`
+	_, err := r.db.ExecWithRetry(ctx, r.retries, query, record.UserID, record.Key, record.CreatedAt)
+	return err
+}
+
+func (r *IdempotencyRepository) DeleteOlderThan(ctx context.Context, before time.Time) (int64, error) {
+	query := `DELETE FROM idempotency_keys WHERE created_at < $1`
+	res, err := r.db.ExecWithRetry(ctx, r.retries, query, before)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
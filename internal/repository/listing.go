@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+)
+
+// cursor is the position after the last row of a page: the value of the sort
+// column and the row ID that breaks ties.
+type cursor struct {
+	Value string `json:"v"`
+	ID    string `json:"id"`
+}
+
+func EncodeCursor(value, id string) string {
+	raw, _ := json.Marshal(cursor{Value: value, ID: id})
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func DecodeCursor(encoded string) (value, id string, err error) {
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", "", ErrInvalidCursor
+	}
+	var c cursor
+	if err := json.Unmarshal(raw, &c); err != nil || c.ID == "" {
+		return "", "", ErrInvalidCursor
+	}
+	return c.Value, c.ID, nil
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// LikePattern builds an ILIKE pattern matching s anywhere in the column.
+func LikePattern(s string) string {
+	return "%" + likeEscaper.Replace(s) + "%"
+}
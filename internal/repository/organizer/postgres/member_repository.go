@@ -0,0 +1,105 @@
+package organizer_postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"event-booker/internal/domain"
+	"event-booker/internal/repository"
+)
+
+func (r *OrganizerRepository) GetForUpdate(ctx context.Context, tx *sql.Tx, id string) (*domain.Organizer, error) {
+	query := `SELECT ` + organizerColumns + ` FROM organizers WHERE id = $1 FOR UPDATE`
+	return scanOrganizer(tx.QueryRowContext(ctx, query, id))
+}
+
+func (r *OrganizerRepository) ListMembers(ctx context.Context, organizerID string) ([]*domain.OrganizerMember, error) {
+	query := `
+SELECT organizer_id, user_id, role, created_at
+FROM organizer_members WHERE organizer_id = $1
+ORDER BY created_at, user_id
+`
+	return r.queryMembers(ctx, query, organizerID)
+}
+
+// ListUserMemberships returns every organizer membership of the user.
+func (r *OrganizerRepository) ListUserMemberships(ctx context.Context, userID string) ([]*domain.OrganizerMember, error) {
+	query := `
+SELECT organizer_id, user_id, role, created_at
+FROM organizer_members WHERE user_id = $1
+`
+	return r.queryMembers(ctx, query, userID)
+}
+
+func (r *OrganizerRepository) queryMembers(ctx context.Context, query string, arg string) ([]*domain.OrganizerMember, error) {
+	rows, err := r.db.QueryWithRetry(ctx, r.retries, query, arg)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	members := []*domain.OrganizerMember{}
+	for rows.Next() {
+		var m domain.OrganizerMember
+		if err := rows.Scan(&m.OrganizerID, &m.UserID, &m.Role, &m.CreatedAt); err != nil {
+			return nil, err
+		}
+		members = append(members, &m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return members, nil
+}
+
+func (r *OrganizerRepository) GetMember(ctx context.Context, tx *sql.Tx, organizerID, userID string) (*domain.OrganizerMember, error) {
+	query := `
+SELECT organizer_id, user_id, role, created_at
+FROM organizer_members WHERE organizer_id = $1 AND user_id = $2
+`
+	var m domain.OrganizerMember
+	err := tx.QueryRowContext(ctx, query, organizerID, userID).Scan(&m.OrganizerID, &m.UserID, &m.Role, &m.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, repository.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// UpsertMember adds the user to the organizer or changes their role. It fails
+// with ErrNotFound when the user does not exist.
+func (r *OrganizerRepository) UpsertMember(ctx context.Context, tx *sql.Tx, m *domain.OrganizerMember) error {
+	query := `
+INSERT INTO organizer_members (organizer_id, user_id, role, created_at)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (organizer_id, user_id) DO UPDATE SET role = EXCLUDED.role
+`
+	_, err := tx.ExecContext(ctx, query, m.OrganizerID, m.UserID, m.Role, m.CreatedAt)
+	if repository.IsForeignKeyViolation(err) {
+		return repository.ErrNotFound
+	}
+	return err
+}
+
+func (r *OrganizerRepository) DeleteMember(ctx context.Context, tx *sql.Tx, organizerID, userID string) error {
+	res, err := tx.ExecContext(ctx, `DELETE FROM organizer_members WHERE organizer_id = $1 AND user_id = $2`, organizerID, userID)
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+func (r *OrganizerRepository) CountOwners(ctx context.Context, tx *sql.Tx, organizerID string) (int, error) {
+	var owners int
+	err := tx.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM organizer_members WHERE organizer_id = $1 AND role = 'owner'`, organizerID).Scan(&owners)
+	return owners, err
+}
@@ -0,0 +1,115 @@
+package organizer_postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"event-booker/internal/domain"
+	"event-booker/internal/repository"
+
+	"github.com/wb-go/wbf/dbpg"
+	"github.com/wb-go/wbf/retry"
+)
+
+const organizerColumns = `id, name, email, website, created_at, updated_at`
+
+type OrganizerRepository struct {
+	db      *dbpg.DB
+	retries retry.Strategy
+}
+
+func NewOrganizerRepository(db *dbpg.DB, retries retry.Strategy) *OrganizerRepository {
+	return &OrganizerRepository{db: db, retries: retries}
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanOrganizer(row rowScanner) (*domain.Organizer, error) {
+	var o domain.Organizer
+	err := row.Scan(&o.ID, &o.Name, &o.Email, &o.Website, &o.CreatedAt, &o.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, repository.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &o, nil
+}
+
+func (r *OrganizerRepository) Create(ctx context.Context, o *domain.Organizer) error {
+	query := `
+INSERT INTO organizers (` + organizerColumns + `)
+VALUES ($1, $2, $3, $4, $5, $6)
+`
+	_, err := r.db.ExecWithRetry(ctx, r.retries, query,
+		o.ID, o.Name, o.Email, o.Website, o.CreatedAt, o.UpdatedAt)
+	if repository.IsUniqueViolation(err) {
+		return repository.ErrAlreadyExists
+	}
+	return err
+}
+
+func (r *OrganizerRepository) GetByID(ctx context.Context, id string) (*domain.Organizer, error) {
+	query := `SELECT ` + organizerColumns + ` FROM organizers WHERE id = $1`
+	row, err := r.db.QueryRowWithRetry(ctx, r.retries, query, id)
+	if err != nil {
+		return nil, err
+	}
+	return scanOrganizer(row)
+}
+
+func (r *OrganizerRepository) List(ctx context.Context) ([]*domain.Organizer, error) {
+	query := `SELECT ` + organizerColumns + ` FROM organizers ORDER BY name, id`
+	rows, err := r.db.QueryWithRetry(ctx, r.retries, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	organizers := []*domain.Organizer{}
+	for rows.Next() {
+		o, err := scanOrganizer(rows)
+		if err != nil {
+			return nil, err
+		}
+		organizers = append(organizers, o)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return organizers, nil
+}
+
+func (r *OrganizerRepository) Update(ctx context.Context, o *domain.Organizer) error {
+	query := `
+UPDATE organizers
+SET name = $1, email = $2, website = $3, updated_at = $4
+WHERE id = $5
+`
+	_, err := r.db.ExecWithRetry(ctx, r.retries, query, o.Name, o.Email, o.Website, o.UpdatedAt, o.ID)
+	if repository.IsUniqueViolation(err) {
+		return repository.ErrAlreadyExists
+	}
+	return err
+}
+
+// Delete removes an organizer; it fails with ErrInUse while events reference
+// it.
+func (r *OrganizerRepository) Delete(ctx context.Context, id string) error {
+	res, err := r.db.ExecWithRetry(ctx, r.retries, `DELETE FROM organizers WHERE id = $1`, id)
+	if repository.IsForeignKeyViolation(err) {
+		return repository.ErrInUse
+	}
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,175 @@
+package outbox_postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"event-booker/internal/domain"
+	"event-booker/internal/repository"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/wb-go/wbf/dbpg"
+	"github.com/wb-go/wbf/retry"
+)
+
+const outboxColumns = `id, payload, status, attempts, next_attempt_at, COALESCE(last_error, ''), created_at, updated_at, sent_at, delivered_channels`
+
+type OutboxRepository struct {
+	db      *dbpg.DB
+	retries retry.Strategy
+}
+
+func NewOutboxRepository(db *dbpg.DB, retries retry.Strategy) *OutboxRepository {
+	return &OutboxRepository{db: db, retries: retries}
+}
+
+func (r *OutboxRepository) Enqueue(ctx context.Context, tx *sql.Tx, notification *domain.Notification) error {
+	payload, err := json.Marshal(notification)
+	if err != nil {
+		return err
+	}
+	query := `
+INSERT INTO outbox (id, kind, user_id, payload, status, next_attempt_at, created_at, updated_at)
+VALUES ($1, $2, $3, $4, 'pending', NOW(), NOW(), NOW())
+`
+	args := []interface{}{uuid.NewString(), notification.Kind, notification.UserID, payload}
+	if tx != nil {
+		_, err := tx.ExecContext(ctx, query, args...)
+		return err
+	}
+	_, err = r.db.ExecWithRetry(ctx, r.retries, query, args...)
+	return err
+}
+
+// ClaimDue leases up to limit due messages until leaseUntil so that concurrent
+// dispatchers skip them. A crashed dispatcher's lease simply expires.
+func (r *OutboxRepository) ClaimDue(ctx context.Context, limit int, leaseUntil time.Time) ([]*domain.OutboxMessage, error) {
+	query := `
+UPDATE outbox SET next_attempt_at = $2, updated_at = NOW()
+WHERE id IN (
+    SELECT id FROM outbox
+    WHERE status = 'pending' AND next_attempt_at <= NOW()
+    ORDER BY next_attempt_at
+    LIMIT $1
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING ` + outboxColumns
+	rows, err := r.db.Master.QueryContext(ctx, query, limit, leaseUntil)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanMessages(rows)
+}
+
+func (r *OutboxRepository) MarkSent(ctx context.Context, id string, attempts int, delivered []domain.NotificationChannel) error {
+	query := `
+UPDATE outbox SET status = 'sent', attempts = $2, delivered_channels = $3, last_error = NULL, sent_at = NOW(), updated_at = NOW()
+WHERE id = $1
+`
+	_, err := r.db.ExecWithRetry(ctx, r.retries, query, id, attempts, pq.Array(channelStrings(delivered)))
+	return err
+}
+
+// MarkRetry reschedules a message. delivered lists the channels that already
+// received it, which the next attempt skips.
+func (r *OutboxRepository) MarkRetry(ctx context.Context, id string, attempts int, delivered []domain.NotificationChannel, nextAttemptAt time.Time, lastError string) error {
+	query := `
+UPDATE outbox SET attempts = $2, delivered_channels = $3, next_attempt_at = $4, last_error = $5, updated_at = NOW()
+WHERE id = $1
+`
+	_, err := r.db.ExecWithRetry(ctx, r.retries, query, id, attempts, pq.Array(channelStrings(delivered)), nextAttemptAt, lastError)
+	return err
+}
+
+func (r *OutboxRepository) MarkDead(ctx context.Context, id string, attempts int, delivered []domain.NotificationChannel, lastError string) error {
+	query := `
+UPDATE outbox SET status = 'dead', attempts = $2, delivered_channels = $3, last_error = $4, updated_at = NOW()
+WHERE id = $1
+`
+	_, err := r.db.ExecWithRetry(ctx, r.retries, query, id, attempts, pq.Array(channelStrings(delivered)), lastError)
+	return err
+}
+
+func (r *OutboxRepository) GetByID(ctx context.Context, id string) (*domain.OutboxMessage, error) {
+	query := `SELECT ` + outboxColumns + ` FROM outbox WHERE id = $1`
+	rows, err := r.db.QueryWithRetry(ctx, r.retries, query, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	messages, err := scanMessages(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(messages) == 0 {
+		return nil, repository.ErrNotFound
+	}
+	return messages[0], nil
+}
+
+func (r *OutboxRepository) GetByStatus(ctx context.Context, status domain.OutboxStatus, limit int) ([]*domain.OutboxMessage, error) {
+	query := `SELECT ` + outboxColumns + ` FROM outbox WHERE status = $1 ORDER BY created_at DESC LIMIT $2`
+	rows, err := r.db.QueryWithRetry(ctx, r.retries, query, status, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanMessages(rows)
+}
+
+// Requeue resets a dead message so the dispatcher delivers it again over the
+// channels that have not received it yet.
+func (r *OutboxRepository) Requeue(ctx context.Context, id string) error {
+	query := `
+UPDATE outbox SET status = 'pending', attempts = 0, next_attempt_at = NOW(), updated_at = NOW()
+WHERE id = $1 AND status = 'dead'
+`
+	res, err := r.db.ExecWithRetry(ctx, r.retries, query, id)
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+func scanMessages(rows *sql.Rows) ([]*domain.OutboxMessage, error) {
+	var messages []*domain.OutboxMessage
+	for rows.Next() {
+		var m domain.OutboxMessage
+		var payload []byte
+		var delivered []string
+		err := rows.Scan(&m.ID, &payload, &m.Status, &m.Attempts, &m.NextAttemptAt, &m.LastError, &m.CreatedAt, &m.UpdatedAt, &m.SentAt, pq.Array(&delivered))
+		if err != nil {
+			return nil, err
+		}
+		for _, ch := range delivered {
+			m.Delivered = append(m.Delivered, domain.NotificationChannel(ch))
+		}
+		if err := json.Unmarshal(payload, &m.Notification); err != nil {
+			return nil, err
+		}
+		messages = append(messages, &m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
+
+func channelStrings(channels []domain.NotificationChannel) []string {
+	out := make([]string, len(channels))
+	for i, ch := range channels {
+		out[i] = string(ch)
+	}
+	return out
+}
@@ -0,0 +1,116 @@
+package payment_postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"event-booker/internal/domain"
+	"event-booker/internal/repository"
+
+	"github.com/wb-go/wbf/dbpg"
+	"github.com/wb-go/wbf/retry"
+)
+
+const paymentColumns = `id, booking_id, provider, intent_id, amount, currency, status, checkout_url, created_at, updated_at`
+
+type PaymentRepository struct {
+	db      *dbpg.DB
+	retries retry.Strategy
+}
+
+func NewPaymentRepository(db *dbpg.DB, retries retry.Strategy) *PaymentRepository {
+	return &PaymentRepository{db: db, retries: retries}
+}
+
+func (r *PaymentRepository) Create(ctx context.Context, tx *sql.Tx, p *domain.Payment) error {
+	query := `
+INSERT INTO payments (id, booking_id, provider, intent_id, amount, currency, status, checkout_url, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+`
+	args := []interface{}{p.ID, p.BookingID, p.Provider, p.IntentID, p.Amount, p.Currency, p.Status, p.CheckoutURL, p.CreatedAt, p.UpdatedAt}
+	if tx != nil {
+		_, err := tx.ExecContext(ctx, query, args...)
+		return err
+	}
+	_, err := r.db.ExecWithRetry(ctx, r.retries, query, args...)
+	return err
+}
+
+func (r *PaymentRepository) GetByID(ctx context.Context, tx *sql.Tx, id string) (*domain.Payment, error) {
+	query := `SELECT ` + paymentColumns + ` FROM payments WHERE id = $1`
+	if tx != nil {
+		return scanPayment(tx.QueryRowContext(ctx, query, id))
+	}
+	row, err := r.db.QueryRowWithRetry(ctx, r.retries, query, id)
+	if err != nil {
+		return nil, err
+	}
+	return scanPayment(row)
+}
+
+func (r *PaymentRepository) GetByIntentIDForUpdate(ctx context.Context, tx *sql.Tx, provider, intentID string) (*domain.Payment, error) {
+	query := `SELECT ` + paymentColumns + ` FROM payments WHERE provider = $1 AND intent_id = $2 FOR UPDATE`
+	return scanPayment(tx.QueryRowContext(ctx, query, provider, intentID))
+}
+
+// GetLatestByBookingID returns the most recent payment attempt for a booking.
+func (r *PaymentRepository) GetLatestByBookingID(ctx context.Context, tx *sql.Tx, bookingID string) (*domain.Payment, error) {
+	query := `SELECT ` + paymentColumns + ` FROM payments WHERE booking_id = $1 ORDER BY created_at DESC LIMIT 1`
+	if tx != nil {
+		return scanPayment(tx.QueryRowContext(ctx, query, bookingID))
+	}
+	row, err := r.db.QueryRowWithRetry(ctx, r.retries, query, bookingID)
+	if err != nil {
+		return nil, err
+	}
+	return scanPayment(row)
+}
+
+// ListAuthorized returns up to limit payments that still await capture, oldest
+// first.
+func (r *PaymentRepository) ListAuthorized(ctx context.Context, limit int) ([]*domain.Payment, error) {
+	query := `SELECT ` + paymentColumns + ` FROM payments WHERE status = 'authorized' ORDER BY updated_at LIMIT $1`
+	rows, err := r.db.QueryWithRetry(ctx, r.retries, query, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var payments []*domain.Payment
+	for rows.Next() {
+		p, err := scanPayment(rows)
+		if err != nil {
+			return nil, err
+		}
+		payments = append(payments, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return payments, nil
+}
+
+func (r *PaymentRepository) UpdateStatus(ctx context.Context, tx *sql.Tx, p *domain.Payment) error {
+	query := `UPDATE payments SET status = $1, updated_at = $2 WHERE id = $3`
+	if tx != nil {
+		_, err := tx.ExecContext(ctx, query, p.Status, p.UpdatedAt, p.ID)
+		return err
+	}
+	_, err := r.db.ExecWithRetry(ctx, r.retries, query, p.Status, p.UpdatedAt, p.ID)
+	return err
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanPayment(row scanner) (*domain.Payment, error) {
+	var p domain.Payment
+	err := row.Scan(&p.ID, &p.BookingID, &p.Provider, &p.IntentID, &p.Amount, &p.Currency, &p.Status, &p.CheckoutURL, &p.CreatedAt, &p.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, repository.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
@@ -0,0 +1,173 @@
+package promo_postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"event-booker/internal/domain"
+	"event-booker/internal/repository"
+
+	"github.com/wb-go/wbf/dbpg"
+	"github.com/wb-go/wbf/retry"
+)
+
+const promoColumns = `id, code, event_id, kind, value, currency, max_uses, max_uses_per_user, uses, valid_from, valid_until, active, created_at, updated_at`
+
+type PromoRepository struct {
+	db      *dbpg.DB
+	retries retry.Strategy
+}
+
+func NewPromoRepository(db *dbpg.DB, retries retry.Strategy) *PromoRepository {
+	return &PromoRepository{db: db, retries: retries}
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanPromoCode(row rowScanner) (*domain.PromoCode, error) {
+	var p domain.PromoCode
+	err := row.Scan(
+		&p.ID,
+		&p.Code,
+		&p.EventID,
+		&p.Kind,
+		&p.Value,
+		&p.Currency,
+		&p.MaxUses,
+		&p.MaxUsesPerUser,
+		&p.Uses,
+		&p.ValidFrom,
+		&p.ValidUntil,
+		&p.Active,
+		&p.CreatedAt,
+		&p.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, repository.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+func (r *PromoRepository) Create(ctx context.Context, p *domain.PromoCode) error {
+	query := `
+INSERT INTO promo_codes (` + promoColumns + `)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+`
+	_, err := r.db.ExecWithRetry(ctx, r.retries, query,
+		p.ID, p.Code, p.EventID, p.Kind, p.Value, p.Currency, p.MaxUses, p.MaxUsesPerUser, p.Uses,
+		p.ValidFrom, p.ValidUntil, p.Active, p.CreatedAt, p.UpdatedAt)
+	if repository.IsUniqueViolation(err) {
+		return repository.ErrAlreadyExists
+	}
+	return err
+}
+
+func (r *PromoRepository) GetByID(ctx context.Context, id string) (*domain.PromoCode, error) {
+	query := `SELECT ` + promoColumns + ` FROM promo_codes WHERE id = $1`
+	row, err := r.db.QueryRowWithRetry(ctx, r.retries, query, id)
+	if err != nil {
+		return nil, err
+	}
+	return scanPromoCode(row)
+}
+
+func (r *PromoRepository) GetByCodeForUpdate(ctx context.Context, tx *sql.Tx, code string) (*domain.PromoCode, error) {
+	query := `SELECT ` + promoColumns + ` FROM promo_codes WHERE code = $1 FOR UPDATE`
+	return scanPromoCode(tx.QueryRowContext(ctx, query, code))
+}
+
+func (r *PromoRepository) List(ctx context.Context) ([]*domain.PromoCode, error) {
+	query := `SELECT ` + promoColumns + ` FROM promo_codes ORDER BY created_at DESC`
+	rows, err := r.db.QueryWithRetry(ctx, r.retries, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	codes := []*domain.PromoCode{}
+	for rows.Next() {
+		p, err := scanPromoCode(rows)
+		if err != nil {
+			return nil, err
+		}
+		codes = append(codes, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+func (r *PromoRepository) Update(ctx context.Context, p *domain.PromoCode) error {
+	query := `
+UPDATE promo_codes
+SET active = $1, max_uses = $2, max_uses_per_user = $3, valid_until = $4, updated_at = $5
+WHERE id = $6
+`
+	_, err := r.db.ExecWithRetry(ctx, r.retries, query,
+		p.Active, p.MaxUses, p.MaxUsesPerUser, p.ValidUntil, p.UpdatedAt, p.ID)
+	return err
+}
+
+// Delete removes a promo code; it fails with ErrInUse once bookings have used
+// it, in which case the code should be deactivated instead.
+func (r *PromoRepository) Delete(ctx context.Context, id string) error {
+	res, err := r.db.ExecWithRetry(ctx, r.retries, `DELETE FROM promo_codes WHERE id = $1`, id)
+	if repository.IsForeignKeyViolation(err) {
+		return repository.ErrInUse
+	}
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+// CountUserUses counts the user's pending and confirmed bookings holding the
+// code.
+func (r *PromoRepository) CountUserUses(ctx context.Context, tx *sql.Tx, promoID, userID string) (int, error) {
+	query := `
+SELECT COUNT(*) FROM bookings
+WHERE promo_code_id = $1 AND user_id = $2 AND status IN ('pending', 'confirmed')
+`
+	var uses int
+	err := tx.QueryRowContext(ctx, query, promoID, userID).Scan(&uses)
+	return uses, err
+}
+
+// Redeem counts one more use of the code, failing with ErrLimitReached when
+// its total limit is exhausted.
+func (r *PromoRepository) Redeem(ctx context.Context, tx *sql.Tx, id string) error {
+	query := `
+UPDATE promo_codes SET uses = uses + 1, updated_at = NOW()
+WHERE id = $1 AND (max_uses = 0 OR uses < max_uses)
+`
+	res, err := tx.ExecContext(ctx, query, id)
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return repository.ErrLimitReached
+	}
+	return nil
+}
+
+func (r *PromoRepository) Release(ctx context.Context, tx *sql.Tx, id string) error {
+	query := `UPDATE promo_codes SET uses = uses - 1, updated_at = NOW() WHERE id = $1 AND uses > 0`
+	_, err := tx.ExecContext(ctx, query, id)
+	return err
+}
@@ -0,0 +1,90 @@
+package refund_postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"event-booker/internal/domain"
+
+	"github.com/wb-go/wbf/dbpg"
+	"github.com/wb-go/wbf/retry"
+)
+
+const refundColumns = `id, payment_id, booking_id, amount, currency, percent, reason, status, provider_refund_id, attempts, last_error, created_at, updated_at`
+
+type RefundRepository struct {
+	db      *dbpg.DB
+	retries retry.Strategy
+}
+
+func NewRefundRepository(db *dbpg.DB, retries retry.Strategy) *RefundRepository {
+	return &RefundRepository{db: db, retries: retries}
+}
+
+func (r *RefundRepository) Create(ctx context.Context, tx *sql.Tx, refund *domain.Refund) error {
+	query := `
+INSERT INTO refunds (id, payment_id, booking_id, amount, currency, percent, reason, status, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+`
+	args := []interface{}{refund.ID, refund.PaymentID, refund.BookingID, refund.Amount, refund.Currency,
+		refund.Percent, refund.Reason, refund.Status, refund.CreatedAt, refund.UpdatedAt}
+	if tx != nil {
+		_, err := tx.ExecContext(ctx, query, args...)
+		return err
+	}
+	_, err := r.db.ExecWithRetry(ctx, r.retries, query, args...)
+	return err
+}
+
+// ClaimDue marks up to limit refunds as processing and returns them. It picks
+// pending refunds of captured payments and processing refunds last claimed
+// before staleBefore, whose run presumably died. Claims are committed right
+// away, so no lock is held while the provider is called.
+func (r *RefundRepository) ClaimDue(ctx context.Context, limit int, staleBefore time.Time) ([]*domain.Refund, error) {
+	query := `
+UPDATE refunds SET status = 'processing', attempts = attempts + 1, updated_at = NOW()
+WHERE id IN (
+    SELECT r.id FROM refunds r
+    JOIN payments p ON p.id = r.payment_id
+    WHERE p.status = 'succeeded'
+      AND (r.status = 'pending' OR (r.status = 'processing' AND r.updated_at < $2))
+    ORDER BY r.created_at
+    LIMIT $1
+    FOR UPDATE OF r SKIP LOCKED
+)
+RETURNING ` + refundColumns
+	rows, err := r.db.Master.QueryContext(ctx, query, limit, staleBefore)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var refunds []*domain.Refund
+	for rows.Next() {
+		var refund domain.Refund
+		if err := rows.Scan(&refund.ID, &refund.PaymentID, &refund.BookingID, &refund.Amount, &refund.Currency,
+			&refund.Percent, &refund.Reason, &refund.Status, &refund.ProviderRefundID, &refund.Attempts,
+			&refund.LastError, &refund.CreatedAt, &refund.UpdatedAt); err != nil {
+			return nil, err
+		}
+		refunds = append(refunds, &refund)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return refunds, nil
+}
+
+func (r *RefundRepository) Update(ctx context.Context, tx *sql.Tx, refund *domain.Refund) error {
+	query := `
+UPDATE refunds SET status = $1, provider_refund_id = $2, attempts = $3, last_error = $4, updated_at = $5
+WHERE id = $6
+`
+	args := []interface{}{refund.Status, refund.ProviderRefundID, refund.Attempts, refund.LastError, refund.UpdatedAt, refund.ID}
+	if tx != nil {
+		_, err := tx.ExecContext(ctx, query, args...)
+		return err
+	}
+	_, err := r.db.ExecWithRetry(ctx, r.retries, query, args...)
+	return err
+}
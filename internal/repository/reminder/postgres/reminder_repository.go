@@ -0,0 +1,122 @@
+package reminder_postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"event-booker/internal/domain"
+
+	"github.com/wb-go/wbf/dbpg"
+	"github.com/wb-go/wbf/retry"
+)
+
+type ReminderRepository struct {
+	db      *dbpg.DB
+	retries retry.Strategy
+}
+
+func NewReminderRepository(db *dbpg.DB, retries retry.Strategy) *ReminderRepository {
+	return &ReminderRepository{db: db, retries: retries}
+}
+
+// ClaimDue records up to limit reminders that are due lead before their event
+// and returns them. A reminder is due for confirmed bookings of active events
+// that have not started yet, provided the booking was confirmed before the
+// reminder time. Bookings already reminded for lead are skipped, and concurrent
+// callers never claim the same reminder.
+func (r *ReminderRepository) ClaimDue(ctx context.Context, tx *sql.Tx, lead time.Duration, now time.Time, limit int) ([]*domain.Reminder, error) {
+	query := `
+WITH due AS (
+    SELECT b.id
+    FROM bookings b
+    JOIN events e ON e.id = b.event_id
+    WHERE b.status = 'confirmed'
+      AND e.status = 'active'
+      AND e.date > $2
+      AND e.date - $1::bigint * interval '1 second' <= $2
+      AND b.confirmed_at < e.date - $1::bigint * interval '1 second'
+      AND NOT EXISTS (SELECT 1 FROM event_reminders r WHERE r.booking_id = b.id AND r.kind = 'event_reminder' AND r.lead_seconds = $1::bigint)
+    ORDER BY e.date
+    LIMIT $3
+), claimed AS (
+    INSERT INTO event_reminders (booking_id, kind, lead_seconds, sent_at)
+    SELECT id, 'event_reminder', $1::bigint, $2::timestamptz FROM due
+    ON CONFLICT (booking_id, kind, lead_seconds) DO NOTHING
+    RETURNING booking_id
+)
+SELECT b.id, b.event_id, b.user_id, b.quantity, b.status, b.created_at, b.expires_at, b.confirmed_at,
+       e.name, e.date
+FROM claimed c
+JOIN bookings b ON b.id = c.booking_id
+JOIN events e ON e.id = b.event_id
+`
+	return r.claim(ctx, tx, query, lead, now, limit)
+}
+
+// ClaimPendingPayments records up to limit payment reminders for pending
+// bookings of paid events that expire within lead and returns them. Each
+// booking is reminded at most once.
+func (r *ReminderRepository) ClaimPendingPayments(ctx context.Context, tx *sql.Tx, lead time.Duration, now time.Time, limit int) ([]*domain.Reminder, error) {
+	query := `
+WITH due AS (
+    SELECT b.id
+    FROM bookings b
+    JOIN events e ON e.id = b.event_id
+    WHERE b.status = 'pending'
+      AND e.requires_payment
+      AND b.expires_at > $2
+      AND b.expires_at - $1::bigint * interval '1 second' <= $2
+      AND NOT EXISTS (SELECT 1 FROM event_reminders r WHERE r.booking_id = b.id AND r.kind = 'payment_pending')
+    ORDER BY b.expires_at
+    LIMIT $3
+), claimed AS (
+    INSERT INTO event_reminders (booking_id, kind, lead_seconds, sent_at)
+    SELECT id, 'payment_pending', $1::bigint, $2::timestamptz FROM due
+    ON CONFLICT (booking_id, kind, lead_seconds) DO NOTHING
+    RETURNING booking_id
+)
+SELECT b.id, b.event_id, b.user_id, b.quantity, b.status, b.created_at, b.expires_at, b.confirmed_at,
+       e.name, e.date
+FROM claimed c
+JOIN bookings b ON b.id = c.booking_id
+JOIN events e ON e.id = b.event_id
+`
+	return r.claim(ctx, tx, query, lead, now, limit)
+}
+
+// ResetForEvent forgets the event reminders sent for bookings of an event, so
+// that they are sent again ahead of its new date after a reschedule.
+func (r *ReminderRepository) ResetForEvent(ctx context.Context, tx *sql.Tx, eventID string) error {
+	query := `
+DELETE FROM event_reminders
+WHERE kind = 'event_reminder'
+  AND booking_id IN (SELECT id FROM bookings WHERE event_id = $1)
+`
+	_, err := tx.ExecContext(ctx, query, eventID)
+	return err
+}
+
+func (r *ReminderRepository) claim(ctx context.Context, tx *sql.Tx, query string, lead time.Duration, now time.Time, limit int) ([]*domain.Reminder, error) {
+	rows, err := tx.QueryContext(ctx, query, int64(lead/time.Second), now, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var reminders []*domain.Reminder
+	for rows.Next() {
+		b := &domain.Booking{}
+		e := &domain.Event{}
+		err := rows.Scan(&b.ID, &b.EventID, &b.UserID, &b.Quantity, &b.Status, &b.CreatedAt, &b.ExpiresAt, &b.ConfirmedAt,
+			&e.Name, &e.Date)
+		if err != nil {
+			return nil, err
+		}
+		e.ID = b.EventID
+		reminders = append(reminders, &domain.Reminder{Booking: b, Event: e, Lead: lead})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return reminders, nil
+}
@@ -0,0 +1,233 @@
+package seat_postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"event-booker/internal/domain"
+	"event-booker/internal/repository"
+
+	"github.com/lib/pq"
+	"github.com/wb-go/wbf/dbpg"
+	"github.com/wb-go/wbf/retry"
+)
+
+type SeatRepository struct {
+	db      *dbpg.DB
+	retries retry.Strategy
+}
+
+func NewSeatRepository(db *dbpg.DB, retries retry.Strategy) *SeatRepository {
+	return &SeatRepository{db: db, retries: retries}
+}
+
+func (r *SeatRepository) CreateLayout(ctx context.Context, tx *sql.Tx, layout *domain.SeatLayout) error {
+	query := `INSERT INTO seat_layouts (id, name, created_at) VALUES ($1, $2, $3)`
+	if _, err := tx.ExecContext(ctx, query, layout.ID, layout.Name, layout.CreatedAt); err != nil {
+		return err
+	}
+	seatQuery := `
+INSERT INTO layout_seats (id, layout_id, section, row_label, number, accessible)
+VALUES ($1, $2, $3, $4, $5, $6)
+`
+	stmt, err := tx.PrepareContext(ctx, seatQuery)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+	for _, seat := range layout.Seats {
+		if _, err := stmt.ExecContext(ctx, seat.ID, layout.ID, seat.Section, seat.Row, seat.Number, seat.Accessible); err != nil {
+			if repository.IsUniqueViolation(err) {
+				return repository.ErrAlreadyExists
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// GetLayout returns the layout with its seats ordered by section, row and
+// number.
+func (r *SeatRepository) GetLayout(ctx context.Context, id string) (*domain.SeatLayout, error) {
+	query := `SELECT id, name, created_at FROM seat_layouts WHERE id = $1`
+	row, err := r.db.QueryRowWithRetry(ctx, r.retries, query, id)
+	if err != nil {
+		return nil, err
+	}
+	var layout domain.SeatLayout
+	err = row.Scan(&layout.ID, &layout.Name, &layout.CreatedAt)
+	if err == sql.ErrNoRows {
+		return nil, repository.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	seatsQuery := `
+SELECT id, section, row_label, number, accessible
+FROM layout_seats WHERE layout_id = $1
+ORDER BY section, row_label, number
+`
+	rows, err := r.db.QueryWithRetry(ctx, r.retries, seatsQuery, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var seat domain.Seat
+		if err := rows.Scan(&seat.ID, &seat.Section, &seat.Row, &seat.Number, &seat.Accessible); err != nil {
+			return nil, err
+		}
+		layout.Seats = append(layout.Seats, &seat)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return &layout, nil
+}
+
+// ListLayouts returns all layouts without their seats.
+func (r *SeatRepository) ListLayouts(ctx context.Context) ([]*domain.SeatLayout, error) {
+	query := `SELECT id, name, created_at FROM seat_layouts ORDER BY name, id`
+	rows, err := r.db.QueryWithRetry(ctx, r.retries, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var layouts []*domain.SeatLayout
+	for rows.Next() {
+		var layout domain.SeatLayout
+		if err := rows.Scan(&layout.ID, &layout.Name, &layout.CreatedAt); err != nil {
+			return nil, err
+		}
+		layouts = append(layouts, &layout)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return layouts, nil
+}
+
+// CreateInventory adds every seat of the layout to the event as available and
+// returns how many seats were added.
+func (r *SeatRepository) CreateInventory(ctx context.Context, tx *sql.Tx, eventID, layoutID string) (int, error) {
+	query := `
+INSERT INTO event_seats (event_id, seat_id)
+SELECT $1, id FROM layout_seats WHERE layout_id = $2
+`
+	res, err := tx.ExecContext(ctx, query, eventID, layoutID)
+	if err != nil {
+		return 0, err
+	}
+	n, err := res.RowsAffected()
+	return int(n), err
+}
+
+// CountLayoutSeats returns the number of seats in the layout, or
+// repository.ErrNotFound if there is no such layout.
+func (r *SeatRepository) CountLayoutSeats(ctx context.Context, layoutID string) (int, error) {
+	query := `
+SELECT COUNT(s.id)
+FROM seat_layouts l LEFT JOIN layout_seats s ON s.layout_id = l.id
+WHERE l.id = $1
+GROUP BY l.id
+`
+	row, err := r.db.QueryRowWithRetry(ctx, r.retries, query, layoutID)
+	if err != nil {
+		return 0, err
+	}
+	var count int
+	err = row.Scan(&count)
+	if err == sql.ErrNoRows {
+		return 0, repository.ErrNotFound
+	}
+	return count, err
+}
+
+// GetSeatMap returns the event's seats with their availability, ordered by
+// section, row and number.
+func (r *SeatRepository) GetSeatMap(ctx context.Context, eventID string) ([]*domain.EventSeat, error) {
+	query := `
+SELECT s.id, s.section, s.row_label, s.number, s.accessible,
+       CASE
+           WHEN es.booking_id IS NULL THEN 'available'
+           WHEN b.status = 'confirmed' THEN 'booked'
+           ELSE 'held'
+       END
+FROM event_seats es
+JOIN layout_seats s ON s.id = es.seat_id
+LEFT JOIN bookings b ON b.id = es.booking_id
+WHERE es.event_id = $1
+ORDER BY s.section, s.row_label, s.number
+`
+	rows, err := r.db.QueryWithRetry(ctx, r.retries, query, eventID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var seats []*domain.EventSeat
+	for rows.Next() {
+		seat := &domain.EventSeat{Seat: &domain.Seat{}}
+		if err := rows.Scan(&seat.ID, &seat.Section, &seat.Row, &seat.Number, &seat.Accessible, &seat.Status); err != nil {
+			return nil, err
+		}
+		seats = append(seats, seat)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return seats, nil
+}
+
+// HoldSeats assigns the seats to the booking. The seat rows are locked in a
+// fixed order so that concurrent bookings of overlapping seats serialize
+// instead of deadlocking; it returns repository.ErrNotFound if a seat is not
+// part of the event and repository.ErrSeatUnavailable if one is taken.
+func (r *SeatRepository) HoldSeats(ctx context.Context, tx *sql.Tx, eventID, bookingID string, seatIDs []string) error {
+	lockQuery := `
+SELECT seat_id, booking_id IS NOT NULL
+FROM event_seats
+WHERE event_id = $1 AND seat_id = ANY($2)
+ORDER BY seat_id
+FOR UPDATE
+`
+	found, taken, err := lockSeats(ctx, tx, lockQuery, eventID, seatIDs)
+	if err != nil {
+		return err
+	}
+	if found != len(seatIDs) {
+		return repository.ErrNotFound
+	}
+	if taken {
+		return repository.ErrSeatUnavailable
+	}
+	updateQuery := `UPDATE event_seats SET booking_id = $3 WHERE event_id = $1 AND seat_id = ANY($2)`
+	_, err = tx.ExecContext(ctx, updateQuery, eventID, pq.Array(seatIDs), bookingID)
+	return err
+}
+
+// ReleaseSeats frees every seat held by the booking.
+func (r *SeatRepository) ReleaseSeats(ctx context.Context, tx *sql.Tx, bookingID string) error {
+	query := `UPDATE event_seats SET booking_id = NULL WHERE booking_id = $1`
+	_, err := tx.ExecContext(ctx, query, bookingID)
+	return err
+}
+
+// lockSeats runs the locking query and drains its rows before returning, since
+// the transaction cannot run the next statement while they are open.
+func lockSeats(ctx context.Context, tx *sql.Tx, query, eventID string, seatIDs []string) (found int, taken bool, err error) {
+	rows, err := tx.QueryContext(ctx, query, eventID, pq.Array(seatIDs))
+	if err != nil {
+		return 0, false, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var seatID string
+		var held bool
+		if err := rows.Scan(&seatID, &held); err != nil {
+			return 0, false, err
+		}
+		found++
+		taken = taken || held
+	}
+	return found, taken, rows.Err()
+}
@@ -11,6 +11,11 @@ import (
 	"github.com/wb-go/wbf/retry"
 )
 
+// userColumns selects a user together with their notification preferences;
+// users who never changed them get the defaults.
+const userColumns = `u.id, u.email, COALESCE(u.telegram, ''), u.password_hash, u.role, u.locale, u.timezone, u.created_at,
+       COALESCE(p.email_enabled, true), COALESCE(p.telegram_enabled, true), COALESCE(p.webhook_enabled, true), COALESCE(p.reminders_enabled, true), p.updated_at`
+
 type UserRepository struct {
 	db      *dbpg.DB
 	retries retry.Strategy
@@ -22,30 +27,67 @@ func NewUserRepository(db *dbpg.DB, retries retry.Strategy) *UserRepository {
 
 func (r *UserRepository) Create(ctx context.Context, user *domain.User) error {
 	query := `
-INSERT INTO users (id, email, telegram, role, created_at)
-VALUES ($1, $2, $3, $4, $5)
+INSERT INTO users (id, email, telegram, password_hash, role, locale, timezone, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 `
 	_, err := r.db.ExecWithRetry(ctx, r.retries, query,
-		user.ID, user.Email, user.Telegram, user.Role, user.CreatedAt)
+		user.ID, user.Email, user.Telegram, user.PasswordHash, user.Role, user.Locale, user.Timezone, user.CreatedAt)
+	if repository.IsUniqueViolation(err) {
+		return repository.ErrAlreadyExists
+	}
 	return err
 }
 
 func (r *UserRepository) GetByID(ctx context.Context, id string) (*domain.User, error) {
 	query := `
-SELECT id, email, telegram, role, created_at
-FROM users WHERE id = $1
+SELECT ` + userColumns + `
+FROM users u
+LEFT JOIN notification_preferences p ON p.user_id = u.id
+WHERE u.id = $1
 `
-	row, err := r.db.QueryRowWithRetry(ctx, r.retries, query, id)
+	return r.getOne(ctx, query, id)
+}
+
+func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*domain.User, error) {
+	query := `
+SELECT ` + userColumns + `
+FROM users u
+LEFT JOIN notification_preferences p ON p.user_id = u.id
+WHERE u.email = $1
+`
+	return r.getOne(ctx, query, email)
+}
+
+func (r *UserRepository) getOne(ctx context.Context, query string, arg string) (*domain.User, error) {
+	row, err := r.db.QueryRowWithRetry(ctx, r.retries, query, arg)
 	if err != nil {
 		return nil, err
 	}
 	var user domain.User
-	err = row.Scan(&user.ID, &user.Email, &user.Telegram, &user.Role, &user.CreatedAt)
+	err = row.Scan(&user.ID, &user.Email, &user.Telegram, &user.PasswordHash, &user.Role, &user.Locale, &user.Timezone, &user.CreatedAt,
+		&user.Preferences.Email, &user.Preferences.Telegram, &user.Preferences.Webhook, &user.Preferences.Reminders, &user.Preferences.UpdatedAt)
 	if err == sql.ErrNoRows {
 		return nil, repository.ErrNotFound
 	}
 	if err != nil {
 		return nil, err
 	}
+	user.Preferences.UserID = user.ID
 	return &user, nil
 }
+
+func (r *UserRepository) SavePreferences(ctx context.Context, prefs *domain.NotificationPreferences) error {
+	query := `
+INSERT INTO notification_preferences (user_id, email_enabled, telegram_enabled, webhook_enabled, reminders_enabled, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (user_id) DO UPDATE SET
+    email_enabled = EXCLUDED.email_enabled,
+    telegram_enabled = EXCLUDED.telegram_enabled,
+    webhook_enabled = EXCLUDED.webhook_enabled,
+    reminders_enabled = EXCLUDED.reminders_enabled,
+    updated_at = EXCLUDED.updated_at
+`
+	_, err := r.db.ExecWithRetry(ctx, r.retries, query,
+		prefs.UserID, prefs.Email, prefs.Telegram, prefs.Webhook, prefs.Reminders, prefs.UpdatedAt)
+	return err
+}
@@ -0,0 +1,140 @@
+package venue_postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"event-booker/internal/domain"
+	"event-booker/internal/repository"
+
+	"github.com/wb-go/wbf/dbpg"
+	"github.com/wb-go/wbf/retry"
+)
+
+const venueColumns = `id, name, address, timezone, capacity, latitude, longitude, created_at, updated_at`
+
+type VenueRepository struct {
+	db      *dbpg.DB
+	retries retry.Strategy
+}
+
+func NewVenueRepository(db *dbpg.DB, retries retry.Strategy) *VenueRepository {
+	return &VenueRepository{db: db, retries: retries}
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanVenue(row rowScanner) (*domain.Venue, error) {
+	var v domain.Venue
+	err := row.Scan(
+		&v.ID,
+		&v.Name,
+		&v.Address,
+		&v.Timezone,
+		&v.Capacity,
+		&v.Latitude,
+		&v.Longitude,
+		&v.CreatedAt,
+		&v.UpdatedAt,
+	)
+	if err == sql.ErrNoRows {
+		return nil, repository.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+func (r *VenueRepository) Create(ctx context.Context, v *domain.Venue) error {
+	query := `
+INSERT INTO venues (` + venueColumns + `)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+`
+	_, err := r.db.ExecWithRetry(ctx, r.retries, query,
+		v.ID, v.Name, v.Address, v.Timezone, v.Capacity, v.Latitude, v.Longitude, v.CreatedAt, v.UpdatedAt)
+	return err
+}
+
+func (r *VenueRepository) GetByID(ctx context.Context, id string) (*domain.Venue, error) {
+	query := `SELECT ` + venueColumns + ` FROM venues WHERE id = $1`
+	row, err := r.db.QueryRowWithRetry(ctx, r.retries, query, id)
+	if err != nil {
+		return nil, err
+	}
+	return scanVenue(row)
+}
+
+func (r *VenueRepository) GetForUpdate(ctx context.Context, tx *sql.Tx, id string) (*domain.Venue, error) {
+	query := `SELECT ` + venueColumns + ` FROM venues WHERE id = $1 FOR UPDATE`
+	return scanVenue(tx.QueryRowContext(ctx, query, id))
+}
+
+// GetForShare locks the venue against concurrent capacity changes while an
+// event held there is created or resized.
+func (r *VenueRepository) GetForShare(ctx context.Context, tx *sql.Tx, id string) (*domain.Venue, error) {
+	query := `SELECT ` + venueColumns + ` FROM venues WHERE id = $1 FOR SHARE`
+	return scanVenue(tx.QueryRowContext(ctx, query, id))
+}
+
+func (r *VenueRepository) List(ctx context.Context) ([]*domain.Venue, error) {
+	query := `SELECT ` + venueColumns + ` FROM venues ORDER BY name, id`
+	rows, err := r.db.QueryWithRetry(ctx, r.retries, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	venues := []*domain.Venue{}
+	for rows.Next() {
+		v, err := scanVenue(rows)
+		if err != nil {
+			return nil, err
+		}
+		venues = append(venues, v)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return venues, nil
+}
+
+func (r *VenueRepository) Update(ctx context.Context, tx *sql.Tx, v *domain.Venue) error {
+	query := `
+UPDATE venues
+SET name = $1, address = $2, timezone = $3, capacity = $4, latitude = $5, longitude = $6, updated_at = $7
+WHERE id = $8
+`
+	_, err := tx.ExecContext(ctx, query,
+		v.Name, v.Address, v.Timezone, v.Capacity, v.Latitude, v.Longitude, v.UpdatedAt, v.ID)
+	return err
+}
+
+// MaxEventSeats returns the largest capacity among the active events held at
+// the venue, or zero when there are none.
+func (r *VenueRepository) MaxEventSeats(ctx context.Context, tx *sql.Tx, venueID string) (int, error) {
+	query := `SELECT COALESCE(MAX(total_seats), 0) FROM events WHERE venue_id = $1 AND status = 'active'`
+	var seats int
+	err := tx.QueryRowContext(ctx, query, venueID).Scan(&seats)
+	return seats, err
+}
+
+// Delete removes a venue; it fails with ErrInUse while events reference it.
+func (r *VenueRepository) Delete(ctx context.Context, id string) error {
+	res, err := r.db.ExecWithRetry(ctx, r.retries, `DELETE FROM venues WHERE id = $1`, id)
+	if repository.IsForeignKeyViolation(err) {
+		return repository.ErrInUse
+	}
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package waitlist_postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"event-booker/internal/domain"
+	"event-booker/internal/repository"
+
+	"github.com/wb-go/wbf/dbpg"
+	"github.com/wb-go/wbf/retry"
+)
+
+type WaitlistRepository struct {
+	db      *dbpg.DB
+	retries retry.Strategy
+}
+
+func NewWaitlistRepository(db *dbpg.DB, retries retry.Strategy) *WaitlistRepository {
+	return &WaitlistRepository{db: db, retries: retries}
+}
+
+func (r *WaitlistRepository) Create(ctx context.Context, entry *domain.WaitlistEntry) error {
+	query := `
+INSERT INTO waitlist_entries (id, event_id, user_id, quantity, status, created_at, tier_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+`
+	_, err := r.db.ExecWithRetry(ctx, r.retries, query,
+		entry.ID, entry.EventID, entry.UserID, entry.Quantity, entry.Status, entry.CreatedAt, entry.TierID)
+	if repository.IsUniqueViolation(err) {
+		return repository.ErrAlreadyExists
+	}
+	return err
+}
+
+func (r *WaitlistRepository) GetByEventID(ctx context.Context, eventID string) ([]*domain.WaitlistEntry, error) {
+	query := `
+SELECT id, event_id, user_id, quantity, status, booking_id, created_at, promoted_at, tier_id
+FROM waitlist_entries WHERE event_id = $1
+ORDER BY created_at ASC
+`
+	rows, err := r.db.QueryWithRetry(ctx, r.retries, query, eventID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanEntries(rows)
+}
+
+func (r *WaitlistRepository) GetWaitingForUpdate(ctx context.Context, tx *sql.Tx, eventID string) ([]*domain.WaitlistEntry, error) {
+	query := `
+SELECT id, event_id, user_id, quantity, status, booking_id, created_at, promoted_at, tier_id
+FROM waitlist_entries WHERE event_id = $1 AND status = 'waiting'
+ORDER BY created_at ASC
+FOR UPDATE
+`
+	rows, err := tx.QueryContext(ctx, query, eventID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanEntries(rows)
+}
+
+func (r *WaitlistRepository) Update(ctx context.Context, tx *sql.Tx, entry *domain.WaitlistEntry) error {
+	query := `
+UPDATE waitlist_entries SET status = $1, booking_id = $2, promoted_at = $3 WHERE id = $4
+`
+	if tx != nil {
+		_, err := tx.ExecContext(ctx, query, entry.Status, entry.BookingID, entry.PromotedAt, entry.ID)
+		return err
+	}
+	_, err := r.db.ExecWithRetry(ctx, r.retries, query, entry.Status, entry.BookingID, entry.PromotedAt, entry.ID)
+	return err
+}
+
+func (r *WaitlistRepository) CancelByEventID(ctx context.Context, tx *sql.Tx, eventID string) error {
+	query := `UPDATE waitlist_entries SET status = 'cancelled' WHERE event_id = $1 AND status = 'waiting'`
+	if tx != nil {
+		_, err := tx.ExecContext(ctx, query, eventID)
+		return err
+	}
+	_, err := r.db.ExecWithRetry(ctx, r.retries, query, eventID)
+	return err
+}
+
+func scanEntries(rows *sql.Rows) ([]*domain.WaitlistEntry, error) {
+	var entries []*domain.WaitlistEntry
+	for rows.Next() {
+		var e domain.WaitlistEntry
+		err := rows.Scan(&e.ID, &e.EventID, &e.UserID, &e.Quantity, &e.Status, &e.BookingID, &e.CreatedAt, &e.PromotedAt, &e.TierID)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, &e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
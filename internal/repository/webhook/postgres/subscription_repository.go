@@ -0,0 +1,121 @@
+package webhook_postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"event-booker/internal/domain"
+	"event-booker/internal/repository"
+
+	"github.com/lib/pq"
+)
+
+const subscriptionColumns = `id, url, secret, event_types, active, created_at, updated_at`
+
+func (r *WebhookRepository) CreateSubscription(ctx context.Context, sub *domain.WebhookSubscription) error {
+	query := `
+INSERT INTO webhook_subscriptions (id, url, secret, event_types, active, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+`
+	_, err := r.db.ExecWithRetry(ctx, r.retries, query,
+		sub.ID, sub.URL, sub.Secret, pq.Array(eventTypeStrings(sub.EventTypes)), sub.Active, sub.CreatedAt, sub.UpdatedAt)
+	return err
+}
+
+func (r *WebhookRepository) GetSubscription(ctx context.Context, id string) (*domain.WebhookSubscription, error) {
+	query := `SELECT ` + subscriptionColumns + ` FROM webhook_subscriptions WHERE id = $1`
+	row, err := r.db.QueryRowWithRetry(ctx, r.retries, query, id)
+	if err != nil {
+		return nil, err
+	}
+	sub, err := scanSubscription(row)
+	if err == sql.ErrNoRows {
+		return nil, repository.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return sub, nil
+}
+
+func (r *WebhookRepository) ListSubscriptions(ctx context.Context) ([]*domain.WebhookSubscription, error) {
+	query := `SELECT ` + subscriptionColumns + ` FROM webhook_subscriptions ORDER BY created_at`
+	rows, err := r.db.QueryWithRetry(ctx, r.retries, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var subs []*domain.WebhookSubscription
+	for rows.Next() {
+		sub, err := scanSubscription(rows)
+		if err != nil {
+			return nil, err
+		}
+		subs = append(subs, sub)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return subs, nil
+}
+
+func (r *WebhookRepository) UpdateSubscription(ctx context.Context, sub *domain.WebhookSubscription) error {
+	query := `
+UPDATE webhook_subscriptions
+SET url = $2, event_types = $3, active = $4, updated_at = $5
+WHERE id = $1
+`
+	res, err := r.db.ExecWithRetry(ctx, r.retries, query, sub.ID, sub.URL, pq.Array(eventTypeStrings(sub.EventTypes)), sub.Active, sub.UpdatedAt)
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+func (r *WebhookRepository) DeleteSubscription(ctx context.Context, id string) error {
+	query := `DELETE FROM webhook_subscriptions WHERE id = $1`
+	res, err := r.db.ExecWithRetry(ctx, r.retries, query, id)
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanSubscription(row scanner) (*domain.WebhookSubscription, error) {
+	var sub domain.WebhookSubscription
+	var eventTypes []string
+	if err := row.Scan(&sub.ID, &sub.URL, &sub.Secret, pq.Array(&eventTypes), &sub.Active, &sub.CreatedAt, &sub.UpdatedAt); err != nil {
+		return nil, err
+	}
+	sub.EventTypes = make([]domain.DomainEventType, len(eventTypes))
+	for i, t := range eventTypes {
+		sub.EventTypes[i] = domain.DomainEventType(t)
+	}
+	return &sub, nil
+}
+
+func eventTypeStrings(types []domain.DomainEventType) []string {
+	out := make([]string, len(types))
+	for i, t := range types {
+		out[i] = string(t)
+	}
+	return out
+}
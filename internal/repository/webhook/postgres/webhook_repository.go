@@ -0,0 +1,316 @@
+package webhook_postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"event-booker/internal/domain"
+	"event-booker/internal/repository"
+
+	"github.com/wb-go/wbf/dbpg"
+	"github.com/wb-go/wbf/retry"
+)
+
+const deliveryColumns = `d.id, d.endpoint_id, d.subscription_id, d.kind, d.payload, d.status, d.attempts, d.next_attempt_at, d.response_status,
+       COALESCE(d.last_error, ''), d.created_at, d.updated_at, d.delivered_at`
+
+type WebhookRepository struct {
+	db      *dbpg.DB
+	retries retry.Strategy
+}
+
+func NewWebhookRepository(db *dbpg.DB, retries retry.Strategy) *WebhookRepository {
+	return &WebhookRepository{db: db, retries: retries}
+}
+
+const endpointColumns = `id, user_id, organizer_id, url, secret, created_at`
+
+// CreateEndpoint stores an endpoint. It returns repository.ErrNotFound when
+// the owning user or organizer does not exist.
+func (r *WebhookRepository) CreateEndpoint(ctx context.Context, endpoint *domain.WebhookEndpoint) error {
+	query := `
+INSERT INTO webhook_endpoints (` + endpointColumns + `)
+VALUES ($1, $2, $3, $4, $5, $6)
+`
+	_, err := r.db.ExecWithRetry(ctx, r.retries, query,
+		endpoint.ID, endpoint.UserID, endpoint.OrganizerID, endpoint.URL, endpoint.Secret, endpoint.CreatedAt)
+	if repository.IsForeignKeyViolation(err) {
+		return repository.ErrNotFound
+	}
+	return err
+}
+
+func (r *WebhookRepository) GetEndpoint(ctx context.Context, id string) (*domain.WebhookEndpoint, error) {
+	query := `SELECT ` + endpointColumns + ` FROM webhook_endpoints WHERE id = $1`
+	row, err := r.db.QueryRowWithRetry(ctx, r.retries, query, id)
+	if err != nil {
+		return nil, err
+	}
+	var e domain.WebhookEndpoint
+	err = row.Scan(endpointFields(&e)...)
+	if err == sql.ErrNoRows {
+		return nil, repository.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+func (r *WebhookRepository) ListEndpoints(ctx context.Context, userID string) ([]*domain.WebhookEndpoint, error) {
+	return r.listEndpoints(ctx, "user_id", userID)
+}
+
+func (r *WebhookRepository) ListOrganizerEndpoints(ctx context.Context, organizerID string) ([]*domain.WebhookEndpoint, error) {
+	return r.listEndpoints(ctx, "organizer_id", organizerID)
+}
+
+func (r *WebhookRepository) listEndpoints(ctx context.Context, column, id string) ([]*domain.WebhookEndpoint, error) {
+	query := `
+SELECT ` + endpointColumns + `
+FROM webhook_endpoints WHERE ` + column + ` = $1 ORDER BY created_at
+`
+	rows, err := r.db.QueryWithRetry(ctx, r.retries, query, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var endpoints []*domain.WebhookEndpoint
+	for rows.Next() {
+		var e domain.WebhookEndpoint
+		if err := rows.Scan(endpointFields(&e)...); err != nil {
+			return nil, err
+		}
+		endpoints = append(endpoints, &e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return endpoints, nil
+}
+
+func (r *WebhookRepository) HasEndpoints(ctx context.Context, userID string) (bool, error) {
+	query := `SELECT EXISTS (SELECT 1 FROM webhook_endpoints WHERE user_id = $1)`
+	row, err := r.db.QueryRowWithRetry(ctx, r.retries, query, userID)
+	if err != nil {
+		return false, err
+	}
+	var exists bool
+	err = row.Scan(&exists)
+	return exists, err
+}
+
+func (r *WebhookRepository) DeleteEndpoint(ctx context.Context, id string) error {
+	query := `DELETE FROM webhook_endpoints WHERE id = $1`
+	res, err := r.db.ExecWithRetry(ctx, r.retries, query, id)
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return repository.ErrNotFound
+	}
+	return nil
+}
+
+// EnqueueForUser creates a pending delivery of payload to every endpoint of
+// the user and returns how many were created.
+func (r *WebhookRepository) EnqueueForUser(ctx context.Context, userID string, kind domain.NotificationKind, payload []byte) (int64, error) {
+	query := `
+INSERT INTO webhook_deliveries (id, endpoint_id, kind, payload)
+SELECT gen_random_uuid()::text, id, $2, $3 FROM webhook_endpoints WHERE user_id = $1
+`
+	res, err := r.db.ExecWithRetry(ctx, r.retries, query, userID, kind, payload)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+// EnqueueForOrganizer creates a pending delivery of a domain event to every
+// endpoint of the organizer, inside tx like EnqueueForSubscriptions.
+func (r *WebhookRepository) EnqueueForOrganizer(ctx context.Context, tx *sql.Tx, organizerID string, eventType domain.DomainEventType, payload []byte) error {
+	query := `
+INSERT INTO webhook_deliveries (id, endpoint_id, kind, payload)
+SELECT gen_random_uuid()::text, id, $2, $3 FROM webhook_endpoints WHERE organizer_id = $1
+`
+	_, err := tx.ExecContext(ctx, query, organizerID, eventType, payload)
+	return err
+}
+
+// EnqueueForSubscriptions creates a pending delivery of payload to every active
+// subscription to eventType, inside tx so that it commits with the change it
+// describes.
+func (r *WebhookRepository) EnqueueForSubscriptions(ctx context.Context, tx *sql.Tx, eventType domain.DomainEventType, payload []byte) error {
+	query := `
+INSERT INTO webhook_deliveries (id, subscription_id, kind, payload)
+SELECT gen_random_uuid()::text, id, $1::text, $2 FROM webhook_subscriptions
+WHERE active AND (cardinality(event_types) = 0 OR $1::text = ANY(event_types))
+`
+	_, err := tx.ExecContext(ctx, query, eventType, payload)
+	return err
+}
+
+// CreateSubscriptionDelivery creates a pending delivery to one subscription
+// and returns it ready to send.
+func (r *WebhookRepository) CreateSubscriptionDelivery(ctx context.Context, subscriptionID string, eventType domain.DomainEventType, payload []byte) (*domain.WebhookDelivery, error) {
+	query := `
+WITH created AS (
+    INSERT INTO webhook_deliveries (id, subscription_id, kind, payload)
+    VALUES (gen_random_uuid()::text, $1, $2, $3)
+    RETURNING *
+)
+SELECT ` + deliveryColumns + `, s.url, s.secret
+FROM created d
+JOIN webhook_subscriptions s ON s.id = d.subscription_id
+`
+	row, err := r.db.QueryRowWithRetry(ctx, r.retries, query, subscriptionID, eventType, payload)
+	if err != nil {
+		return nil, err
+	}
+	var d domain.WebhookDelivery
+	if err := row.Scan(append(deliveryFields(&d), &d.URL, &d.Secret)...); err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+func (r *WebhookRepository) GetDelivery(ctx context.Context, id string) (*domain.WebhookDelivery, error) {
+	query := `SELECT ` + deliveryColumns + ` FROM webhook_deliveries d WHERE d.id = $1`
+	row, err := r.db.QueryRowWithRetry(ctx, r.retries, query, id)
+	if err != nil {
+		return nil, err
+	}
+	var d domain.WebhookDelivery
+	err = row.Scan(deliveryFields(&d)...)
+	if err == sql.ErrNoRows {
+		return nil, repository.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+// ClaimDue leases up to limit due deliveries until leaseUntil, together with
+// their target's URL and secret. Deliveries to deactivated subscriptions are
+// held back until the subscription is reactivated.
+func (r *WebhookRepository) ClaimDue(ctx context.Context, limit int, leaseUntil time.Time) ([]*domain.WebhookDelivery, error) {
+	query := `
+WITH claimed AS (
+    UPDATE webhook_deliveries SET next_attempt_at = $2, updated_at = NOW()
+    WHERE id IN (
+        SELECT wd.id FROM webhook_deliveries wd
+        LEFT JOIN webhook_subscriptions ws ON ws.id = wd.subscription_id
+        WHERE wd.status = 'pending' AND wd.next_attempt_at <= NOW()
+          AND (wd.subscription_id IS NULL OR ws.active)
+        ORDER BY wd.next_attempt_at
+        LIMIT $1
+        FOR UPDATE OF wd SKIP LOCKED
+    )
+    RETURNING *
+)
+SELECT ` + deliveryColumns + `, COALESCE(e.url, s.url), COALESCE(e.secret, s.secret)
+FROM claimed d
+LEFT JOIN webhook_endpoints e ON e.id = d.endpoint_id
+LEFT JOIN webhook_subscriptions s ON s.id = d.subscription_id
+`
+	rows, err := r.db.Master.QueryContext(ctx, query, limit, leaseUntil)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var deliveries []*domain.WebhookDelivery
+	for rows.Next() {
+		var d domain.WebhookDelivery
+		if err := rows.Scan(append(deliveryFields(&d), &d.URL, &d.Secret)...); err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, &d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+func (r *WebhookRepository) MarkDelivered(ctx context.Context, id string, attempts int, responseStatus int) error {
+	query := `
+UPDATE webhook_deliveries
+SET status = 'delivered', attempts = $2, response_status = $3, last_error = NULL, delivered_at = NOW(), updated_at = NOW()
+WHERE id = $1
+`
+	_, err := r.db.ExecWithRetry(ctx, r.retries, query, id, attempts, responseStatus)
+	return err
+}
+
+func (r *WebhookRepository) MarkRetry(ctx context.Context, id string, attempts int, responseStatus *int, nextAttemptAt time.Time, lastError string) error {
+	query := `
+UPDATE webhook_deliveries
+SET attempts = $2, response_status = $3, next_attempt_at = $4, last_error = $5, updated_at = NOW()
+WHERE id = $1
+`
+	_, err := r.db.ExecWithRetry(ctx, r.retries, query, id, attempts, responseStatus, nextAttemptAt, lastError)
+	return err
+}
+
+func (r *WebhookRepository) MarkFailed(ctx context.Context, id string, attempts int, responseStatus *int, lastError string) error {
+	query := `
+UPDATE webhook_deliveries
+SET status = 'failed', attempts = $2, response_status = $3, last_error = $4, updated_at = NOW()
+WHERE id = $1
+`
+	_, err := r.db.ExecWithRetry(ctx, r.retries, query, id, attempts, responseStatus, lastError)
+	return err
+}
+
+// ListDeliveries returns the most recent deliveries to an endpoint.
+func (r *WebhookRepository) ListDeliveries(ctx context.Context, endpointID string, limit int) ([]*domain.WebhookDelivery, error) {
+	return r.listDeliveries(ctx, "endpoint_id", endpointID, limit)
+}
+
+// ListSubscriptionDeliveries returns the most recent deliveries to a
+// subscription.
+func (r *WebhookRepository) ListSubscriptionDeliveries(ctx context.Context, subscriptionID string, limit int) ([]*domain.WebhookDelivery, error) {
+	return r.listDeliveries(ctx, "subscription_id", subscriptionID, limit)
+}
+
+func (r *WebhookRepository) listDeliveries(ctx context.Context, column, id string, limit int) ([]*domain.WebhookDelivery, error) {
+	query := `
+SELECT ` + deliveryColumns + `
+FROM webhook_deliveries d
+WHERE d.` + column + ` = $1
+ORDER BY d.created_at DESC
+LIMIT $2
+`
+	rows, err := r.db.QueryWithRetry(ctx, r.retries, query, id, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var deliveries []*domain.WebhookDelivery
+	for rows.Next() {
+		var d domain.WebhookDelivery
+		if err := rows.Scan(deliveryFields(&d)...); err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, &d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+func endpointFields(e *domain.WebhookEndpoint) []interface{} {
+	return []interface{}{&e.ID, &e.UserID, &e.OrganizerID, &e.URL, &e.Secret, &e.CreatedAt}
+}
+
+func deliveryFields(d *domain.WebhookDelivery) []interface{} {
+	return []interface{}{&d.ID, &d.EndpointID, &d.SubscriptionID, &d.Kind, &d.Payload, &d.Status, &d.Attempts, &d.NextAttemptAt,
+		&d.ResponseStatus, &d.LastError, &d.CreatedAt, &d.UpdatedAt, &d.DeliveredAt}
+}
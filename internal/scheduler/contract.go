@@ -8,5 +8,22 @@ import (
 
 type bookingUsecase interface {
 	GetExpiredBookings(ctx context.Context) ([]*domain.Booking, error)
-	CancelBooking(ctx context.Context, bookingID string) error
+	ExpireBooking(ctx context.Context, bookingID string) error
+}
+
+type paymentProcessor interface {
+	CapturePayments(ctx context.Context) error
+	ProcessRefunds(ctx context.Context) error
+}
+
+type eventUsecase interface {
+	CompletePastEvents(ctx context.Context) (int, error)
+}
+
+type reminderSender interface {
+	SendDueReminders(ctx context.Context) (int, error)
+}
+
+type idempotencyPurger interface {
+	PurgeExpired(ctx context.Context) (int64, error)
 }
@@ -3,7 +3,8 @@ package scheduler
 import (
 	"context"
 	"event-booker/internal/config"
-	"strings"
+	"event-booker/internal/metrics"
+	"time"
 
 	"github.com/robfig/cron/v3"
 	"github.com/wb-go/wbf/zlog"
@@ -11,14 +12,22 @@ import (
 
 type Scheduler struct {
 	bookingUsecase bookingUsecase
+	eventUsecase   eventUsecase
+	payments       paymentProcessor
+	reminders      reminderSender
+	idempotency    idempotencyPurger
 	cfg            *config.Config
 	logger         *zlog.Zerolog
 	cron           *cron.Cron
 }
 
-func NewScheduler(bookingUsecase bookingUsecase, cfg *config.Config, logger *zlog.Zerolog) *Scheduler {
+func NewScheduler(bookingUsecase bookingUsecase, eventUsecase eventUsecase, payments paymentProcessor, reminders reminderSender, idempotency idempotencyPurger, cfg *config.Config, logger *zlog.Zerolog) *Scheduler {
 	return &Scheduler{
 		bookingUsecase: bookingUsecase,
+		eventUsecase:   eventUsecase,
+		payments:       payments,
+		reminders:      reminders,
+		idempotency:    idempotency,
 		cfg:            cfg,
 		logger:         logger,
 		cron:           cron.New(),
@@ -26,30 +35,107 @@ func NewScheduler(bookingUsecase bookingUsecase, cfg *config.Config, logger *zlo
 }
 
 func (s *Scheduler) Start(ctx context.Context) {
-	intervalStr := strings.TrimSuffix(s.cfg.Scheduler.CleanupInterval.String(), "0s")
-	_, err := s.cron.AddFunc("@every "+intervalStr, func() {
+	_, err := s.cron.AddFunc(every(s.cfg.Scheduler.CleanupInterval), func() {
 		s.cleanupExpiredBookings(ctx)
+		s.capturePayments(ctx)
+		s.processRefunds(ctx)
+		s.purgeIdempotencyKeys(ctx)
 	})
 	if err != nil {
 		s.logger.Error().Err(err).Msg("Failed to add cron job")
 	}
+	_, err = s.cron.AddFunc(every(s.cfg.Scheduler.LifecycleInterval), func() {
+		s.completePastEvents(ctx)
+	})
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Failed to add lifecycle cron job")
+	}
+	_, err = s.cron.AddFunc(every(s.cfg.Scheduler.ReminderInterval), func() {
+		s.sendReminders(ctx)
+	})
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Failed to add reminder cron job")
+	}
 	s.cron.Start()
 	s.logger.Info().Msg("Scheduler started")
 }
 
+// every builds a cron spec running at interval d. cron parses Go duration
+// strings, so d.String() is used as is.
+func every(d time.Duration) string {
+	return "@every " + d.String()
+}
+
 func (s *Scheduler) cleanupExpiredBookings(ctx context.Context) {
+	start := time.Now()
+	defer func() { metrics.ObserveSchedulerRun("expire_bookings", time.Since(start)) }()
 	expired, err := s.bookingUsecase.GetExpiredBookings(ctx)
 	if err != nil {
 		s.logger.Error().Err(err).Msg("Failed to get expired bookings")
 		return
 	}
+	cancelled := 0
 	for _, b := range expired {
-		if err := s.bookingUsecase.CancelBooking(ctx, b.ID); err != nil {
+		if err := s.bookingUsecase.ExpireBooking(ctx, b.ID); err != nil {
 			s.logger.Error().Err(err).Str("booking_id", b.ID).Msg("Failed to cancel expired booking")
 		} else {
+			cancelled++
 			s.logger.Info().Str("booking_id", b.ID).Msg("Expired booking cancelled")
 		}
 	}
+	metrics.ObserveExpiredBookings(cancelled)
+}
+
+func (s *Scheduler) completePastEvents(ctx context.Context) {
+	start := time.Now()
+	defer func() { metrics.ObserveSchedulerRun("complete_events", time.Since(start)) }()
+	completed, err := s.eventUsecase.CompletePastEvents(ctx)
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Failed to complete past events")
+		return
+	}
+	if completed > 0 {
+		s.logger.Info().Int("count", completed).Msg("Past events completed")
+	}
+}
+
+func (s *Scheduler) sendReminders(ctx context.Context) {
+	start := time.Now()
+	defer func() { metrics.ObserveSchedulerRun("send_reminders", time.Since(start)) }()
+	sent, err := s.reminders.SendDueReminders(ctx)
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Failed to send event reminders")
+	}
+	if sent > 0 {
+		s.logger.Info().Int("count", sent).Msg("Event reminders enqueued")
+	}
+}
+
+func (s *Scheduler) capturePayments(ctx context.Context) {
+	start := time.Now()
+	defer func() { metrics.ObserveSchedulerRun("capture_payments", time.Since(start)) }()
+	if err := s.payments.CapturePayments(ctx); err != nil {
+		s.logger.Error().Err(err).Msg("Failed to capture payments")
+	}
+}
+
+func (s *Scheduler) processRefunds(ctx context.Context) {
+	start := time.Now()
+	defer func() { metrics.ObserveSchedulerRun("process_refunds", time.Since(start)) }()
+	if err := s.payments.ProcessRefunds(ctx); err != nil {
+		s.logger.Error().Err(err).Msg("Failed to process refunds")
+	}
+}
+
+func (s *Scheduler) purgeIdempotencyKeys(ctx context.Context) {
+	purged, err := s.idempotency.PurgeExpired(ctx)
+	if err != nil {
+		s.logger.Error().Err(err).Msg("Failed to purge idempotency keys")
+		return
+	}
+	if purged > 0 {
+		s.logger.Debug().Int64("count", purged).Msg("Expired idempotency keys purged")
+	}
 }
 
 func (s *Scheduler) Stop() {
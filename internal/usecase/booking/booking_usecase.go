@@ -2,11 +2,13 @@ package booking_uc
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"time"
 
 	"event-booker/internal/config"
 	"event-booker/internal/domain"
+	"event-booker/internal/metrics"
 	"event-booker/internal/repository"
 
 	"github.com/google/uuid"
@@ -14,46 +16,108 @@ import (
 	"github.com/wb-go/wbf/zlog"
 )
 
+const (
+	defaultPageSize = 50
+	maxPageSize     = 200
+)
+
 type BookingUsecase struct {
 	db        *dbpg.DB
 	repo      bookingRepository
 	eventRepo eventRepository
-	userRepo  userRepository
-	notifier  notifier
+	seatRepo  seatRepository
+	waitlist  waitlist
+	payments  payments
+	promos    promotions
+	outbox    outbox
+	events    publisher
 	cfg       *config.Config
 	logger    *zlog.Zerolog
 }
 
-func NewBookingUsecase(db *dbpg.DB, repo bookingRepository, eventRepo eventRepository, userRepo userRepository, notifier notifier, cfg *config.Config, logger *zlog.Zerolog) *BookingUsecase {
+func NewBookingUsecase(db *dbpg.DB, repo bookingRepository, eventRepo eventRepository, seatRepo seatRepository, waitlist waitlist, payments payments, promos promotions, outbox outbox, events publisher, cfg *config.Config, logger *zlog.Zerolog) *BookingUsecase {
 	return &BookingUsecase{
 		db:        db,
 		repo:      repo,
 		eventRepo: eventRepo,
-		userRepo:  userRepo,
-		notifier:  notifier,
+		seatRepo:  seatRepo,
+		waitlist:  waitlist,
+		payments:  payments,
+		promos:    promos,
+		outbox:    outbox,
+		events:    events,
 		cfg:       cfg,
 		logger:    logger,
 	}
 }
 
-func (uc *BookingUsecase) BookPlace(ctx context.Context, eventID, userID string) (*domain.Booking, error) {
+// BookPlace reserves seats for the user. For paid events the booking starts as
+// pending and the returned payment carries the checkout details; the booking is
+// confirmed once the provider reports the payment as succeeded. The payment is
+// opened after the booking commits, so the event row is not locked while the
+// provider is called. If that fails the booking is returned without a payment
+// and the client starts one through the pay endpoint.
+//
+// Reserved-seating events are booked by seat: seatIDs names the seats to hold
+// and quantity must equal their number. Seats are locked row by row, so two
+// bookings can never hold the same seat.
+//
+// Events with ticket tiers are booked in the tier named by tierID, which must
+// be on sale and have enough tickets left besides the event-wide availability.
+//
+// A non-empty promoCode discounts the price of a paid booking; a booking
+// discounted to nothing is confirmed straight away.
+func (uc *BookingUsecase) BookPlace(ctx context.Context, eventID, userID string, quantity int, seatIDs []string, tierID, promoCode string) (*domain.Booking, *domain.Payment, error) {
+	if quantity <= 0 {
+		return nil, nil, ErrInvalidQuantity
+	}
+	if len(seatIDs) > 0 {
+		if quantity != len(seatIDs) {
+			return nil, nil, ErrSeatCountMismatch
+		}
+		seen := make(map[string]struct{}, len(seatIDs))
+		for _, id := range seatIDs {
+			if _, ok := seen[id]; ok {
+				return nil, nil, ErrDuplicateSeat
+			}
+			seen[id] = struct{}{}
+		}
+	}
 	tx, err := uc.db.BeginTx(ctx, nil)
 	if err != nil {
 		uc.logger.Error().Err(err).Msg("failed to begin transaction")
-		return nil, err
+		return nil, nil, err
 	}
 	defer tx.Rollback()
 	event, err := uc.eventRepo.GetForUpdate(ctx, tx, eventID)
 	if err != nil {
 		if errors.Is(err, repository.ErrNotFound) {
-			return nil, ErrEventNotFound
+			return nil, nil, ErrEventNotFound
 		}
 		uc.logger.Error().Err(err).Str("event_id", eventID).Msg("failed to get event")
-		return nil, err
+		return nil, nil, err
 	}
-	if event.Available <= 0 {
-		return nil, ErrNoSeatsAvailable
+	if err := checkBookable(event, time.Now()); err != nil {
+		return nil, nil, err
 	}
+	if event.ReservedSeating() && len(seatIDs) == 0 {
+		return nil, nil, ErrSeatsRequired
+	}
+	if !event.ReservedSeating() && len(seatIDs) > 0 {
+		return nil, nil, ErrSeatsNotSupported
+	}
+	if event.MaxPerBooking > 0 && quantity > event.MaxPerBooking {
+		return nil, nil, ErrQuantityTooLarge
+	}
+	if event.Available < quantity {
+		metrics.ObserveBooking(metrics.BookingRejectedNoSeats, 1)
+		return nil, nil, ErrNoSeatsAvailable
+	}
+	tier, err := uc.lockTier(ctx, tx, event, tierID, quantity)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	ttl := event.BookingTTL
 	if ttl == 0 {
 		ttl = uc.cfg.Scheduler.BookingTTL
@@ -63,10 +127,24 @@ func (uc *BookingUsecase) BookPlace(ctx context.Context, eventID, userID string)
 		ID:        uuid.NewString(),
 		EventID:   eventID,
 		UserID:    userID,
+		Quantity:  quantity,
 		CreatedAt: now,
 		ExpiresAt: now, // default
 	}
-	if event.RequiresPayment {
+	if tier != nil {
+		booking.TierID = &tier.ID
+	}
+	price, currency := domain.TicketPrice(event, tier)
+	subtotal := price * int64(quantity)
+	if promoCode != "" {
+		promo, discount, err := uc.promos.ApplyInTx(ctx, tx, promoCode, userID, event, subtotal, currency)
+		if err != nil {
+			return nil, nil, err
+		}
+		booking.PromoCodeID = &promo.ID
+		booking.Discount = discount
+	}
+	if event.RequiresPayment && subtotal > booking.Discount {
 		booking.Status = domain.BookingPending
 		booking.ExpiresAt = now.Add(ttl)
 	} else {
@@ -75,28 +153,84 @@ func (uc *BookingUsecase) BookPlace(ctx context.Context, eventID, userID string)
 		booking.ExpiresAt = time.Time{} // no expiration
 	}
 	if err := uc.repo.Create(ctx, tx, booking); err != nil {
+		if errors.Is(err, repository.ErrAlreadyExists) {
+			return nil, nil, ErrAlreadyBooked
+		}
 		uc.logger.Error().Err(err).Str("booking_id", booking.ID).Msg("failed to create booking")
-		return nil, err
+		return nil, nil, err
+	}
+	if event.ReservedSeating() {
+		if err := uc.seatRepo.HoldSeats(ctx, tx, eventID, booking.ID, seatIDs); err != nil {
+			switch {
+			case errors.Is(err, repository.ErrNotFound):
+				return nil, nil, ErrSeatNotFound
+			case errors.Is(err, repository.ErrSeatUnavailable):
+				return nil, nil, ErrSeatTaken
+			}
+			uc.logger.Error().Err(err).Str("booking_id", booking.ID).Msg("failed to hold seats")
+			return nil, nil, err
+		}
+		booking.SeatIDs = seatIDs
 	}
-	if err := uc.eventRepo.DecrementAvailableSeats(ctx, tx, eventID); err != nil {
+	if err := uc.eventRepo.DecrementAvailableSeats(ctx, tx, eventID, quantity); err != nil {
+		if errors.Is(err, repository.ErrInsufficientSeats) {
+			metrics.ObserveBooking(metrics.BookingRejectedNoSeats, 1)
+			return nil, nil, ErrNoSeatsAvailable
+		}
 		uc.logger.Error().Err(err).Str("event_id", eventID).Msg("failed to decrement available seats")
-		return nil, err
+		return nil, nil, err
+	}
+	if tier != nil {
+		if err := uc.eventRepo.DecrementTierSeats(ctx, tx, tier.ID, quantity); err != nil {
+			if errors.Is(err, repository.ErrInsufficientSeats) {
+				metrics.ObserveBooking(metrics.BookingRejectedNoSeats, 1)
+				return nil, nil, ErrTierSoldOut
+			}
+			uc.logger.Error().Err(err).Str("tier_id", tier.ID).Msg("failed to decrement tier seats")
+			return nil, nil, err
+		}
+	}
+	if err := uc.outbox.Enqueue(ctx, tx, &domain.Notification{
+		Kind:    domain.NotificationBookingCreated,
+		UserID:  booking.UserID,
+		Booking: booking,
+		Event:   event,
+	}); err != nil {
+		uc.logger.Error().Err(err).Str("booking_id", booking.ID).Msg("failed to enqueue booking notification")
+		return nil, nil, err
+	}
+	if err := uc.events.Publish(ctx, tx, &domain.DomainEvent{
+		Type:    domain.DomainBookingCreated,
+		Event:   event,
+		Booking: booking,
+	}); err != nil {
+		uc.logger.Error().Err(err).Str("booking_id", booking.ID).Msg("failed to publish booking event")
+		return nil, nil, err
 	}
 	if err := tx.Commit(); err != nil {
 		uc.logger.Error().Err(err).Msg("failed to commit transaction")
-		return nil, err
+		return nil, nil, err
+	}
+	metrics.ObserveBooking(metrics.BookingCreated, 1)
+	if booking.Status != domain.BookingPending {
+		return booking, nil, nil
 	}
-	return booking, nil
+	payment, err := uc.payments.OpenPayment(ctx, booking.ID)
+	if err != nil {
+		uc.logger.Warn().Err(err).Str("booking_id", booking.ID).Msg("failed to open payment, the client has to start it")
+		return booking, nil, nil
+	}
+	return booking, payment, nil
 }
 
-func (uc *BookingUsecase) ConfirmBooking(ctx context.Context, bookingID string) error {
+func (uc *BookingUsecase) ConfirmBooking(ctx context.Context, bookingID string, actor *domain.Principal) error {
 	tx, err := uc.db.BeginTx(ctx, nil)
 	if err != nil {
 		uc.logger.Error().Err(err).Msg("failed to begin transaction")
 		return err
 	}
 	defer tx.Rollback()
-	booking, err := uc.repo.GetByID(ctx, bookingID)
+	booking, err := uc.repo.GetForUpdate(ctx, tx, bookingID)
 	if err != nil {
 		if errors.Is(err, repository.ErrNotFound) {
 			return ErrBookingNotFound
@@ -104,9 +238,20 @@ func (uc *BookingUsecase) ConfirmBooking(ctx context.Context, bookingID string)
 		uc.logger.Error().Err(err).Str("booking_id", bookingID).Msg("failed to get booking")
 		return err
 	}
+	if !actor.CanAccessUser(booking.UserID) {
+		return ErrForbidden
+	}
 	if booking.Status != domain.BookingPending {
 		return ErrBookingNotPending
 	}
+	event, err := uc.eventRepo.GetByID(ctx, booking.EventID)
+	if err != nil {
+		uc.logger.Error().Err(err).Str("event_id", booking.EventID).Msg("failed to get event")
+		return err
+	}
+	if event.RequiresPayment {
+		return ErrPaymentRequired
+	}
 	if time.Now().After(booking.ExpiresAt) && !booking.ExpiresAt.IsZero() {
 		return ErrBookingExpired
 	}
@@ -117,53 +262,188 @@ func (uc *BookingUsecase) ConfirmBooking(ctx context.Context, bookingID string)
 		uc.logger.Error().Err(err).Str("booking_id", bookingID).Msg("failed to update booking")
 		return err
 	}
+	if err := uc.outbox.Enqueue(ctx, tx, &domain.Notification{
+		Kind:    domain.NotificationBookingConfirmed,
+		UserID:  booking.UserID,
+		Booking: booking,
+		Event:   event,
+	}); err != nil {
+		uc.logger.Error().Err(err).Str("booking_id", bookingID).Msg("failed to enqueue confirmation notification")
+		return err
+	}
+	if err := uc.events.Publish(ctx, tx, &domain.DomainEvent{
+		Type:    domain.DomainBookingConfirmed,
+		Event:   event,
+		Booking: booking,
+	}); err != nil {
+		uc.logger.Error().Err(err).Str("booking_id", bookingID).Msg("failed to publish confirmation event")
+		return err
+	}
 	if err := tx.Commit(); err != nil {
 		uc.logger.Error().Err(err).Msg("failed to commit transaction")
 		return err
 	}
+	metrics.ObserveBooking(metrics.BookingConfirmed, 1)
 	return nil
 }
 
-func (uc *BookingUsecase) CancelBooking(ctx context.Context, bookingID string) error {
+// lockTier locks and checks the tier a booking is made in. It returns nil for
+// events without tiers.
+func (uc *BookingUsecase) lockTier(ctx context.Context, tx *sql.Tx, event *domain.Event, tierID string, quantity int) (*domain.TicketTier, error) {
+	if tierID == "" {
+		hasTiers, err := uc.eventRepo.HasTiers(ctx, tx, event.ID)
+		if err != nil {
+			uc.logger.Error().Err(err).Str("event_id", event.ID).Msg("failed to check ticket tiers")
+			return nil, err
+		}
+		if hasTiers {
+			return nil, ErrTierRequired
+		}
+		return nil, nil
+	}
+	tier, err := uc.eventRepo.GetTierForUpdate(ctx, tx, tierID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrTierNotFound
+		}
+		uc.logger.Error().Err(err).Str("tier_id", tierID).Msg("failed to get ticket tier")
+		return nil, err
+	}
+	if tier.EventID != event.ID {
+		return nil, ErrTierNotFound
+	}
+	if !tier.OnSale(time.Now()) {
+		return nil, ErrTierNotOnSale
+	}
+	if tier.Available < quantity {
+		metrics.ObserveBooking(metrics.BookingRejectedNoSeats, 1)
+		return nil, ErrTierSoldOut
+	}
+	return tier, nil
+}
+
+// checkBookable rejects events that are no longer open for new bookings. The
+// date check covers events the lifecycle job has not completed yet.
+func checkBookable(event *domain.Event, now time.Time) error {
+	switch event.Status {
+	case domain.EventCancelled:
+		return ErrEventCancelled
+	case domain.EventCompleted:
+		return ErrEventCompleted
+	}
+	if !event.Date.After(now) {
+		return ErrEventStarted
+	}
+	return nil
+}
+
+// CancelBooking cancels the actor's booking. If it was paid for, the event's
+// cancellation policy decides how much is refunded; the returned refund is nil
+// when nothing is.
+func (uc *BookingUsecase) CancelBooking(ctx context.Context, bookingID string, actor *domain.Principal) (*domain.Refund, error) {
+	return uc.cancelBooking(ctx, bookingID, "cancelled by user", domain.NotificationBookingCancelled, domain.DomainBookingCancelled, metrics.BookingCancelled, func(booking *domain.Booking) error {
+		if !actor.CanAccessUser(booking.UserID) {
+			return ErrForbidden
+		}
+		return nil
+	})
+}
+
+// ExpireBooking cancels a pending booking whose confirmation deadline has passed.
+// It is used by the scheduler and therefore runs without a caller identity.
+func (uc *BookingUsecase) ExpireBooking(ctx context.Context, bookingID string) error {
+	_, err := uc.cancelBooking(ctx, bookingID, "booking expired", domain.NotificationBookingExpired, domain.DomainBookingExpired, metrics.BookingExpired, func(booking *domain.Booking) error {
+		if booking.Status != domain.BookingPending || booking.ExpiresAt.IsZero() || time.Now().Before(booking.ExpiresAt) {
+			return ErrBookingNotExpired
+		}
+		return nil
+	})
+	return err
+}
+
+func (uc *BookingUsecase) cancelBooking(ctx context.Context, bookingID, reason string, kind domain.NotificationKind, eventType domain.DomainEventType, outcome metrics.BookingOutcome, authorize func(booking *domain.Booking) error) (*domain.Refund, error) {
 	tx, err := uc.db.BeginTx(ctx, nil)
 	if err != nil {
 		uc.logger.Error().Err(err).Msg("failed to begin transaction")
-		return err
+		return nil, err
 	}
 	defer tx.Rollback()
-	booking, err := uc.repo.GetByID(ctx, bookingID)
+	booking, err := uc.repo.GetForUpdate(ctx, tx, bookingID)
 	if err != nil {
 		if errors.Is(err, repository.ErrNotFound) {
-			return ErrBookingNotFound
+			return nil, ErrBookingNotFound
 		}
 		uc.logger.Error().Err(err).Str("booking_id", bookingID).Msg("failed to get booking")
-		return err
+		return nil, err
+	}
+	if err := authorize(booking); err != nil {
+		return nil, err
 	}
 	if booking.Status == domain.BookingCancelled {
-		return ErrAlreadyCancelled
+		return nil, ErrAlreadyCancelled
+	}
+	event, err := uc.eventRepo.GetByID(ctx, booking.EventID)
+	if err != nil {
+		uc.logger.Error().Err(err).Str("event_id", booking.EventID).Msg("failed to get event")
+		return nil, err
 	}
 	booking.Status = domain.BookingCancelled
 	if err := uc.repo.Update(ctx, tx, booking); err != nil {
 		uc.logger.Error().Err(err).Str("booking_id", bookingID).Msg("failed to update booking")
-		return err
+		return nil, err
 	}
-	if err := uc.eventRepo.IncrementAvailableSeats(ctx, tx, booking.EventID); err != nil {
+	if err := uc.eventRepo.IncrementAvailableSeats(ctx, tx, booking.EventID, booking.Quantity); err != nil {
 		uc.logger.Error().Err(err).Str("event_id", booking.EventID).Msg("failed to increment available seats")
-		return err
+		return nil, err
+	}
+	if booking.TierID != nil {
+		if err := uc.eventRepo.IncrementTierSeats(ctx, tx, *booking.TierID, booking.Quantity); err != nil {
+			uc.logger.Error().Err(err).Str("tier_id", *booking.TierID).Msg("failed to increment tier seats")
+			return nil, err
+		}
+	}
+	if err := uc.seatRepo.ReleaseSeats(ctx, tx, booking.ID); err != nil {
+		uc.logger.Error().Err(err).Str("booking_id", bookingID).Msg("failed to release seats")
+		return nil, err
+	}
+	if err := uc.promos.ReleaseInTx(ctx, tx, booking); err != nil {
+		uc.logger.Error().Err(err).Str("booking_id", bookingID).Msg("failed to release promo code")
+		return nil, err
+	}
+	percent := event.Cancellation.RefundPercent(event.Date, time.Now())
+	refund, err := uc.payments.SettleCancellationInTx(ctx, tx, booking, percent, reason)
+	if err != nil {
+		uc.logger.Error().Err(err).Str("booking_id", bookingID).Msg("failed to settle booking payment")
+		return nil, err
+	}
+	if _, err := uc.waitlist.PromoteInTx(ctx, tx, booking.EventID); err != nil {
+		uc.logger.Error().Err(err).Str("event_id", booking.EventID).Msg("failed to promote waitlist")
+		return nil, err
+	}
+	if err := uc.outbox.Enqueue(ctx, tx, &domain.Notification{
+		Kind:    kind,
+		UserID:  booking.UserID,
+		Booking: booking,
+		Event:   event,
+	}); err != nil {
+		uc.logger.Error().Err(err).Str("booking_id", bookingID).Msg("failed to enqueue cancellation notification")
+		return nil, err
+	}
+	if err := uc.events.Publish(ctx, tx, &domain.DomainEvent{
+		Type:    eventType,
+		Event:   event,
+		Booking: booking,
+		Reason:  reason,
+	}); err != nil {
+		uc.logger.Error().Err(err).Str("booking_id", bookingID).Msg("failed to publish cancellation event")
+		return nil, err
 	}
 	if err := tx.Commit(); err != nil {
 		uc.logger.Error().Err(err).Msg("failed to commit transaction")
-		return err
-	}
-	user, err := uc.userRepo.GetByID(ctx, booking.UserID)
-	if err == nil {
-		if notifyErr := uc.notifier.NotifyCancellation(user, booking); notifyErr != nil {
-			uc.logger.Error().Err(notifyErr).Str("user_id", user.ID).Msg("Failed to notify cancellation")
-		}
-	} else {
-		uc.logger.Error().Err(err).Str("user_id", booking.UserID).Msg("failed to get user for notification")
+		return nil, err
 	}
-	return nil
+	metrics.ObserveBooking(outcome, 1)
+	return refund, nil
 }
 
 func (uc *BookingUsecase) GetExpiredBookings(ctx context.Context) ([]*domain.Booking, error) {
@@ -175,6 +455,77 @@ func (uc *BookingUsecase) GetExpiredBookings(ctx context.Context) ([]*domain.Boo
 	return expired, nil
 }
 
-func (uc *BookingUsecase) ListBookings(ctx context.Context) ([]*domain.Booking, error) {
-	return uc.repo.GetAll(ctx)
+// ListBookings returns one page of bookings across events. Admins see all of
+// them, organizer staff only those for events of their organizers. The limit
+// defaults to defaultPageSize and is capped at maxPageSize.
+func (uc *BookingUsecase) ListBookings(ctx context.Context, filter domain.BookingFilter, actor *domain.Principal) (*domain.Page[*domain.Booking], error) {
+	if !actor.IsStaff() {
+		return nil, ErrNotStaff
+	}
+	filter.OrganizerIDs = nil
+	if actor.ScopedToOrganizers() {
+		filter.OrganizerIDs = actor.OrganizerIDs()
+	}
+	switch filter.Status {
+	case "", domain.BookingPending, domain.BookingConfirmed, domain.BookingCancelled:
+	default:
+		return nil, ErrInvalidFilter
+	}
+	switch filter.Sort {
+	case "":
+		filter.Sort = domain.BookingSortCreatedDesc
+	case domain.BookingSortCreatedDesc, domain.BookingSortCreatedAsc:
+	default:
+		return nil, ErrInvalidFilter
+	}
+	if filter.Limit < 0 {
+		return nil, ErrInvalidFilter
+	}
+	if filter.Limit == 0 {
+		filter.Limit = defaultPageSize
+	}
+	if filter.Limit > maxPageSize {
+		filter.Limit = maxPageSize
+	}
+	page, err := uc.repo.List(ctx, filter)
+	if err != nil {
+		if errors.Is(err, repository.ErrInvalidCursor) {
+			return nil, ErrInvalidCursor
+		}
+		uc.logger.Error().Err(err).Msg("failed to list bookings")
+		return nil, err
+	}
+	return page, nil
+}
+
+// ListUserBookings returns one page of a user's booking history. Users may only
+// list their own bookings; administrators may list anyone's.
+func (uc *BookingUsecase) ListUserBookings(ctx context.Context, filter domain.UserBookingFilter, actor *domain.Principal) (*domain.Page[*domain.UserBooking], error) {
+	if !actor.CanAccessUser(filter.UserID) {
+		return nil, ErrForbidden
+	}
+	switch filter.Scope {
+	case "", domain.UserBookingsUpcoming, domain.UserBookingsPast, domain.UserBookingsCancelled:
+	default:
+		return nil, ErrInvalidFilter
+	}
+	if filter.Limit < 0 {
+		return nil, ErrInvalidFilter
+	}
+	if filter.Limit == 0 {
+		filter.Limit = defaultPageSize
+	}
+	if filter.Limit > maxPageSize {
+		filter.Limit = maxPageSize
+	}
+	filter.Now = time.Now()
+	page, err := uc.repo.ListByUser(ctx, filter)
+	if err != nil {
+		if errors.Is(err, repository.ErrInvalidCursor) {
+			return nil, ErrInvalidCursor
+		}
+		uc.logger.Error().Err(err).Str("user_id", filter.UserID).Msg("failed to list user bookings")
+		return nil, err
+	}
+	return page, nil
 }
@@ -0,0 +1,99 @@
+package booking_uc
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"testing"
+	"time"
+
+	"event-booker/internal/config"
+	"event-booker/internal/domain"
+	"event-booker/internal/repository"
+
+	"github.com/wb-go/wbf/dbpg"
+	"github.com/wb-go/wbf/zlog"
+)
+
+// txConnector hands out connections that only begin and end transactions;
+// the repositories in these tests are fakes that never touch them.
+type txConnector struct{}
+
+func (txConnector) Connect(context.Context) (driver.Conn, error) { return txConn{}, nil }
+func (txConnector) Driver() driver.Driver                        { return nil }
+
+type txConn struct{}
+
+func (txConn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("not supported") }
+func (txConn) Close() error                        { return nil }
+func (txConn) Begin() (driver.Tx, error)           { return txConn{}, nil }
+func (txConn) Commit() error                       { return nil }
+func (txConn) Rollback() error                     { return nil }
+
+type fakeEventRepo struct {
+	eventRepository
+	events map[string]*domain.Event
+}
+
+func (r *fakeEventRepo) GetForUpdate(_ context.Context, _ *sql.Tx, id string) (*domain.Event, error) {
+	event, ok := r.events[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	return event, nil
+}
+
+func (r *fakeEventRepo) HasTiers(context.Context, *sql.Tx, string) (bool, error) {
+	return false, nil
+}
+
+func (r *fakeEventRepo) DecrementAvailableSeats(_ context.Context, _ *sql.Tx, id string, seats int) error {
+	r.events[id].Available -= seats
+	return nil
+}
+
+type fakeBookingRepo struct {
+	bookingRepository
+	created []*domain.Booking
+}
+
+func (r *fakeBookingRepo) Create(_ context.Context, _ *sql.Tx, booking *domain.Booking) error {
+	r.created = append(r.created, booking)
+	return nil
+}
+
+type discard struct{}
+
+func (discard) Enqueue(context.Context, *sql.Tx, *domain.Notification) error { return nil }
+func (discard) Publish(context.Context, *sql.Tx, *domain.DomainEvent) error  { return nil }
+
+func TestBookPlaceOnAnotherOrganizersEvent(t *testing.T) {
+	orgA, orgB := "org-a", "org-b"
+	events := &fakeEventRepo{events: map[string]*domain.Event{
+		"event-b": {
+			ID:          "event-b",
+			OrganizerID: &orgB,
+			Status:      domain.EventActive,
+			Date:        time.Now().Add(24 * time.Hour),
+			TotalSeats:  10,
+			Available:   10,
+		},
+	}}
+	bookings := &fakeBookingRepo{}
+	logger := zlog.Zerolog{}
+	db := &dbpg.DB{Master: sql.OpenDB(txConnector{})}
+	uc := NewBookingUsecase(db, bookings, events, nil, nil, nil, nil, discard{}, discard{}, &config.Config{}, &logger)
+	staff := &domain.Principal{UserID: "u1", Role: domain.RoleUser, Memberships: map[string]domain.OrganizerRole{orgA: domain.OrganizerManager}}
+
+	booking, _, err := uc.BookPlace(context.Background(), "event-b", staff.UserID, 2, nil, "", "")
+	if err != nil {
+		t.Fatalf("staff of %s booking an event of %s: %v", orgA, orgB, err)
+	}
+	if booking.Status != domain.BookingConfirmed || booking.UserID != staff.UserID || len(bookings.created) != 1 {
+		t.Errorf("booking = %+v, %d created", booking, len(bookings.created))
+	}
+	if got := events.events["event-b"].Available; got != 8 {
+		t.Errorf("available seats = %d, want 8", got)
+	}
+}
@@ -11,24 +11,49 @@ import (
 type bookingRepository interface {
 	Create(ctx context.Context, tx *sql.Tx, booking *domain.Booking) error
 	GetByID(ctx context.Context, id string) (*domain.Booking, error)
+	GetForUpdate(ctx context.Context, tx *sql.Tx, id string) (*domain.Booking, error)
 	Update(ctx context.Context, tx *sql.Tx, booking *domain.Booking) error
 	Delete(ctx context.Context, tx *sql.Tx, id string) error
 	GetExpired(ctx context.Context, now time.Time) ([]*domain.Booking, error)
 	GetByEventID(ctx context.Context, eventID string) ([]*domain.Booking, error)
-	GetAll(ctx context.Context) ([]*domain.Booking, error)
+	List(ctx context.Context, filter domain.BookingFilter) (*domain.Page[*domain.Booking], error)
+	ListByUser(ctx context.Context, filter domain.UserBookingFilter) (*domain.Page[*domain.UserBooking], error)
 }
 
 type eventRepository interface {
 	GetByID(ctx context.Context, id string) (*domain.Event, error)
 	GetForUpdate(ctx context.Context, tx *sql.Tx, id string) (*domain.Event, error)
-	DecrementAvailableSeats(ctx context.Context, tx *sql.Tx, id string) error
-	IncrementAvailableSeats(ctx context.Context, tx *sql.Tx, id string) error
+	DecrementAvailableSeats(ctx context.Context, tx *sql.Tx, id string, seats int) error
+	IncrementAvailableSeats(ctx context.Context, tx *sql.Tx, id string, seats int) error
+	GetTierForUpdate(ctx context.Context, tx *sql.Tx, id string) (*domain.TicketTier, error)
+	HasTiers(ctx context.Context, tx *sql.Tx, eventID string) (bool, error)
+	DecrementTierSeats(ctx context.Context, tx *sql.Tx, tierID string, seats int) error
+	IncrementTierSeats(ctx context.Context, tx *sql.Tx, tierID string, seats int) error
 }
 
-type userRepository interface {
-	GetByID(ctx context.Context, id string) (*domain.User, error)
+type outbox interface {
+	Enqueue(ctx context.Context, tx *sql.Tx, notification *domain.Notification) error
 }
 
-type notifier interface {
-	NotifyCancellation(user *domain.User, booking *domain.Booking) error
+type waitlist interface {
+	PromoteInTx(ctx context.Context, tx *sql.Tx, eventID string) ([]*domain.Booking, error)
+}
+
+type payments interface {
+	OpenPayment(ctx context.Context, bookingID string) (*domain.Payment, error)
+	SettleCancellationInTx(ctx context.Context, tx *sql.Tx, booking *domain.Booking, percent int, reason string) (*domain.Refund, error)
+}
+
+type promotions interface {
+	ApplyInTx(ctx context.Context, tx *sql.Tx, code, userID string, event *domain.Event, subtotal int64, currency string) (*domain.PromoCode, int64, error)
+	ReleaseInTx(ctx context.Context, tx *sql.Tx, booking *domain.Booking) error
+}
+
+type publisher interface {
+	Publish(ctx context.Context, tx *sql.Tx, event *domain.DomainEvent) error
+}
+
+type seatRepository interface {
+	HoldSeats(ctx context.Context, tx *sql.Tx, eventID, bookingID string, seatIDs []string) error
+	ReleaseSeats(ctx context.Context, tx *sql.Tx, bookingID string) error
 }
@@ -9,4 +9,26 @@ var (
 	ErrBookingNotPending = errors.New("booking not pending")
 	ErrBookingExpired    = errors.New("booking expired")
 	ErrAlreadyCancelled  = errors.New("booking already cancelled")
+	ErrInvalidQuantity   = errors.New("quantity must be positive")
+	ErrQuantityTooLarge  = errors.New("quantity exceeds per-booking limit")
+	ErrForbidden         = errors.New("booking belongs to another user")
+	ErrNotStaff          = errors.New("only admins and organizer staff may list bookings")
+	ErrBookingNotExpired = errors.New("booking has not expired")
+	ErrPaymentRequired   = errors.New("booking must be confirmed through payment")
+	ErrEventCancelled    = errors.New("event has been cancelled")
+	ErrEventCompleted    = errors.New("event has already taken place")
+	ErrEventStarted      = errors.New("event has already started")
+	ErrAlreadyBooked     = errors.New("user already holds a booking for this event")
+	ErrInvalidFilter     = errors.New("invalid list filter")
+	ErrInvalidCursor     = errors.New("invalid cursor")
+	ErrSeatsRequired     = errors.New("reserved-seating events must be booked with seat IDs")
+	ErrSeatsNotSupported = errors.New("event does not have reserved seating")
+	ErrSeatCountMismatch = errors.New("quantity must match the number of seats")
+	ErrDuplicateSeat     = errors.New("seat listed more than once")
+	ErrSeatNotFound      = errors.New("seat not found for this event")
+	ErrSeatTaken         = errors.New("seat is already taken")
+	ErrTierRequired      = errors.New("event has ticket tiers, choose one")
+	ErrTierNotFound      = errors.New("ticket tier not found for this event")
+	ErrTierNotOnSale     = errors.New("ticket tier is not on sale")
+	ErrTierSoldOut       = errors.New("ticket tier is sold out")
 )
@@ -3,29 +3,74 @@ package event_uc
 import (
 	"context"
 	"database/sql"
+	"time"
+
 	"event-booker/internal/domain"
 )
 
 type eventRepository interface {
-	Create(ctx context.Context, event *domain.Event) error
+	Create(ctx context.Context, tx *sql.Tx, event *domain.Event) error
 	GetByID(ctx context.Context, id string) (*domain.Event, error)
 	GetForUpdate(ctx context.Context, tx *sql.Tx, id string) (*domain.Event, error)
-	GetAll(ctx context.Context) ([]*domain.Event, error)
+	List(ctx context.Context, filter domain.EventFilter) (*domain.Page[*domain.Event], error)
 	Update(ctx context.Context, tx *sql.Tx, event *domain.Event) error
 	Delete(ctx context.Context, id string) error
-	DecrementAvailableSeats(ctx context.Context, tx *sql.Tx, id string) error
-	IncrementAvailableSeats(ctx context.Context, tx *sql.Tx, id string) error
+	DecrementAvailableSeats(ctx context.Context, tx *sql.Tx, id string, seats int) error
+	IncrementAvailableSeats(ctx context.Context, tx *sql.Tx, id string, seats int) error
+	CompletePast(ctx context.Context, tx *sql.Tx, now time.Time) ([]string, error)
+	CreateTier(ctx context.Context, tx *sql.Tx, tier *domain.TicketTier) error
+	GetTierForUpdate(ctx context.Context, tx *sql.Tx, id string) (*domain.TicketTier, error)
+	ListTiers(ctx context.Context, tx *sql.Tx, eventID string) ([]*domain.TicketTier, error)
+	UpdateTier(ctx context.Context, tx *sql.Tx, tier *domain.TicketTier) error
+	DeleteTier(ctx context.Context, tx *sql.Tx, id string) error
+	IncrementTierSeats(ctx context.Context, tx *sql.Tx, tierID string, seats int) error
+	CreateSeries(ctx context.Context, tx *sql.Tx, series *domain.EventSeries) error
+	GetSeries(ctx context.Context, id string) (*domain.EventSeries, error)
+	ListSeriesEventIDs(ctx context.Context, tx *sql.Tx, seriesID string, from time.Time) ([]string, error)
 }
 
 type bookingRepository interface {
 	GetByEventID(ctx context.Context, eventID string) ([]*domain.Booking, error)
+	GetReservedSeats(ctx context.Context, tx *sql.Tx, eventID string) (int, error)
+	GetReservedTierSeats(ctx context.Context, tx *sql.Tx, tierID string) (int, error)
 	Update(ctx context.Context, tx *sql.Tx, booking *domain.Booking) error
 }
 
-type userRepository interface {
-	GetByID(ctx context.Context, id string) (*domain.User, error)
+type seatRepository interface {
+	CountLayoutSeats(ctx context.Context, layoutID string) (int, error)
+	CreateInventory(ctx context.Context, tx *sql.Tx, eventID, layoutID string) (int, error)
+	ReleaseSeats(ctx context.Context, tx *sql.Tx, bookingID string) error
+}
+
+type venueRepository interface {
+	GetForShare(ctx context.Context, tx *sql.Tx, id string) (*domain.Venue, error)
+}
+
+type organizerRepository interface {
+	GetByID(ctx context.Context, id string) (*domain.Organizer, error)
+}
+
+type outbox interface {
+	Enqueue(ctx context.Context, tx *sql.Tx, notification *domain.Notification) error
+}
+
+type reminderRepository interface {
+	ResetForEvent(ctx context.Context, tx *sql.Tx, eventID string) error
+}
+
+type waitlist interface {
+	PromoteInTx(ctx context.Context, tx *sql.Tx, eventID string) ([]*domain.Booking, error)
+	CancelForEventInTx(ctx context.Context, tx *sql.Tx, eventID string) error
+}
+
+type payments interface {
+	SettleCancellationInTx(ctx context.Context, tx *sql.Tx, booking *domain.Booking, percent int, reason string) (*domain.Refund, error)
+}
+
+type promotions interface {
+	ReleaseInTx(ctx context.Context, tx *sql.Tx, booking *domain.Booking) error
 }
 
-type notifier interface {
-	NotifyCancellation(user *domain.User, booking *domain.Booking) error
+type publisher interface {
+	Publish(ctx context.Context, tx *sql.Tx, event *domain.DomainEvent) error
 }
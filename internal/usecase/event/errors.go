@@ -4,9 +4,41 @@ import "errors"
 
 var (
 	ErrEventNotFound         = errors.New("event not found")
+	ErrForbidden             = errors.New("forbidden")
 	ErrEventAlreadyCancelled = errors.New("event is already cancelled")
 	ErrCannotCancelPastEvent = errors.New("cannot cancel past event")
-	ErrCancellationTooLate   = errors.New("cannot cancel event less than 24 hours before start")
+	ErrCancellationTooLate   = errors.New("event is within its cancellation cutoff")
 	ErrEventAlreadyStarted   = errors.New("event has already started")
 	ErrInvalidEventStatus    = errors.New("invalid event status")
+	ErrEventNotActive        = errors.New("event is not active")
+	ErrInvalidCapacity       = errors.New("total seats must be positive")
+	ErrCapacityBelowReserved = errors.New("total seats cannot be lower than already reserved seats")
+	ErrPriceRequired         = errors.New("paid events must have a positive price")
+	ErrInvalidPolicy         = errors.New("invalid cancellation policy")
+	ErrInvalidName           = errors.New("event name must not be empty")
+	ErrEventDateInPast       = errors.New("event date must be in the future")
+	ErrInvalidBookingTTL     = errors.New("booking TTL must be positive")
+	ErrInvalidMaxPerBooking  = errors.New("max per booking must be between 0 and total seats")
+	ErrInvalidPrice          = errors.New("price must not be negative")
+	ErrInvalidCurrency       = errors.New("currency must be a 3-letter code")
+	ErrInvalidFilter         = errors.New("invalid list filter")
+	ErrInvalidCursor         = errors.New("invalid cursor")
+	ErrLayoutNotFound        = errors.New("seat layout not found")
+	ErrCapacityFixedByLayout = errors.New("capacity of a reserved-seating event is set by its layout")
+	ErrTierNotFound          = errors.New("ticket tier not found")
+	ErrTierExists            = errors.New("event already has a ticket tier with this name")
+	ErrTierInUse             = errors.New("ticket tier has bookings or waitlist entries")
+	ErrInvalidTierName       = errors.New("ticket tier name must not be empty")
+	ErrInvalidQuota          = errors.New("ticket tier quota must be positive")
+	ErrQuotaBelowReserved    = errors.New("ticket tier quota cannot be lower than already reserved seats")
+	ErrQuotaExceedsCapacity  = errors.New("ticket tier quotas cannot exceed the event's total seats")
+	ErrInvalidSalesWindow    = errors.New("ticket tier sales must start before they end")
+	ErrVenueNotFound         = errors.New("venue not found")
+	ErrOrganizerNotFound     = errors.New("organizer not found")
+	ErrCapacityExceedsVenue  = errors.New("total seats cannot exceed the venue capacity")
+	ErrSeriesNotFound        = errors.New("event series not found")
+	ErrInvalidRecurrence     = errors.New("invalid recurrence rule")
+	ErrTooManyOccurrences    = errors.New("recurrence rule produces too many occurrences")
+	ErrNoOccurrences         = errors.New("recurrence rule produces no occurrences")
+	ErrSeriesDatePatch       = errors.New("occurrence dates of a series cannot be changed in bulk")
 )
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"event-booker/internal/domain"
+	"event-booker/internal/metrics"
 	"event-booker/internal/repository"
 
 	"github.com/google/uuid"
@@ -14,39 +15,53 @@ import (
 	"github.com/wb-go/wbf/zlog"
 )
 
+const (
+	defaultCurrency = "RUB"
+	defaultPageSize = 50
+	maxPageSize     = 200
+)
+
 type EventUsecase struct {
 	db          *dbpg.DB
 	repo        eventRepository
 	bookingRepo bookingRepository
-	userRepo    userRepository
-	notifier    notifier
+	seatRepo    seatRepository
+	venueRepo   venueRepository
+	orgRepo     organizerRepository
+	reminders   reminderRepository
+	waitlist    waitlist
+	payments    payments
+	promos      promotions
+	outbox      outbox
+	events      publisher
 	logger      *zlog.Zerolog
 }
 
-func NewEventUsecase(db *dbpg.DB, repo eventRepository, bookingRepo bookingRepository, userRepo userRepository, notifier notifier, logger *zlog.Zerolog) *EventUsecase {
+func NewEventUsecase(db *dbpg.DB, repo eventRepository, bookingRepo bookingRepository, seatRepo seatRepository, venueRepo venueRepository, orgRepo organizerRepository, reminders reminderRepository, waitlist waitlist, payments payments, promos promotions, outbox outbox, events publisher, logger *zlog.Zerolog) *EventUsecase {
 	return &EventUsecase{
 		db:          db,
 		repo:        repo,
 		bookingRepo: bookingRepo,
-		userRepo:    userRepo,
-		notifier:    notifier,
+		seatRepo:    seatRepo,
+		venueRepo:   venueRepo,
+		orgRepo:     orgRepo,
+		reminders:   reminders,
+		waitlist:    waitlist,
+		payments:    payments,
+		promos:      promos,
+		outbox:      outbox,
+		events:      events,
 		logger:      logger,
 	}
 }
 
-func (uc *EventUsecase) CancelEvent(ctx context.Context, eventID string, reason string) error {
+func (uc *EventUsecase) CancelEvent(ctx context.Context, eventID string, reason string, actor *domain.Principal) error {
 	tx, err := uc.db.BeginTx(ctx, nil)
 	if err != nil {
 		uc.logger.Error().Err(err).Str("event_id", eventID).Msg("Failed to begin transaction")
 		return err
 	}
-	defer func() {
-		if err != nil {
-			if rbErr := tx.Rollback(); rbErr != nil {
-				uc.logger.Error().Err(rbErr).Str("event_id", eventID).Msg("Failed to rollback transaction")
-			}
-		}
-	}()
+	defer tx.Rollback()
 	event, err := uc.repo.GetForUpdate(ctx, tx, eventID)
 	if err != nil {
 		if errors.Is(err, repository.ErrNotFound) {
@@ -55,55 +70,70 @@ func (uc *EventUsecase) CancelEvent(ctx context.Context, eventID string, reason
 		uc.logger.Error().Err(err).Str("event_id", eventID).Msg("Failed to get event for update")
 		return err
 	}
+	if !actor.CanManageEvents(event.OrganizerID) {
+		return ErrForbidden
+	}
 	if err := uc.validateEventCancellation(event); err != nil {
 		return err
 	}
-	bookings, err := uc.bookingRepo.GetByEventID(ctx, eventID)
+	cancelledCount, err := uc.cancelEventInTx(ctx, tx, event, reason)
 	if err != nil {
-		uc.logger.Error().Err(err).Str("event_id", eventID).Msg("Failed to get bookings for event")
 		return err
 	}
-	var notifications []notificationData
-	for _, booking := range bookings {
-		if booking.Status != domain.BookingCancelled {
-			notifications = append(notifications, notificationData{
-				bookingID: booking.ID,
-				userID:    booking.UserID,
-			})
-		}
+	if err := tx.Commit(); err != nil {
+		uc.logger.Error().Err(err).Str("event_id", eventID).Msg("Failed to commit transaction")
+		return err
+	}
+	metrics.ObserveBooking(metrics.BookingCancelled, cancelledCount)
+	uc.logger.Info().
+		Str("event_id", eventID).
+		Str("event_name", event.Name).
+		Int("cancelled_bookings", cancelledCount).
+		Str("reason", reason).
+		Msg("Event cancelled successfully")
+	return nil
+}
+
+// cancelEventInTx cancels a locked event together with its bookings and
+// waitlist and returns the number of bookings cancelled.
+func (uc *EventUsecase) cancelEventInTx(ctx context.Context, tx *sql.Tx, event *domain.Event, reason string) (int, error) {
+	bookings, err := uc.bookingRepo.GetByEventID(ctx, event.ID)
+	if err != nil {
+		uc.logger.Error().Err(err).Str("event_id", event.ID).Msg("Failed to get bookings for event")
+		return 0, err
 	}
 	cancelledCount := 0
 	for _, booking := range bookings {
 		if booking.Status != domain.BookingCancelled {
-			if err := uc.cancelBookingInTx(ctx, tx, booking); err != nil {
+			if err := uc.cancelBookingInTx(ctx, tx, event, booking, reason); err != nil {
 				uc.logger.Error().Err(err).
 					Str("booking_id", booking.ID).
-					Str("event_id", eventID).
+					Str("event_id", event.ID).
 					Msg("Failed to cancel booking in transaction")
-				return err
+				return 0, err
 			}
 			cancelledCount++
 		}
 	}
+	if err := uc.waitlist.CancelForEventInTx(ctx, tx, event.ID); err != nil {
+		uc.logger.Error().Err(err).Str("event_id", event.ID).Msg("Failed to cancel waitlist entries")
+		return 0, err
+	}
 	event.Status = domain.EventCancelled
 	event.UpdatedAt = time.Now()
 	if err := uc.repo.Update(ctx, tx, event); err != nil {
-		uc.logger.Error().Err(err).Str("event_id", eventID).Msg("Failed to update event status")
-		return err
+		uc.logger.Error().Err(err).Str("event_id", event.ID).Msg("Failed to update event status")
+		return 0, err
 	}
-	if err := tx.Commit(); err != nil {
-		uc.logger.Error().Err(err).Str("event_id", eventID).Msg("Failed to commit transaction")
-		return err
+	if err := uc.events.Publish(ctx, tx, &domain.DomainEvent{
+		Type:   domain.DomainEventCancelled,
+		Event:  event,
+		Reason: reason,
+	}); err != nil {
+		uc.logger.Error().Err(err).Str("event_id", event.ID).Msg("Failed to publish event cancellation")
+		return 0, err
 	}
-	uc.sendNotificationsAsync(notifications, event)
-	uc.logger.Info().
-		Str("event_id", eventID).
-		Str("event_name", event.Name).
-		Int("total_bookings", len(bookings)).
-		Int("cancelled_bookings", cancelledCount).
-		Str("reason", reason).
-		Msg("Event cancelled successfully")
-	return nil
+	return cancelledCount, nil
 }
 
 func (uc *EventUsecase) validateEventCancellation(event *domain.Event) error {
@@ -113,115 +143,427 @@ func (uc *EventUsecase) validateEventCancellation(event *domain.Event) error {
 	if event.Date.Before(time.Now()) {
 		return ErrCannotCancelPastEvent
 	}
-	minCancellationTime := 24 * time.Hour
-	if time.Until(event.Date) < minCancellationTime {
+	if time.Until(event.Date) < event.Cancellation.Cutoff {
 		return ErrCancellationTooLate
 	}
 	return nil
 }
 
-func (uc *EventUsecase) cancelBookingInTx(ctx context.Context, tx *sql.Tx, booking *domain.Booking) error {
+func (uc *EventUsecase) cancelBookingInTx(ctx context.Context, tx *sql.Tx, event *domain.Event, booking *domain.Booking, reason string) error {
 	oldStatus := booking.Status
 	booking.Status = domain.BookingCancelled
 	if err := uc.bookingRepo.Update(ctx, tx, booking); err != nil {
 		return err
 	}
-	if err := uc.repo.IncrementAvailableSeats(ctx, tx, booking.EventID); err != nil {
+	if err := uc.repo.IncrementAvailableSeats(ctx, tx, booking.EventID, booking.Quantity); err != nil {
+		return err
+	}
+	if booking.TierID != nil {
+		if err := uc.repo.IncrementTierSeats(ctx, tx, *booking.TierID, booking.Quantity); err != nil {
+			return err
+		}
+	}
+	if err := uc.seatRepo.ReleaseSeats(ctx, tx, booking.ID); err != nil {
+		return err
+	}
+	if err := uc.promos.ReleaseInTx(ctx, tx, booking); err != nil {
+		return err
+	}
+	// Attendees are not at fault when the organizer cancels, so paid bookings
+	// are refunded in full regardless of the policy's tiers.
+	if _, err := uc.payments.SettleCancellationInTx(ctx, tx, booking, 100, "event cancelled"); err != nil {
+		return err
+	}
+	if err := uc.outbox.Enqueue(ctx, tx, &domain.Notification{
+		Kind:    domain.NotificationEventCancelled,
+		UserID:  booking.UserID,
+		Booking: booking,
+		Event:   event,
+		Reason:  reason,
+	}); err != nil {
+		return err
+	}
+	if err := uc.events.Publish(ctx, tx, &domain.DomainEvent{
+		Type:    domain.DomainBookingCancelled,
+		Event:   event,
+		Booking: booking,
+		Reason:  reason,
+	}); err != nil {
 		return err
 	}
 	uc.logger.Debug().
 		Str("booking_id", booking.ID).
 		Str("old_status", string(oldStatus)).
 		Str("new_status", string(booking.Status)).
+		Int("quantity", booking.Quantity).
 		Str("user_id", booking.UserID).
 		Msg("Booking cancelled in transaction")
 	return nil
 }
 
-type notificationData struct {
-	bookingID string
-	userID    string
-}
-
-func (uc *EventUsecase) sendNotificationsAsync(notifications []notificationData, event *domain.Event) {
-	if len(notifications) == 0 {
-		return
-	}
-	go func() {
-		notifyCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-		defer cancel()
-		uc.logger.Info().
-			Int("notification_count", len(notifications)).
-			Str("event_id", event.ID).
-			Msg("Starting async notification sending")
-		sentCount := 0
-		failedCount := 0
-		for _, data := range notifications {
-			select {
-			case <-notifyCtx.Done():
-				uc.logger.Warn().
-					Str("event_id", event.ID).
-					Msg("Notification sending cancelled due to timeout")
-				return
-			default:
-				if err := uc.sendSingleNotification(notifyCtx, data.userID, data.bookingID, event); err != nil {
-					failedCount++
-					uc.logger.Error().
-						Err(err).
-						Str("user_id", data.userID).
-						Str("booking_id", data.bookingID).
-						Msg("Failed to send notification")
-				} else {
-					sentCount++
-				}
-			}
-		}
-		uc.logger.Info().
-			Str("event_id", event.ID).
-			Int("sent", sentCount).
-			Int("failed", failedCount).
-			Int("total", len(notifications)).
-			Msg("Notification sending completed")
-	}()
-}
-
-func (uc *EventUsecase) sendSingleNotification(ctx context.Context, userID, bookingID string, event *domain.Event) error {
-	user, err := uc.userRepo.GetByID(ctx, userID)
+// CreateEvent stores a new active event for input.OrganizerID, built from the
+// descriptive fields of input; identifiers, availability and timestamps are
+// assigned here. Only admins may create events without an organizer. An event
+// with a layout gets one seat per layout seat and a matching capacity.
+func (uc *EventUsecase) CreateEvent(ctx context.Context, input *domain.Event, actor *domain.Principal) (*domain.Event, error) {
+	if !actor.CanManageEvents(input.OrganizerID) {
+		return nil, ErrForbidden
+	}
+	event, err := uc.newEvent(ctx, input)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	notificationBooking := &domain.Booking{
-		ID:      bookingID,
-		EventID: event.ID,
-		UserID:  userID,
-		Status:  domain.BookingCancelled,
+	tx, err := uc.db.BeginTx(ctx, nil)
+	if err != nil {
+		uc.logger.Error().Err(err).Msg("Failed to begin transaction")
+		return nil, err
 	}
-	if err := uc.notifier.NotifyCancellation(user, notificationBooking); err != nil {
-		return err
+	defer tx.Rollback()
+	if err := uc.createEventInTx(ctx, tx, event); err != nil {
+		return nil, err
 	}
-	return nil
+	if err := tx.Commit(); err != nil {
+		uc.logger.Error().Err(err).Str("event_id", event.ID).Msg("Failed to commit transaction")
+		return nil, err
+	}
+	return event, nil
 }
 
-func (uc *EventUsecase) CreateEvent(ctx context.Context, name string, date time.Time, totalSeats int, ttl time.Duration, requiresPayment bool) (*domain.Event, error) {
+// newEvent validates input and builds the event to be stored from it.
+func (uc *EventUsecase) newEvent(ctx context.Context, input *domain.Event) (*domain.Event, error) {
+	totalSeats := input.TotalSeats
+	if input.LayoutID != nil {
+		count, err := uc.seatRepo.CountLayoutSeats(ctx, *input.LayoutID)
+		if err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				return nil, ErrLayoutNotFound
+			}
+			return nil, err
+		}
+		totalSeats = count
+	}
+	if totalSeats <= 0 {
+		return nil, ErrInvalidCapacity
+	}
+	if input.MaxPerBooking < 0 || input.MaxPerBooking > totalSeats {
+		return nil, ErrInvalidMaxPerBooking
+	}
+	if input.RequiresPayment && input.Price <= 0 {
+		return nil, ErrPriceRequired
+	}
+	if !input.Cancellation.Valid() {
+		return nil, ErrInvalidPolicy
+	}
+	if input.OrganizerID != nil {
+		if err := uc.checkOrganizer(ctx, *input.OrganizerID); err != nil {
+			return nil, err
+		}
+	}
+	currency := input.Currency
+	if currency == "" {
+		currency = defaultCurrency
+	}
 	now := time.Now()
 	event := &domain.Event{
 		ID:              uuid.NewString(),
-		Name:            name,
-		Date:            date,
+		Name:            input.Name,
+		Date:            input.Date,
 		TotalSeats:      totalSeats,
 		Available:       totalSeats,
-		BookingTTL:      ttl,
-		RequiresPayment: requiresPayment,
+		MaxPerBooking:   input.MaxPerBooking,
+		BookingTTL:      input.BookingTTL,
+		RequiresPayment: input.RequiresPayment,
+		Price:           input.Price,
+		Currency:        currency,
+		Cancellation:    input.Cancellation,
+		LayoutID:        input.LayoutID,
+		VenueID:         input.VenueID,
+		OrganizerID:     input.OrganizerID,
 		Status:          domain.EventActive,
 		CreatedAt:       now,
 		UpdatedAt:       now,
 	}
-	if err := uc.repo.Create(ctx, event); err != nil {
+	return event, nil
+}
+
+func (uc *EventUsecase) createEventInTx(ctx context.Context, tx *sql.Tx, event *domain.Event) error {
+	if err := uc.checkVenueCapacity(ctx, tx, event); err != nil {
+		return err
+	}
+	if err := uc.repo.Create(ctx, tx, event); err != nil {
+		return err
+	}
+	if event.ReservedSeating() {
+		if _, err := uc.seatRepo.CreateInventory(ctx, tx, event.ID, *event.LayoutID); err != nil {
+			uc.logger.Error().Err(err).Str("event_id", event.ID).Msg("Failed to create seat inventory")
+			return err
+		}
+	}
+	if err := uc.events.Publish(ctx, tx, &domain.DomainEvent{
+		Type:  domain.DomainEventCreated,
+		Event: event,
+	}); err != nil {
+		uc.logger.Error().Err(err).Str("event_id", event.ID).Msg("Failed to publish event creation")
+		return err
+	}
+	return nil
+}
+
+func (uc *EventUsecase) UpdateCapacity(ctx context.Context, eventID string, totalSeats int, actor *domain.Principal) (*domain.Event, error) {
+	return uc.UpdateEvent(ctx, eventID, &domain.EventPatch{TotalSeats: &totalSeats}, actor)
+}
+
+// UpdateEvent applies patch to an active event. Availability is recomputed from
+// the seats held by pending and confirmed bookings, freed seats are offered to
+// the waitlist and attendees are notified when the date moves.
+func (uc *EventUsecase) UpdateEvent(ctx context.Context, eventID string, patch *domain.EventPatch, actor *domain.Principal) (*domain.Event, error) {
+	tx, err := uc.db.BeginTx(ctx, nil)
+	if err != nil {
+		uc.logger.Error().Err(err).Str("event_id", eventID).Msg("Failed to begin transaction")
+		return nil, err
+	}
+	defer tx.Rollback()
+	event, err := uc.lockActiveEvent(ctx, tx, eventID, actor)
+	if err != nil {
 		return nil, err
 	}
+	promoted, rescheduled, err := uc.updateEventInTx(ctx, tx, event, patch, actor)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		uc.logger.Error().Err(err).Str("event_id", eventID).Msg("Failed to commit transaction")
+		return nil, err
+	}
+	for _, booking := range promoted {
+		event.Available -= booking.Quantity
+	}
+	uc.logger.Info().
+		Str("event_id", eventID).
+		Int("total_seats", event.TotalSeats).
+		Int("available", event.Available).
+		Int("promoted", len(promoted)).
+		Bool("rescheduled", rescheduled).
+		Msg("Event updated")
 	return event, nil
 }
 
+// updateEventInTx applies patch to a locked active event and returns the
+// bookings promoted from the waitlist and whether the event moved. Moving the
+// event to another organizer needs the right to manage events there too.
+func (uc *EventUsecase) updateEventInTx(ctx context.Context, tx *sql.Tx, event *domain.Event, patch *domain.EventPatch, actor *domain.Principal) ([]*domain.Booking, bool, error) {
+	eventID := event.ID
+	previousDate := event.Date
+	if patch.OrganizerID != nil {
+		if !actor.CanManageEvents(optionalID(*patch.OrganizerID)) {
+			return nil, false, ErrForbidden
+		}
+		if *patch.OrganizerID != "" {
+			if err := uc.checkOrganizer(ctx, *patch.OrganizerID); err != nil {
+				return nil, false, err
+			}
+		}
+	}
+	if err := applyPatch(event, patch); err != nil {
+		return nil, false, err
+	}
+	if patch.TotalSeats != nil || patch.VenueID != nil {
+		if err := uc.checkVenueCapacity(ctx, tx, event); err != nil {
+			return nil, false, err
+		}
+	}
+	reserved, err := uc.bookingRepo.GetReservedSeats(ctx, tx, eventID)
+	if err != nil {
+		uc.logger.Error().Err(err).Str("event_id", eventID).Msg("Failed to count reserved seats")
+		return nil, false, err
+	}
+	if event.TotalSeats < reserved {
+		return nil, false, ErrCapacityBelowReserved
+	}
+	if patch.TotalSeats != nil {
+		if err := uc.checkTierQuotas(ctx, tx, event, "", 0); err != nil {
+			return nil, false, err
+		}
+	}
+	event.Available = event.TotalSeats - reserved
+	event.UpdatedAt = time.Now()
+	if err := uc.repo.Update(ctx, tx, event); err != nil {
+		uc.logger.Error().Err(err).Str("event_id", eventID).Msg("Failed to update event")
+		return nil, false, err
+	}
+	promoted, err := uc.waitlist.PromoteInTx(ctx, tx, eventID)
+	if err != nil {
+		uc.logger.Error().Err(err).Str("event_id", eventID).Msg("Failed to promote waitlist")
+		return nil, false, err
+	}
+	rescheduled := !event.Date.Equal(previousDate)
+	if rescheduled {
+		if err := uc.reminders.ResetForEvent(ctx, tx, eventID); err != nil {
+			uc.logger.Error().Err(err).Str("event_id", eventID).Msg("Failed to reset event reminders")
+			return nil, false, err
+		}
+		if err := uc.notifyRescheduled(ctx, tx, event, previousDate); err != nil {
+			uc.logger.Error().Err(err).Str("event_id", eventID).Msg("Failed to enqueue reschedule notifications")
+			return nil, false, err
+		}
+	}
+	return promoted, rescheduled, nil
+}
+
+func applyPatch(event *domain.Event, patch *domain.EventPatch) error {
+	if patch.Name != nil {
+		if *patch.Name == "" {
+			return ErrInvalidName
+		}
+		event.Name = *patch.Name
+	}
+	if patch.Date != nil {
+		if patch.Date.Before(time.Now()) {
+			return ErrEventDateInPast
+		}
+		event.Date = *patch.Date
+	}
+	if patch.BookingTTL != nil {
+		if *patch.BookingTTL <= 0 {
+			return ErrInvalidBookingTTL
+		}
+		event.BookingTTL = *patch.BookingTTL
+	}
+	if patch.Price != nil {
+		if *patch.Price < 0 {
+			return ErrInvalidPrice
+		}
+		event.Price = *patch.Price
+	}
+	if patch.Currency != nil {
+		if len(*patch.Currency) != 3 {
+			return ErrInvalidCurrency
+		}
+		event.Currency = *patch.Currency
+	}
+	if patch.RequiresPayment != nil {
+		event.RequiresPayment = *patch.RequiresPayment
+	}
+	if event.RequiresPayment && event.Price <= 0 {
+		return ErrPriceRequired
+	}
+	if patch.TotalSeats != nil {
+		if event.ReservedSeating() && *patch.TotalSeats != event.TotalSeats {
+			return ErrCapacityFixedByLayout
+		}
+		if *patch.TotalSeats <= 0 {
+			return ErrInvalidCapacity
+		}
+		event.TotalSeats = *patch.TotalSeats
+	}
+	if patch.VenueID != nil {
+		event.VenueID = optionalID(*patch.VenueID)
+	}
+	if patch.OrganizerID != nil {
+		event.OrganizerID = optionalID(*patch.OrganizerID)
+	}
+	if patch.MaxPerBooking != nil {
+		if *patch.MaxPerBooking < 0 || *patch.MaxPerBooking > event.TotalSeats {
+			return ErrInvalidMaxPerBooking
+		}
+		event.MaxPerBooking = *patch.MaxPerBooking
+	} else if event.MaxPerBooking > event.TotalSeats {
+		event.MaxPerBooking = event.TotalSeats
+	}
+	return nil
+}
+
+// optionalID maps the empty string, used by patches to unlink a reference, to
+// nil.
+func optionalID(id string) *string {
+	if id == "" {
+		return nil
+	}
+	return &id
+}
+
+func (uc *EventUsecase) checkOrganizer(ctx context.Context, organizerID string) error {
+	if _, err := uc.orgRepo.GetByID(ctx, organizerID); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrOrganizerNotFound
+		}
+		uc.logger.Error().Err(err).Str("organizer_id", organizerID).Msg("Failed to get organizer")
+		return err
+	}
+	return nil
+}
+
+// checkVenueCapacity verifies that event fits its venue. The venue row stays
+// share-locked until tx ends so that its capacity cannot be lowered
+// concurrently.
+func (uc *EventUsecase) checkVenueCapacity(ctx context.Context, tx *sql.Tx, event *domain.Event) error {
+	if event.VenueID == nil {
+		return nil
+	}
+	venue, err := uc.venueRepo.GetForShare(ctx, tx, *event.VenueID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrVenueNotFound
+		}
+		uc.logger.Error().Err(err).Str("venue_id", *event.VenueID).Msg("Failed to get venue")
+		return err
+	}
+	if event.TotalSeats > venue.Capacity {
+		return ErrCapacityExceedsVenue
+	}
+	return nil
+}
+
+func (uc *EventUsecase) notifyRescheduled(ctx context.Context, tx *sql.Tx, event *domain.Event, previousDate time.Time) error {
+	bookings, err := uc.bookingRepo.GetByEventID(ctx, event.ID)
+	if err != nil {
+		return err
+	}
+	for _, booking := range bookings {
+		if booking.Status == domain.BookingCancelled {
+			continue
+		}
+		if err := uc.outbox.Enqueue(ctx, tx, &domain.Notification{
+			Kind:         domain.NotificationEventRescheduled,
+			UserID:       booking.UserID,
+			Booking:      booking,
+			Event:        event,
+			PreviousDate: &previousDate,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CompletePastEvents moves active events whose start time has passed to
+// completed and closes their waitlists. It returns the number of events
+// completed.
+func (uc *EventUsecase) CompletePastEvents(ctx context.Context) (int, error) {
+	tx, err := uc.db.BeginTx(ctx, nil)
+	if err != nil {
+		uc.logger.Error().Err(err).Msg("Failed to begin transaction")
+		return 0, err
+	}
+	defer tx.Rollback()
+	ids, err := uc.repo.CompletePast(ctx, tx, time.Now())
+	if err != nil {
+		uc.logger.Error().Err(err).Msg("Failed to complete past events")
+		return 0, err
+	}
+	for _, id := range ids {
+		if err := uc.waitlist.CancelForEventInTx(ctx, tx, id); err != nil {
+			uc.logger.Error().Err(err).Str("event_id", id).Msg("Failed to cancel waitlist entries")
+			return 0, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		uc.logger.Error().Err(err).Msg("Failed to commit transaction")
+		return 0, err
+	}
+	return len(ids), nil
+}
+
 func (uc *EventUsecase) GetEvent(ctx context.Context, id string) (*domain.Event, error) {
 	event, err := uc.repo.GetByID(ctx, id)
 	if err != nil {
@@ -233,6 +575,55 @@ func (uc *EventUsecase) GetEvent(ctx context.Context, id string) (*domain.Event,
 	return event, nil
 }
 
-func (uc *EventUsecase) ListEvents(ctx context.Context) ([]*domain.Event, error) {
-	return uc.repo.GetAll(ctx)
+// ListEvents returns one page of the public event catalog. The limit defaults
+// to defaultPageSize and is capped at maxPageSize.
+func (uc *EventUsecase) ListEvents(ctx context.Context, filter domain.EventFilter) (*domain.Page[*domain.Event], error) {
+	filter.OrganizerIDs = nil
+	return uc.listEvents(ctx, filter)
+}
+
+// ListManagedEvents returns one page of the events actor manages: all of them
+// for admins, those of their own organizers for organizer staff.
+func (uc *EventUsecase) ListManagedEvents(ctx context.Context, filter domain.EventFilter, actor *domain.Principal) (*domain.Page[*domain.Event], error) {
+	if !actor.IsStaff() {
+		return nil, ErrForbidden
+	}
+	filter.OrganizerIDs = nil
+	if actor.ScopedToOrganizers() {
+		filter.OrganizerIDs = actor.OrganizerIDs()
+	}
+	return uc.listEvents(ctx, filter)
+}
+
+func (uc *EventUsecase) listEvents(ctx context.Context, filter domain.EventFilter) (*domain.Page[*domain.Event], error) {
+	switch filter.Status {
+	case "", domain.EventActive, domain.EventCancelled, domain.EventCompleted:
+	default:
+		return nil, ErrInvalidFilter
+	}
+	switch filter.Sort {
+	case "":
+		filter.Sort = domain.EventSortDateAsc
+	case domain.EventSortDateAsc, domain.EventSortDateDesc, domain.EventSortCreatedDesc, domain.EventSortNameAsc:
+	default:
+		return nil, ErrInvalidFilter
+	}
+	if filter.Limit < 0 {
+		return nil, ErrInvalidFilter
+	}
+	if filter.Limit == 0 {
+		filter.Limit = defaultPageSize
+	}
+	if filter.Limit > maxPageSize {
+		filter.Limit = maxPageSize
+	}
+	page, err := uc.repo.List(ctx, filter)
+	if err != nil {
+		if errors.Is(err, repository.ErrInvalidCursor) {
+			return nil, ErrInvalidCursor
+		}
+		uc.logger.Error().Err(err).Msg("failed to list events")
+		return nil, err
+	}
+	return page, nil
 }
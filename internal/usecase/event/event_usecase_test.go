@@ -0,0 +1,108 @@
+package event_uc
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"event-booker/internal/domain"
+	"event-booker/internal/repository"
+
+	"github.com/wb-go/wbf/zlog"
+)
+
+type fakeEventRepo struct {
+	eventRepository
+	events map[string]*domain.Event
+	series map[string]*domain.EventSeries
+	filter domain.EventFilter
+}
+
+func (r *fakeEventRepo) GetSeries(_ context.Context, id string) (*domain.EventSeries, error) {
+	series, ok := r.series[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	return series, nil
+}
+
+func (r *fakeEventRepo) GetByID(_ context.Context, id string) (*domain.Event, error) {
+	event, ok := r.events[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	return event, nil
+}
+
+func (r *fakeEventRepo) List(_ context.Context, filter domain.EventFilter) (*domain.Page[*domain.Event], error) {
+	r.filter = filter
+	return &domain.Page[*domain.Event]{}, nil
+}
+
+func TestEventReadsArePublic(t *testing.T) {
+	orgA, orgB := "org-a", "org-b"
+	repo := &fakeEventRepo{events: map[string]*domain.Event{
+		"event-b": {ID: "event-b", OrganizerID: &orgB, Status: domain.EventActive},
+	}}
+	logger := zlog.Zerolog{}
+	uc := NewEventUsecase(nil, repo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, &logger)
+	staff := &domain.Principal{UserID: "u1", Role: domain.RoleUser, Memberships: map[string]domain.OrganizerRole{orgA: domain.OrganizerCheckIn}}
+	ctx := context.Background()
+
+	event, err := uc.GetEvent(ctx, "event-b")
+	if err != nil || event.ID != "event-b" {
+		t.Fatalf("GetEvent of another organizer's event = %v, %v", event, err)
+	}
+	if _, err := uc.ListEvents(ctx, domain.EventFilter{OrganizerIDs: []string{orgA}}); err != nil {
+		t.Fatal(err)
+	}
+	if repo.filter.OrganizerIDs != nil {
+		t.Errorf("ListEvents scoped the catalog to %v", repo.filter.OrganizerIDs)
+	}
+
+	if _, err := uc.ListManagedEvents(ctx, domain.EventFilter{}, staff); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(repo.filter.OrganizerIDs, []string{orgA}) {
+		t.Errorf("ListManagedEvents for staff filtered by %v, want [%s]", repo.filter.OrganizerIDs, orgA)
+	}
+	admin := &domain.Principal{UserID: "admin", Role: domain.RoleAdmin}
+	if _, err := uc.ListManagedEvents(ctx, domain.EventFilter{}, admin); err != nil {
+		t.Fatal(err)
+	}
+	if repo.filter.OrganizerIDs != nil {
+		t.Errorf("ListManagedEvents for admins filtered by %v", repo.filter.OrganizerIDs)
+	}
+	customer := &domain.Principal{UserID: "u2", Role: domain.RoleUser}
+	if _, err := uc.ListManagedEvents(ctx, domain.EventFilter{}, customer); !errors.Is(err, ErrForbidden) {
+		t.Errorf("ListManagedEvents for a customer: error = %v, want %v", err, ErrForbidden)
+	}
+}
+
+func TestSeriesChangesCheckOrganizerFirst(t *testing.T) {
+	orgA, orgB := "org-a", "org-b"
+	repo := &fakeEventRepo{series: map[string]*domain.EventSeries{
+		"series-b": {ID: "series-b", OrganizerID: &orgB},
+	}}
+	logger := zlog.Zerolog{}
+	// A nil database makes any attempt to lock occurrences panic.
+	uc := NewEventUsecase(nil, repo, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, &logger)
+	staff := &domain.Principal{UserID: "u1", Role: domain.RoleUser, Memberships: map[string]domain.OrganizerRole{orgA: domain.OrganizerOwner}}
+	ctx := context.Background()
+
+	if _, err := uc.GetSeries(ctx, "series-b"); err != nil {
+		t.Errorf("GetSeries: %v", err)
+	}
+	name := "Renamed"
+	if _, err := uc.UpdateSeries(ctx, "series-b", &domain.EventPatch{Name: &name}, time.Now(), staff); !errors.Is(err, ErrForbidden) {
+		t.Errorf("UpdateSeries by another organizer: error = %v, want %v", err, ErrForbidden)
+	}
+	if _, err := uc.CancelSeries(ctx, "series-b", "", time.Now(), staff); !errors.Is(err, ErrForbidden) {
+		t.Errorf("CancelSeries by another organizer: error = %v, want %v", err, ErrForbidden)
+	}
+	if _, err := uc.CancelSeries(ctx, "missing", "", time.Now(), staff); !errors.Is(err, ErrSeriesNotFound) {
+		t.Errorf("CancelSeries of a missing series: error = %v, want %v", err, ErrSeriesNotFound)
+	}
+}
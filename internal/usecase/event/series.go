@@ -0,0 +1,223 @@
+package event_uc
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"event-booker/internal/domain"
+	"event-booker/internal/metrics"
+	"event-booker/internal/recurrence"
+	"event-booker/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+// CreateSeries creates a recurring event series and all of its occurrences,
+// each built from input with the date given by the recurrence rule.
+func (uc *EventUsecase) CreateSeries(ctx context.Context, input *domain.Event, rule string, actor *domain.Principal) (*domain.EventSeries, []*domain.Event, error) {
+	if !actor.CanManageEvents(input.OrganizerID) {
+		return nil, nil, ErrForbidden
+	}
+	parsed, err := recurrence.Parse(rule)
+	if err != nil {
+		return nil, nil, ErrInvalidRecurrence
+	}
+	dates, err := parsed.Occurrences(input.Date)
+	if err != nil {
+		if errors.Is(err, recurrence.ErrTooManyOccurrences) {
+			return nil, nil, ErrTooManyOccurrences
+		}
+		return nil, nil, ErrInvalidRecurrence
+	}
+	if len(dates) == 0 {
+		return nil, nil, ErrNoOccurrences
+	}
+	now := time.Now()
+	series := &domain.EventSeries{
+		ID:          uuid.NewString(),
+		Name:        input.Name,
+		Recurrence:  parsed.String(),
+		Start:       input.Date,
+		OrganizerID: input.OrganizerID,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	occurrences := make([]*domain.Event, 0, len(dates))
+	for _, date := range dates {
+		occurrence := *input
+		occurrence.Date = date
+		event, err := uc.newEvent(ctx, &occurrence)
+		if err != nil {
+			return nil, nil, err
+		}
+		event.SeriesID = &series.ID
+		occurrences = append(occurrences, event)
+	}
+	tx, err := uc.db.BeginTx(ctx, nil)
+	if err != nil {
+		uc.logger.Error().Err(err).Msg("Failed to begin transaction")
+		return nil, nil, err
+	}
+	defer tx.Rollback()
+	if err := uc.repo.CreateSeries(ctx, tx, series); err != nil {
+		uc.logger.Error().Err(err).Str("series_id", series.ID).Msg("Failed to create event series")
+		return nil, nil, err
+	}
+	for _, event := range occurrences {
+		if err := uc.createEventInTx(ctx, tx, event); err != nil {
+			return nil, nil, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		uc.logger.Error().Err(err).Str("series_id", series.ID).Msg("Failed to commit transaction")
+		return nil, nil, err
+	}
+	uc.logger.Info().
+		Str("series_id", series.ID).
+		Str("recurrence", series.Recurrence).
+		Int("occurrences", len(occurrences)).
+		Msg("Event series created")
+	return series, occurrences, nil
+}
+
+// GetSeries returns a series definition. Like its occurrences, it is public.
+func (uc *EventUsecase) GetSeries(ctx context.Context, id string) (*domain.EventSeries, error) {
+	series, err := uc.repo.GetSeries(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrSeriesNotFound
+		}
+		uc.logger.Error().Err(err).Str("series_id", id).Msg("Failed to get event series")
+		return nil, err
+	}
+	return series, nil
+}
+
+// getManagedSeries returns a series actor may manage. It is checked before any
+// occurrence is locked, so callers from other organizers are turned away
+// without touching the events.
+func (uc *EventUsecase) getManagedSeries(ctx context.Context, id string, actor *domain.Principal) (*domain.EventSeries, error) {
+	series, err := uc.GetSeries(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !actor.CanManageEvents(series.OrganizerID) {
+		return nil, ErrForbidden
+	}
+	return series, nil
+}
+
+// UpdateSeries applies patch to every active occurrence of the series dated
+// at or after from (or now, whichever is later). Occurrence dates are fixed by
+// the recurrence rule and cannot be patched in bulk.
+func (uc *EventUsecase) UpdateSeries(ctx context.Context, seriesID string, patch *domain.EventPatch, from time.Time, actor *domain.Principal) ([]*domain.Event, error) {
+	if patch.Date != nil {
+		return nil, ErrSeriesDatePatch
+	}
+	if _, err := uc.getManagedSeries(ctx, seriesID, actor); err != nil {
+		return nil, err
+	}
+	tx, err := uc.db.BeginTx(ctx, nil)
+	if err != nil {
+		uc.logger.Error().Err(err).Msg("Failed to begin transaction")
+		return nil, err
+	}
+	defer tx.Rollback()
+	ids, err := uc.repo.ListSeriesEventIDs(ctx, tx, seriesID, latest(from, time.Now()))
+	if err != nil {
+		uc.logger.Error().Err(err).Str("series_id", seriesID).Msg("Failed to list series occurrences")
+		return nil, err
+	}
+	var updated []*domain.Event
+	promotedCount := 0
+	for _, id := range ids {
+		event, err := uc.lockActiveEvent(ctx, tx, id, actor)
+		if errors.Is(err, ErrEventNotActive) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		promoted, _, err := uc.updateEventInTx(ctx, tx, event, patch, actor)
+		if err != nil {
+			return nil, err
+		}
+		for _, booking := range promoted {
+			event.Available -= booking.Quantity
+		}
+		promotedCount += len(promoted)
+		updated = append(updated, event)
+	}
+	if err := tx.Commit(); err != nil {
+		uc.logger.Error().Err(err).Str("series_id", seriesID).Msg("Failed to commit transaction")
+		return nil, err
+	}
+	uc.logger.Info().
+		Str("series_id", seriesID).
+		Int("updated", len(updated)).
+		Int("promoted", promotedCount).
+		Msg("Event series updated")
+	return updated, nil
+}
+
+// CancelSeries cancels every occurrence of the series dated at or after from
+// (or now, whichever is later) and returns the IDs of the cancelled events.
+// Occurrences that are already cancelled or within their cancellation cutoff
+// are left as they are. Any occurrence the actor may not manage aborts the
+// whole operation.
+func (uc *EventUsecase) CancelSeries(ctx context.Context, seriesID, reason string, from time.Time, actor *domain.Principal) ([]string, error) {
+	if _, err := uc.getManagedSeries(ctx, seriesID, actor); err != nil {
+		return nil, err
+	}
+	tx, err := uc.db.BeginTx(ctx, nil)
+	if err != nil {
+		uc.logger.Error().Err(err).Msg("Failed to begin transaction")
+		return nil, err
+	}
+	defer tx.Rollback()
+	ids, err := uc.repo.ListSeriesEventIDs(ctx, tx, seriesID, latest(from, time.Now()))
+	if err != nil {
+		uc.logger.Error().Err(err).Str("series_id", seriesID).Msg("Failed to list series occurrences")
+		return nil, err
+	}
+	cancelled := []string{}
+	bookingsCancelled := 0
+	for _, id := range ids {
+		event, err := uc.lockActiveEvent(ctx, tx, id, actor)
+		if errors.Is(err, ErrEventNotActive) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if uc.validateEventCancellation(event) != nil {
+			continue
+		}
+		count, err := uc.cancelEventInTx(ctx, tx, event, reason)
+		if err != nil {
+			return nil, err
+		}
+		bookingsCancelled += count
+		cancelled = append(cancelled, event.ID)
+	}
+	if err := tx.Commit(); err != nil {
+		uc.logger.Error().Err(err).Str("series_id", seriesID).Msg("Failed to commit transaction")
+		return nil, err
+	}
+	metrics.ObserveBooking(metrics.BookingCancelled, bookingsCancelled)
+	uc.logger.Info().
+		Str("series_id", seriesID).
+		Int("cancelled_events", len(cancelled)).
+		Int("cancelled_bookings", bookingsCancelled).
+		Str("reason", reason).
+		Msg("Event series cancelled")
+	return cancelled, nil
+}
+
+func latest(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,245 @@
+package event_uc
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"event-booker/internal/domain"
+	"event-booker/internal/repository"
+
+	"github.com/google/uuid"
+)
+
+// CreateTier adds a ticket tier to an active event. The quotas of all tiers
+// together may not exceed the event's capacity; once an event has tiers every
+// booking must name one.
+func (uc *EventUsecase) CreateTier(ctx context.Context, eventID string, input *domain.TicketTier, actor *domain.Principal) (*domain.TicketTier, error) {
+	tx, err := uc.db.BeginTx(ctx, nil)
+	if err != nil {
+		uc.logger.Error().Err(err).Str("event_id", eventID).Msg("Failed to begin transaction")
+		return nil, err
+	}
+	defer tx.Rollback()
+	event, err := uc.lockActiveEvent(ctx, tx, eventID, actor)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	tier := &domain.TicketTier{
+		ID:        uuid.NewString(),
+		EventID:   eventID,
+		CreatedAt: now,
+	}
+	if err := applyTier(tier, input, event); err != nil {
+		return nil, err
+	}
+	if err := uc.checkTierQuotas(ctx, tx, event, "", tier.Quota); err != nil {
+		return nil, err
+	}
+	tier.Available = tier.Quota
+	tier.UpdatedAt = now
+	if err := uc.repo.CreateTier(ctx, tx, tier); err != nil {
+		if errors.Is(err, repository.ErrAlreadyExists) {
+			return nil, ErrTierExists
+		}
+		uc.logger.Error().Err(err).Str("event_id", eventID).Msg("Failed to create ticket tier")
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		uc.logger.Error().Err(err).Str("event_id", eventID).Msg("Failed to commit transaction")
+		return nil, err
+	}
+	uc.logger.Info().
+		Str("event_id", eventID).
+		Str("tier_id", tier.ID).
+		Str("name", tier.Name).
+		Int("quota", tier.Quota).
+		Msg("Ticket tier created")
+	return tier, nil
+}
+
+func (uc *EventUsecase) ListTiers(ctx context.Context, eventID string) ([]*domain.TicketTier, error) {
+	if _, err := uc.GetEvent(ctx, eventID); err != nil {
+		return nil, err
+	}
+	return uc.repo.ListTiers(ctx, nil, eventID)
+}
+
+// UpdateTier replaces the settings of a tier. Its availability is recomputed
+// from the seats held by pending and confirmed bookings, and tickets freed by
+// a larger quota are offered to the waitlist.
+func (uc *EventUsecase) UpdateTier(ctx context.Context, eventID, tierID string, input *domain.TicketTier, actor *domain.Principal) (*domain.TicketTier, error) {
+	tx, err := uc.db.BeginTx(ctx, nil)
+	if err != nil {
+		uc.logger.Error().Err(err).Str("event_id", eventID).Msg("Failed to begin transaction")
+		return nil, err
+	}
+	defer tx.Rollback()
+	event, err := uc.lockActiveEvent(ctx, tx, eventID, actor)
+	if err != nil {
+		return nil, err
+	}
+	tier, err := uc.getTierForUpdate(ctx, tx, eventID, tierID)
+	if err != nil {
+		return nil, err
+	}
+	if err := applyTier(tier, input, event); err != nil {
+		return nil, err
+	}
+	reserved, err := uc.bookingRepo.GetReservedTierSeats(ctx, tx, tierID)
+	if err != nil {
+		uc.logger.Error().Err(err).Str("tier_id", tierID).Msg("Failed to count reserved tier seats")
+		return nil, err
+	}
+	if tier.Quota < reserved {
+		return nil, ErrQuotaBelowReserved
+	}
+	if err := uc.checkTierQuotas(ctx, tx, event, tierID, tier.Quota); err != nil {
+		return nil, err
+	}
+	tier.Available = tier.Quota - reserved
+	tier.UpdatedAt = time.Now()
+	if err := uc.repo.UpdateTier(ctx, tx, tier); err != nil {
+		if errors.Is(err, repository.ErrAlreadyExists) {
+			return nil, ErrTierExists
+		}
+		uc.logger.Error().Err(err).Str("tier_id", tierID).Msg("Failed to update ticket tier")
+		return nil, err
+	}
+	promoted, err := uc.waitlist.PromoteInTx(ctx, tx, eventID)
+	if err != nil {
+		uc.logger.Error().Err(err).Str("event_id", eventID).Msg("Failed to promote waitlist")
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		uc.logger.Error().Err(err).Str("event_id", eventID).Msg("Failed to commit transaction")
+		return nil, err
+	}
+	for _, booking := range promoted {
+		if booking.TierID != nil && *booking.TierID == tier.ID {
+			tier.Available -= booking.Quantity
+		}
+	}
+	return tier, nil
+}
+
+func (uc *EventUsecase) DeleteTier(ctx context.Context, eventID, tierID string, actor *domain.Principal) error {
+	tx, err := uc.db.BeginTx(ctx, nil)
+	if err != nil {
+		uc.logger.Error().Err(err).Str("event_id", eventID).Msg("Failed to begin transaction")
+		return err
+	}
+	defer tx.Rollback()
+	if _, err := uc.lockActiveEvent(ctx, tx, eventID, actor); err != nil {
+		return err
+	}
+	if _, err := uc.getTierForUpdate(ctx, tx, eventID, tierID); err != nil {
+		return err
+	}
+	if err := uc.repo.DeleteTier(ctx, tx, tierID); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrNotFound):
+			return ErrTierNotFound
+		case errors.Is(err, repository.ErrInUse):
+			return ErrTierInUse
+		}
+		uc.logger.Error().Err(err).Str("tier_id", tierID).Msg("Failed to delete ticket tier")
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		uc.logger.Error().Err(err).Str("event_id", eventID).Msg("Failed to commit transaction")
+		return err
+	}
+	return nil
+}
+
+// lockActiveEvent locks an active event that actor may manage.
+func (uc *EventUsecase) lockActiveEvent(ctx context.Context, tx *sql.Tx, eventID string, actor *domain.Principal) (*domain.Event, error) {
+	event, err := uc.repo.GetForUpdate(ctx, tx, eventID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrEventNotFound
+		}
+		uc.logger.Error().Err(err).Str("event_id", eventID).Msg("Failed to get event for update")
+		return nil, err
+	}
+	if !actor.CanManageEvents(event.OrganizerID) {
+		return nil, ErrForbidden
+	}
+	if event.Status != domain.EventActive {
+		return nil, ErrEventNotActive
+	}
+	return event, nil
+}
+
+func (uc *EventUsecase) getTierForUpdate(ctx context.Context, tx *sql.Tx, eventID, tierID string) (*domain.TicketTier, error) {
+	tier, err := uc.repo.GetTierForUpdate(ctx, tx, tierID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrTierNotFound
+		}
+		uc.logger.Error().Err(err).Str("tier_id", tierID).Msg("Failed to get ticket tier for update")
+		return nil, err
+	}
+	if tier.EventID != eventID {
+		return nil, ErrTierNotFound
+	}
+	return tier, nil
+}
+
+// checkTierQuotas verifies that the tier quotas of event fit its capacity,
+// counting quota in place of the current quota of the tier with ID tierID (or
+// in addition to the existing tiers when tierID is empty).
+func (uc *EventUsecase) checkTierQuotas(ctx context.Context, tx *sql.Tx, event *domain.Event, tierID string, quota int) error {
+	tiers, err := uc.repo.ListTiers(ctx, tx, event.ID)
+	if err != nil {
+		uc.logger.Error().Err(err).Str("event_id", event.ID).Msg("Failed to list ticket tiers")
+		return err
+	}
+	total := quota
+	for _, tier := range tiers {
+		if tier.ID != tierID {
+			total += tier.Quota
+		}
+	}
+	if total > event.TotalSeats {
+		return ErrQuotaExceedsCapacity
+	}
+	return nil
+}
+
+// applyTier validates input and copies its settings onto tier. The currency
+// defaults to the event's, and paid events need a positive price per tier.
+func applyTier(tier *domain.TicketTier, input *domain.TicketTier, event *domain.Event) error {
+	if input.Name == "" {
+		return ErrInvalidTierName
+	}
+	if input.Price < 0 {
+		return ErrInvalidPrice
+	}
+	if event.RequiresPayment && input.Price <= 0 {
+		return ErrPriceRequired
+	}
+	currency := input.Currency
+	if currency == "" {
+		currency = event.Currency
+	}
+	if len(currency) != 3 {
+		return ErrInvalidCurrency
+	}
+	if input.Quota <= 0 {
+		return ErrInvalidQuota
+	}
+	if input.SalesStart != nil && input.SalesEnd != nil && !input.SalesStart.Before(*input.SalesEnd) {
+		return ErrInvalidSalesWindow
+	}
+	tier.Name = input.Name
+	tier.Price = input.Price
+	tier.Currency = currency
+	tier.Quota = input.Quota
+	tier.SalesStart = input.SalesStart
+	tier.SalesEnd = input.SalesEnd
+	return nil
+}
@@ -0,0 +1,17 @@
+package idempotency_uc
+
+import (
+	"context"
+	"time"
+
+	"event-booker/internal/domain"
+)
+
+type idempotencyRepository interface {
+	Reserve(ctx context.Context, record *domain.IdempotencyRecord) (bool, error)
+	Get(ctx context.Context, userID, key string) (*domain.IdempotencyRecord, error)
+	TakeOver(ctx context.Context, record *domain.IdempotencyRecord, claimedAt time.Time) (bool, error)
+	Complete(ctx context.Context, record *domain.IdempotencyRecord) (bool, error)
+	Delete(ctx context.Context, record *domain.IdempotencyRecord) error
+	DeleteOlderThan(ctx context.Context, before time.Time) (int64, error)
+}
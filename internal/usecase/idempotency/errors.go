@@ -0,0 +1,9 @@
+package idempotency_uc
+
+import "errors"
+
+var (
+	ErrFingerprintMismatch = errors.New("idempotency key was used with a different request")
+	ErrRequestInProgress   = errors.New("a request with this idempotency key is still in progress")
+	ErrClaimLost           = errors.New("idempotency key was taken over by another request")
+)
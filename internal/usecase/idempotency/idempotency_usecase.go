@@ -0,0 +1,116 @@
+package idempotency_uc
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"event-booker/internal/domain"
+	"event-booker/internal/repository"
+
+	"github.com/wb-go/wbf/zlog"
+)
+
+type IdempotencyUsecase struct {
+	repo   idempotencyRepository
+	ttl    time.Duration
+	lease  time.Duration
+	logger *zlog.Zerolog
+}
+
+func NewIdempotencyUsecase(repo idempotencyRepository, ttl, lease time.Duration, logger *zlog.Zerolog) *IdempotencyUsecase {
+	return &IdempotencyUsecase{repo: repo, ttl: ttl, lease: lease, logger: logger}
+}
+
+// Begin claims key for the user. It returns the claimed, not yet completed
+// record when the caller should process the request and then pass the record
+// to Complete or Release, or the stored completed record when the request was
+// already answered and should be replayed. A key whose request has not
+// completed within the lease is assumed abandoned, e.g. by a crashed instance,
+// and is taken over by the next request with the same fingerprint.
+func (uc *IdempotencyUsecase) Begin(ctx context.Context, userID, key, fingerprint string) (*domain.IdempotencyRecord, error) {
+	record := &domain.IdempotencyRecord{
+		UserID:      userID,
+		Key:         key,
+		Fingerprint: fingerprint,
+		// The claim is identified by its time, so keep it at the precision
+		// the database stores.
+		CreatedAt: time.Now().Truncate(time.Microsecond),
+	}
+	for {
+		reserved, err := uc.repo.Reserve(ctx, record)
+		if err != nil {
+			uc.logger.Error().Err(err).Str("user_id", userID).Msg("failed to reserve idempotency key")
+			return nil, err
+		}
+		if reserved {
+			return record, nil
+		}
+		existing, err := uc.repo.Get(ctx, userID, key)
+		if errors.Is(err, repository.ErrNotFound) {
+			// Released or purged between the insert and the read; try again.
+			continue
+		}
+		if err != nil {
+			uc.logger.Error().Err(err).Str("user_id", userID).Msg("failed to get idempotency record")
+			return nil, err
+		}
+		if time.Since(existing.CreatedAt) > uc.ttl {
+			if err := uc.repo.Delete(ctx, existing); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if existing.Fingerprint != fingerprint {
+			return nil, ErrFingerprintMismatch
+		}
+		if existing.CompletedAt == nil {
+			if time.Since(existing.CreatedAt) <= uc.lease {
+				return nil, ErrRequestInProgress
+			}
+			taken, err := uc.repo.TakeOver(ctx, record, existing.CreatedAt)
+			if err != nil {
+				uc.logger.Error().Err(err).Str("user_id", userID).Msg("failed to take over idempotency key")
+				return nil, err
+			}
+			if taken {
+				uc.logger.Warn().Str("user_id", userID).Str("idempotency_key", key).Msg("took over abandoned idempotency key")
+				return record, nil
+			}
+			// Completed or taken over by another request meanwhile.
+			continue
+		}
+		return existing, nil
+	}
+}
+
+// Complete stores the response to the request that holds claim. It returns
+// ErrClaimLost when the claim was taken over in the meantime; the response is
+// then dropped so that it cannot overwrite the new owner's.
+func (uc *IdempotencyUsecase) Complete(ctx context.Context, claim *domain.IdempotencyRecord, statusCode int, contentType string, body []byte) error {
+	now := time.Now()
+	completed := *claim
+	completed.StatusCode = statusCode
+	completed.ContentType = contentType
+	completed.Body = body
+	completed.CompletedAt = &now
+	stored, err := uc.repo.Complete(ctx, &completed)
+	if err != nil {
+		return err
+	}
+	if !stored {
+		return ErrClaimLost
+	}
+	return nil
+}
+
+// Release forgets a claimed key so that the client may retry, used when the
+// request failed in a way that should not be replayed. A claim that was taken
+// over is left to its new owner.
+func (uc *IdempotencyUsecase) Release(ctx context.Context, claim *domain.IdempotencyRecord) error {
+	return uc.repo.Delete(ctx, claim)
+}
+
+func (uc *IdempotencyUsecase) PurgeExpired(ctx context.Context) (int64, error) {
+	return uc.repo.DeleteOlderThan(ctx, time.Now().Add(-uc.ttl))
+}
@@ -0,0 +1,108 @@
+package idempotency_uc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"event-booker/internal/domain"
+	"event-booker/internal/repository"
+
+	"github.com/wb-go/wbf/zlog"
+)
+
+// memoryRepo keeps records in memory with the same claim checks as the
+// Postgres repository.
+type memoryRepo struct {
+	records map[string]domain.IdempotencyRecord
+}
+
+func (r *memoryRepo) Reserve(_ context.Context, record *domain.IdempotencyRecord) (bool, error) {
+	if _, ok := r.records[record.Key]; ok {
+		return false, nil
+	}
+	r.records[record.Key] = *record
+	return true, nil
+}
+
+func (r *memoryRepo) Get(_ context.Context, _, key string) (*domain.IdempotencyRecord, error) {
+	record, ok := r.records[key]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	return &record, nil
+}
+
+func (r *memoryRepo) TakeOver(_ context.Context, record *domain.IdempotencyRecord, claimedAt time.Time) (bool, error) {
+	existing, ok := r.records[record.Key]
+	if !ok || existing.CompletedAt != nil || !existing.CreatedAt.Equal(claimedAt) {
+		return false, nil
+	}
+	existing.CreatedAt = record.CreatedAt
+	r.records[record.Key] = existing
+	return true, nil
+}
+
+func (r *memoryRepo) Complete(_ context.Context, record *domain.IdempotencyRecord) (bool, error) {
+	existing, ok := r.records[record.Key]
+	if !ok || existing.CompletedAt != nil || !existing.CreatedAt.Equal(record.CreatedAt) {
+		return false, nil
+	}
+	r.records[record.Key] = *record
+	return true, nil
+}
+
+func (r *memoryRepo) Delete(_ context.Context, record *domain.IdempotencyRecord) error {
+	if existing, ok := r.records[record.Key]; ok && existing.CreatedAt.Equal(record.CreatedAt) {
+		delete(r.records, record.Key)
+	}
+	return nil
+}
+
+func (r *memoryRepo) DeleteOlderThan(context.Context, time.Time) (int64, error) {
+	return 0, nil
+}
+
+func TestCompleteAfterTakeOver(t *testing.T) {
+	repo := &memoryRepo{records: map[string]domain.IdempotencyRecord{}}
+	logger := zlog.Zerolog{}
+	uc := NewIdempotencyUsecase(repo, time.Hour, time.Minute, &logger)
+	ctx := context.Background()
+
+	slow, err := uc.Begin(ctx, "u1", "key", "fp")
+	if err != nil || slow == nil || slow.CompletedAt != nil {
+		t.Fatalf("first Begin = %+v, %v", slow, err)
+	}
+	if _, err := uc.Begin(ctx, "u1", "key", "fp"); !errors.Is(err, ErrRequestInProgress) {
+		t.Fatalf("Begin within the lease: error = %v, want %v", err, ErrRequestInProgress)
+	}
+
+	// Age the claim past the lease so the next request takes it over.
+	stale := repo.records["key"]
+	stale.CreatedAt = stale.CreatedAt.Add(-2 * time.Minute)
+	repo.records["key"] = stale
+	slow.CreatedAt = stale.CreatedAt
+
+	retry, err := uc.Begin(ctx, "u1", "key", "fp")
+	if err != nil || retry == nil || retry.CompletedAt != nil {
+		t.Fatalf("Begin after the lease = %+v, %v", retry, err)
+	}
+	if err := uc.Complete(ctx, retry, 201, "application/json", []byte(`{"id":"new"}`)); err != nil {
+		t.Fatalf("Complete by the new owner: %v", err)
+	}
+	if err := uc.Complete(ctx, slow, 201, "application/json", []byte(`{"id":"old"}`)); !errors.Is(err, ErrClaimLost) {
+		t.Errorf("Complete by the old owner: error = %v, want %v", err, ErrClaimLost)
+	}
+	if err := uc.Release(ctx, slow); err != nil {
+		t.Fatal(err)
+	}
+
+	replay, err := uc.Begin(ctx, "u1", "key", "fp")
+	if err != nil || replay.CompletedAt == nil {
+		t.Fatalf("Begin after completion = %+v, %v", replay, err)
+	}
+	if string(replay.Body) != `{"id":"new"}` {
+		t.Errorf("replayed body = %s, want the new owner's response", replay.Body)
+	}
+}
@@ -0,0 +1,23 @@
+package organizer_uc
+
+import (
+	"context"
+	"database/sql"
+
+	"event-booker/internal/domain"
+)
+
+type organizerRepository interface {
+	Create(ctx context.Context, o *domain.Organizer) error
+	GetByID(ctx context.Context, id string) (*domain.Organizer, error)
+	List(ctx context.Context) ([]*domain.Organizer, error)
+	Update(ctx context.Context, o *domain.Organizer) error
+	Delete(ctx context.Context, id string) error
+	GetForUpdate(ctx context.Context, tx *sql.Tx, id string) (*domain.Organizer, error)
+	ListMembers(ctx context.Context, organizerID string) ([]*domain.OrganizerMember, error)
+	ListUserMemberships(ctx context.Context, userID string) ([]*domain.OrganizerMember, error)
+	GetMember(ctx context.Context, tx *sql.Tx, organizerID, userID string) (*domain.OrganizerMember, error)
+	UpsertMember(ctx context.Context, tx *sql.Tx, m *domain.OrganizerMember) error
+	DeleteMember(ctx context.Context, tx *sql.Tx, organizerID, userID string) error
+	CountOwners(ctx context.Context, tx *sql.Tx, organizerID string) (int, error)
+}
@@ -0,0 +1,16 @@
+package organizer_uc
+
+import "errors"
+
+var (
+	ErrOrganizerNotFound = errors.New("organizer not found")
+	ErrOrganizerExists   = errors.New("organizer with this name already exists")
+	ErrOrganizerInUse    = errors.New("organizer has events")
+	ErrInvalidName       = errors.New("organizer name must not be empty")
+	ErrInvalidEmail      = errors.New("invalid organizer email")
+	ErrForbidden         = errors.New("forbidden")
+	ErrUserNotFound      = errors.New("user not found")
+	ErrMemberNotFound    = errors.New("user is not a member of this organizer")
+	ErrInvalidRole       = errors.New("role must be owner, manager or checkin")
+	ErrLastOwner         = errors.New("organizer must keep at least one owner")
+)
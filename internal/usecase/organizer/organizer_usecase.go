@@ -0,0 +1,268 @@
+package organizer_uc
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/mail"
+	"strings"
+	"time"
+
+	"event-booker/internal/domain"
+	"event-booker/internal/repository"
+
+	"github.com/google/uuid"
+	"github.com/wb-go/wbf/dbpg"
+	"github.com/wb-go/wbf/zlog"
+)
+
+type OrganizerUsecase struct {
+	db     *dbpg.DB
+	repo   organizerRepository
+	logger *zlog.Zerolog
+}
+
+func NewOrganizerUsecase(db *dbpg.DB, repo organizerRepository, logger *zlog.Zerolog) *OrganizerUsecase {
+	return &OrganizerUsecase{db: db, repo: repo, logger: logger}
+}
+
+// CreateOrganizer registers a new tenant; only admins may do so. Its first
+// owner is added through SetMember.
+func (uc *OrganizerUsecase) CreateOrganizer(ctx context.Context, input *domain.Organizer, actor *domain.Principal) (*domain.Organizer, error) {
+	if !actor.IsAdmin() {
+		return nil, ErrForbidden
+	}
+	now := time.Now()
+	organizer := &domain.Organizer{
+		ID:        uuid.NewString(),
+		Name:      strings.TrimSpace(input.Name),
+		Email:     strings.TrimSpace(input.Email),
+		Website:   strings.TrimSpace(input.Website),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := validate(organizer); err != nil {
+		return nil, err
+	}
+	if err := uc.repo.Create(ctx, organizer); err != nil {
+		if errors.Is(err, repository.ErrAlreadyExists) {
+			return nil, ErrOrganizerExists
+		}
+		uc.logger.Error().Err(err).Str("name", organizer.Name).Msg("failed to create organizer")
+		return nil, err
+	}
+	uc.logger.Info().Str("organizer_id", organizer.ID).Str("name", organizer.Name).Msg("Organizer created")
+	return organizer, nil
+}
+
+func (uc *OrganizerUsecase) GetOrganizer(ctx context.Context, id string) (*domain.Organizer, error) {
+	organizer, err := uc.repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrOrganizerNotFound
+		}
+		return nil, err
+	}
+	return organizer, nil
+}
+
+func (uc *OrganizerUsecase) ListOrganizers(ctx context.Context) ([]*domain.Organizer, error) {
+	return uc.repo.List(ctx)
+}
+
+func (uc *OrganizerUsecase) UpdateOrganizer(ctx context.Context, id string, patch *domain.OrganizerPatch, actor *domain.Principal) (*domain.Organizer, error) {
+	organizer, err := uc.GetOrganizer(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !actor.HasOrganizerRole(&id, domain.OrganizerOwner) {
+		return nil, ErrForbidden
+	}
+	if patch.Name != nil {
+		organizer.Name = strings.TrimSpace(*patch.Name)
+	}
+	if patch.Email != nil {
+		organizer.Email = strings.TrimSpace(*patch.Email)
+	}
+	if patch.Website != nil {
+		organizer.Website = strings.TrimSpace(*patch.Website)
+	}
+	if err := validate(organizer); err != nil {
+		return nil, err
+	}
+	organizer.UpdatedAt = time.Now()
+	if err := uc.repo.Update(ctx, organizer); err != nil {
+		if errors.Is(err, repository.ErrAlreadyExists) {
+			return nil, ErrOrganizerExists
+		}
+		uc.logger.Error().Err(err).Str("organizer_id", id).Msg("failed to update organizer")
+		return nil, err
+	}
+	return organizer, nil
+}
+
+func (uc *OrganizerUsecase) DeleteOrganizer(ctx context.Context, id string, actor *domain.Principal) error {
+	if !actor.IsAdmin() {
+		return ErrForbidden
+	}
+	if err := uc.repo.Delete(ctx, id); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrNotFound):
+			return ErrOrganizerNotFound
+		case errors.Is(err, repository.ErrInUse):
+			return ErrOrganizerInUse
+		}
+		uc.logger.Error().Err(err).Str("organizer_id", id).Msg("failed to delete organizer")
+		return err
+	}
+	return nil
+}
+
+// Memberships returns the user's role in each organizer they belong to.
+func (uc *OrganizerUsecase) Memberships(ctx context.Context, userID string) (map[string]domain.OrganizerRole, error) {
+	members, err := uc.repo.ListUserMemberships(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	roles := make(map[string]domain.OrganizerRole, len(members))
+	for _, m := range members {
+		roles[m.OrganizerID] = m.Role
+	}
+	return roles, nil
+}
+
+func (uc *OrganizerUsecase) ListMembers(ctx context.Context, organizerID string, actor *domain.Principal) ([]*domain.OrganizerMember, error) {
+	if _, err := uc.GetOrganizer(ctx, organizerID); err != nil {
+		return nil, err
+	}
+	if !actor.HasOrganizerRole(&organizerID, domain.OrganizerOwner) {
+		return nil, ErrForbidden
+	}
+	return uc.repo.ListMembers(ctx, organizerID)
+}
+
+// SetMember adds the user to the organizer with role, or changes their role.
+// Only owners and admins manage members, and the last owner cannot be
+// demoted.
+func (uc *OrganizerUsecase) SetMember(ctx context.Context, organizerID, userID string, role domain.OrganizerRole, actor *domain.Principal) (*domain.OrganizerMember, error) {
+	if !role.Valid() {
+		return nil, ErrInvalidRole
+	}
+	tx, err := uc.lockOrganizer(ctx, organizerID)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+	if !actor.HasOrganizerRole(&organizerID, domain.OrganizerOwner) {
+		return nil, ErrForbidden
+	}
+	member, err := uc.repo.GetMember(ctx, tx, organizerID, userID)
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		member = &domain.OrganizerMember{OrganizerID: organizerID, UserID: userID, CreatedAt: time.Now()}
+	case err != nil:
+		uc.logger.Error().Err(err).Str("organizer_id", organizerID).Str("user_id", userID).Msg("failed to get organizer member")
+		return nil, err
+	case member.Role == domain.OrganizerOwner && role != domain.OrganizerOwner:
+		if err := uc.checkOtherOwners(ctx, tx, organizerID); err != nil {
+			return nil, err
+		}
+	}
+	member.Role = role
+	if err := uc.repo.UpsertMember(ctx, tx, member); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrUserNotFound
+		}
+		uc.logger.Error().Err(err).Str("organizer_id", organizerID).Str("user_id", userID).Msg("failed to save organizer member")
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		uc.logger.Error().Err(err).Str("organizer_id", organizerID).Msg("Failed to commit transaction")
+		return nil, err
+	}
+	uc.logger.Info().
+		Str("organizer_id", organizerID).
+		Str("user_id", userID).
+		Str("role", string(role)).
+		Msg("Organizer member saved")
+	return member, nil
+}
+
+// RemoveMember takes the user out of the organizer. Owners and admins may
+// remove anyone, and members may leave on their own, but the last owner
+// cannot go.
+func (uc *OrganizerUsecase) RemoveMember(ctx context.Context, organizerID, userID string, actor *domain.Principal) error {
+	tx, err := uc.lockOrganizer(ctx, organizerID)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if !actor.CanAccessUser(userID) && !actor.HasOrganizerRole(&organizerID, domain.OrganizerOwner) {
+		return ErrForbidden
+	}
+	member, err := uc.repo.GetMember(ctx, tx, organizerID, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrMemberNotFound
+		}
+		uc.logger.Error().Err(err).Str("organizer_id", organizerID).Str("user_id", userID).Msg("failed to get organizer member")
+		return err
+	}
+	if member.Role == domain.OrganizerOwner {
+		if err := uc.checkOtherOwners(ctx, tx, organizerID); err != nil {
+			return err
+		}
+	}
+	if err := uc.repo.DeleteMember(ctx, tx, organizerID, userID); err != nil {
+		uc.logger.Error().Err(err).Str("organizer_id", organizerID).Str("user_id", userID).Msg("failed to delete organizer member")
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		uc.logger.Error().Err(err).Str("organizer_id", organizerID).Msg("Failed to commit transaction")
+		return err
+	}
+	return nil
+}
+
+// lockOrganizer begins a transaction holding the organizer row, which
+// serialises membership changes.
+func (uc *OrganizerUsecase) lockOrganizer(ctx context.Context, organizerID string) (*sql.Tx, error) {
+	tx, err := uc.db.BeginTx(ctx, nil)
+	if err != nil {
+		uc.logger.Error().Err(err).Msg("Failed to begin transaction")
+		return nil, err
+	}
+	if _, err := uc.repo.GetForUpdate(ctx, tx, organizerID); err != nil {
+		tx.Rollback()
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrOrganizerNotFound
+		}
+		uc.logger.Error().Err(err).Str("organizer_id", organizerID).Msg("failed to get organizer for update")
+		return nil, err
+	}
+	return tx, nil
+}
+
+func (uc *OrganizerUsecase) checkOtherOwners(ctx context.Context, tx *sql.Tx, organizerID string) error {
+	owners, err := uc.repo.CountOwners(ctx, tx, organizerID)
+	if err != nil {
+		uc.logger.Error().Err(err).Str("organizer_id", organizerID).Msg("failed to count organizer owners")
+		return err
+	}
+	if owners <= 1 {
+		return ErrLastOwner
+	}
+	return nil
+}
+
+func validate(organizer *domain.Organizer) error {
+	if organizer.Name == "" {
+		return ErrInvalidName
+	}
+	if organizer.Email != "" {
+		if _, err := mail.ParseAddress(organizer.Email); err != nil {
+			return ErrInvalidEmail
+		}
+	}
+	return nil
+}
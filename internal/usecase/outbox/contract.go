@@ -0,0 +1,13 @@
+package outbox_uc
+
+import (
+	"context"
+
+	"event-booker/internal/domain"
+)
+
+type outboxRepository interface {
+	GetByID(ctx context.Context, id string) (*domain.OutboxMessage, error)
+	GetByStatus(ctx context.Context, status domain.OutboxStatus, limit int) ([]*domain.OutboxMessage, error)
+	Requeue(ctx context.Context, id string) error
+}
@@ -0,0 +1,9 @@
+package outbox_uc
+
+import "errors"
+
+var (
+	ErrMessageNotFound = errors.New("outbox message not found")
+	ErrMessageNotDead  = errors.New("only dead-lettered messages can be replayed")
+	ErrInvalidStatus   = errors.New("invalid outbox status")
+)
@@ -0,0 +1,54 @@
+package outbox_uc
+
+import (
+	"context"
+	"errors"
+
+	"event-booker/internal/domain"
+	"event-booker/internal/repository"
+
+	"github.com/wb-go/wbf/zlog"
+)
+
+const listLimit = 100
+
+type OutboxUsecase struct {
+	repo   outboxRepository
+	logger *zlog.Zerolog
+}
+
+func NewOutboxUsecase(repo outboxRepository, logger *zlog.Zerolog) *OutboxUsecase {
+	return &OutboxUsecase{repo: repo, logger: logger}
+}
+
+func (uc *OutboxUsecase) ListMessages(ctx context.Context, status domain.OutboxStatus) ([]*domain.OutboxMessage, error) {
+	if status == "" {
+		status = domain.OutboxDead
+	}
+	if status != domain.OutboxPending && status != domain.OutboxSent && status != domain.OutboxDead {
+		return nil, ErrInvalidStatus
+	}
+	return uc.repo.GetByStatus(ctx, status, listLimit)
+}
+
+func (uc *OutboxUsecase) ReplayMessage(ctx context.Context, id string) (*domain.OutboxMessage, error) {
+	msg, err := uc.repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrMessageNotFound
+		}
+		return nil, err
+	}
+	if msg.Status != domain.OutboxDead {
+		return nil, ErrMessageNotDead
+	}
+	if err := uc.repo.Requeue(ctx, id); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrMessageNotDead
+		}
+		uc.logger.Error().Err(err).Str("message_id", id).Msg("failed to requeue outbox message")
+		return nil, err
+	}
+	uc.logger.Info().Str("message_id", id).Str("kind", string(msg.Notification.Kind)).Msg("Outbox message requeued")
+	return uc.repo.GetByID(ctx, id)
+}
@@ -0,0 +1,52 @@
+package payment_uc
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"event-booker/internal/domain"
+	"event-booker/internal/payment"
+)
+
+type provider interface {
+	Name() string
+	CreateIntent(ctx context.Context, req payment.IntentRequest) (*payment.Intent, error)
+	Capture(ctx context.Context, intentID string) error
+	Refund(ctx context.Context, intentID string, amount int64, idempotencyKey string) (*payment.Refund, error)
+	VerifyWebhook(payload []byte, signature string) (*payment.WebhookEvent, error)
+}
+
+type paymentRepository interface {
+	Create(ctx context.Context, tx *sql.Tx, p *domain.Payment) error
+	GetByID(ctx context.Context, tx *sql.Tx, id string) (*domain.Payment, error)
+	GetByIntentIDForUpdate(ctx context.Context, tx *sql.Tx, provider, intentID string) (*domain.Payment, error)
+	GetLatestByBookingID(ctx context.Context, tx *sql.Tx, bookingID string) (*domain.Payment, error)
+	ListAuthorized(ctx context.Context, limit int) ([]*domain.Payment, error)
+	UpdateStatus(ctx context.Context, tx *sql.Tx, p *domain.Payment) error
+}
+
+type refundRepository interface {
+	Create(ctx context.Context, tx *sql.Tx, refund *domain.Refund) error
+	ClaimDue(ctx context.Context, limit int, staleBefore time.Time) ([]*domain.Refund, error)
+	Update(ctx context.Context, tx *sql.Tx, refund *domain.Refund) error
+}
+
+type bookingRepository interface {
+	GetByID(ctx context.Context, id string) (*domain.Booking, error)
+	GetForUpdate(ctx context.Context, tx *sql.Tx, id string) (*domain.Booking, error)
+	Update(ctx context.Context, tx *sql.Tx, booking *domain.Booking) error
+}
+
+type eventRepository interface {
+	GetByID(ctx context.Context, id string) (*domain.Event, error)
+	GetTier(ctx context.Context, id string) (*domain.TicketTier, error)
+}
+
+type outbox interface {
+	Enqueue(ctx context.Context, tx *sql.Tx, notification *domain.Notification) error
+}
+
+type publisher interface {
+	Publish(ctx context.Context, tx *sql.Tx, event *domain.DomainEvent) error
+}
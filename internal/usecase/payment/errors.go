@@ -0,0 +1,15 @@
+package payment_uc
+
+import "errors"
+
+var (
+	ErrInvalidSignature     = errors.New("invalid webhook signature")
+	ErrPaymentNotFound      = errors.New("payment not found")
+	ErrBookingNotFound      = errors.New("booking not found")
+	ErrBookingNotPending    = errors.New("booking not pending")
+	ErrBookingExpired       = errors.New("booking expired")
+	ErrPaymentNotRequired   = errors.New("event does not require payment")
+	ErrPaymentNotConfigured = errors.New("event has no price configured")
+	ErrForbidden            = errors.New("booking belongs to another user")
+	ErrAmountMismatch       = errors.New("webhook amount does not match payment")
+)
@@ -0,0 +1,438 @@
+package payment_uc
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"event-booker/internal/domain"
+	"event-booker/internal/metrics"
+	"event-booker/internal/payment"
+	"event-booker/internal/repository"
+
+	"github.com/google/uuid"
+	"github.com/wb-go/wbf/dbpg"
+	"github.com/wb-go/wbf/zlog"
+)
+
+const (
+	refundBatchSize   = 20
+	maxRefundAttempts = 5
+	captureBatchSize  = 20
+	// refundClaimTimeout is how long a refund may stay processing before
+	// another run takes it over.
+	refundClaimTimeout = 10 * time.Minute
+)
+
+type PaymentUsecase struct {
+	db          *dbpg.DB
+	provider    provider
+	repo        paymentRepository
+	refundRepo  refundRepository
+	bookingRepo bookingRepository
+	eventRepo   eventRepository
+	outbox      outbox
+	events      publisher
+	logger      *zlog.Zerolog
+}
+
+func NewPaymentUsecase(db *dbpg.DB, provider provider, repo paymentRepository, refundRepo refundRepository, bookingRepo bookingRepository, eventRepo eventRepository, outbox outbox, events publisher, logger *zlog.Zerolog) *PaymentUsecase {
+	return &PaymentUsecase{
+		db:          db,
+		provider:    provider,
+		repo:        repo,
+		refundRepo:  refundRepo,
+		bookingRepo: bookingRepo,
+		eventRepo:   eventRepo,
+		outbox:      outbox,
+		events:      events,
+		logger:      logger,
+	}
+}
+
+// StartPayment returns the open payment for a pending booking, creating a new
+// intent when there is none (e.g. for bookings promoted from the waitlist or
+// after a declined attempt).
+func (uc *PaymentUsecase) StartPayment(ctx context.Context, bookingID string, actor *domain.Principal) (*domain.Payment, error) {
+	return uc.openPayment(ctx, bookingID, actor)
+}
+
+// OpenPayment starts the payment of a booking the caller has just made; it is
+// StartPayment without the ownership check.
+func (uc *PaymentUsecase) OpenPayment(ctx context.Context, bookingID string) (*domain.Payment, error) {
+	return uc.openPayment(ctx, bookingID, nil)
+}
+
+// openPayment opens a payment intent with the provider for the full booking
+// amount, less any promo code discount. Bookings made in a ticket tier are
+// charged the tier's price instead of the event's.
+//
+// The provider is called outside of any transaction so that no row stays
+// locked for the length of a network call. The booking is checked again under
+// its row lock before the intent is recorded; if a concurrent request recorded
+// a payment in the meantime, that one is returned and the new intent is left
+// to lapse.
+func (uc *PaymentUsecase) openPayment(ctx context.Context, bookingID string, actor *domain.Principal) (*domain.Payment, error) {
+	booking, err := uc.bookingRepo.GetByID(ctx, bookingID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrBookingNotFound
+		}
+		return nil, err
+	}
+	if err := checkPayable(booking, actor); err != nil {
+		return nil, err
+	}
+	event, err := uc.eventRepo.GetByID(ctx, booking.EventID)
+	if err != nil {
+		return nil, err
+	}
+	if !event.RequiresPayment {
+		return nil, ErrPaymentNotRequired
+	}
+	existing, err := uc.pendingPayment(ctx, nil, bookingID)
+	if err != nil || existing != nil {
+		return existing, err
+	}
+	var tier *domain.TicketTier
+	if booking.TierID != nil {
+		tier, err = uc.eventRepo.GetTier(ctx, *booking.TierID)
+		if err != nil {
+			uc.logger.Error().Err(err).Str("tier_id", *booking.TierID).Msg("failed to get ticket tier")
+			return nil, err
+		}
+	}
+	price, currency := domain.TicketPrice(event, tier)
+	amount := price*int64(booking.Quantity) - booking.Discount
+	if price <= 0 || amount <= 0 {
+		return nil, ErrPaymentNotConfigured
+	}
+	intent, err := uc.provider.CreateIntent(ctx, payment.IntentRequest{
+		BookingID: booking.ID,
+		Amount:    amount,
+		Currency:  currency,
+	})
+	if err != nil {
+		uc.logger.Error().Err(err).Str("booking_id", booking.ID).Msg("failed to create payment intent")
+		return nil, err
+	}
+
+	tx, err := uc.db.BeginTx(ctx, nil)
+	if err != nil {
+		uc.logger.Error().Err(err).Msg("failed to begin transaction")
+		return nil, err
+	}
+	defer tx.Rollback()
+	booking, err = uc.bookingRepo.GetForUpdate(ctx, tx, bookingID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrBookingNotFound
+		}
+		return nil, err
+	}
+	if err := checkPayable(booking, actor); err != nil {
+		return nil, err
+	}
+	existing, err = uc.pendingPayment(ctx, tx, bookingID)
+	if err != nil || existing != nil {
+		return existing, err
+	}
+	now := time.Now()
+	p := &domain.Payment{
+		ID:          uuid.NewString(),
+		BookingID:   booking.ID,
+		Provider:    uc.provider.Name(),
+		IntentID:    intent.ID,
+		Amount:      amount,
+		Currency:    currency,
+		Status:      domain.PaymentPending,
+		CheckoutURL: intent.CheckoutURL,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if err := uc.repo.Create(ctx, tx, p); err != nil {
+		uc.logger.Error().Err(err).Str("booking_id", booking.ID).Msg("failed to store payment")
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		uc.logger.Error().Err(err).Msg("failed to commit transaction")
+		return nil, err
+	}
+	return p, nil
+}
+
+func checkPayable(booking *domain.Booking, actor *domain.Principal) error {
+	if actor != nil && !actor.CanAccessUser(booking.UserID) {
+		return ErrForbidden
+	}
+	if booking.Status != domain.BookingPending {
+		return ErrBookingNotPending
+	}
+	if !booking.ExpiresAt.IsZero() && time.Now().After(booking.ExpiresAt) {
+		return ErrBookingExpired
+	}
+	return nil
+}
+
+// pendingPayment returns the booking's latest payment if it is still open, or
+// nil.
+func (uc *PaymentUsecase) pendingPayment(ctx context.Context, tx *sql.Tx, bookingID string) (*domain.Payment, error) {
+	p, err := uc.repo.GetLatestByBookingID(ctx, tx, bookingID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if p.Status != domain.PaymentPending {
+		return nil, nil
+	}
+	return p, nil
+}
+
+// HandleWebhook applies a verified provider notification. A succeeded payment
+// confirms its booking and is recorded as authorized; the capture happens once
+// that is committed, so a failed commit never leaves money taken for an
+// unconfirmed booking. Captures that fail are retried by CapturePayments. If
+// the booking can no longer be confirmed the authorization is left uncaptured
+// and the payment cancelled. Replays of already processed events are no-ops.
+func (uc *PaymentUsecase) HandleWebhook(ctx context.Context, payload []byte, signature string) error {
+	event, err := uc.provider.VerifyWebhook(payload, signature)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+	tx, err := uc.db.BeginTx(ctx, nil)
+	if err != nil {
+		uc.logger.Error().Err(err).Msg("failed to begin transaction")
+		return err
+	}
+	defer tx.Rollback()
+	p, err := uc.repo.GetByIntentIDForUpdate(ctx, tx, uc.provider.Name(), event.IntentID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrPaymentNotFound
+		}
+		return err
+	}
+	if p.Status != domain.PaymentPending {
+		return nil
+	}
+	switch event.Type {
+	case payment.EventPaymentSucceeded:
+		if event.Amount != p.Amount || event.Currency != p.Currency {
+			return ErrAmountMismatch
+		}
+		if err := uc.applySucceeded(ctx, tx, p); err != nil {
+			return err
+		}
+	case payment.EventPaymentFailed:
+		p.Status = domain.PaymentFailed
+	default:
+		uc.logger.Warn().Str("type", string(event.Type)).Str("intent_id", event.IntentID).Msg("ignoring unknown webhook event")
+		return nil
+	}
+	p.UpdatedAt = time.Now()
+	if err := uc.repo.UpdateStatus(ctx, tx, p); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		uc.logger.Error().Err(err).Msg("failed to commit transaction")
+		return err
+	}
+	if p.Status == domain.PaymentAuthorized {
+		metrics.ObserveBooking(metrics.BookingConfirmed, 1)
+		uc.capture(ctx, p)
+	}
+	uc.logger.Info().
+		Str("payment_id", p.ID).
+		Str("booking_id", p.BookingID).
+		Str("status", string(p.Status)).
+		Msg("Payment webhook processed")
+	return nil
+}
+
+func (uc *PaymentUsecase) applySucceeded(ctx context.Context, tx *sql.Tx, p *domain.Payment) error {
+	booking, err := uc.bookingRepo.GetForUpdate(ctx, tx, p.BookingID)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	expired := !booking.ExpiresAt.IsZero() && now.After(booking.ExpiresAt)
+	if booking.Status != domain.BookingPending || expired {
+		uc.logger.Warn().
+			Str("booking_id", booking.ID).
+			Str("booking_status", string(booking.Status)).
+			Bool("expired", expired).
+			Msg("payment arrived for a booking that cannot be confirmed, leaving it uncaptured")
+		p.Status = domain.PaymentCancelled
+		return nil
+	}
+	booking.Status = domain.BookingConfirmed
+	booking.ConfirmedAt = &now
+	if err := uc.bookingRepo.Update(ctx, tx, booking); err != nil {
+		return err
+	}
+	event, err := uc.eventRepo.GetByID(ctx, booking.EventID)
+	if err != nil {
+		return err
+	}
+	if err := uc.outbox.Enqueue(ctx, tx, &domain.Notification{
+		Kind:    domain.NotificationBookingConfirmed,
+		UserID:  booking.UserID,
+		Booking: booking,
+		Event:   event,
+	}); err != nil {
+		uc.logger.Error().Err(err).Str("booking_id", booking.ID).Msg("failed to enqueue confirmation notification")
+		return err
+	}
+	if err := uc.events.Publish(ctx, tx, &domain.DomainEvent{
+		Type:    domain.DomainBookingConfirmed,
+		Event:   event,
+		Booking: booking,
+	}); err != nil {
+		uc.logger.Error().Err(err).Str("booking_id", booking.ID).Msg("failed to publish confirmation event")
+		return err
+	}
+	p.Status = domain.PaymentAuthorized
+	return nil
+}
+
+// CapturePayments captures authorized payments whose capture failed or was
+// interrupted after their booking was confirmed.
+func (uc *PaymentUsecase) CapturePayments(ctx context.Context) error {
+	payments, err := uc.repo.ListAuthorized(ctx, captureBatchSize)
+	if err != nil {
+		uc.logger.Error().Err(err).Msg("failed to get authorized payments")
+		return err
+	}
+	for _, p := range payments {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		uc.capture(ctx, p)
+	}
+	return nil
+}
+
+// capture captures p with the provider and marks it succeeded. Captures are
+// idempotent on the provider side, so a payment captured twice by concurrent
+// runs is harmless. Failures are logged and left for the next run.
+func (uc *PaymentUsecase) capture(ctx context.Context, p *domain.Payment) {
+	if err := uc.provider.Capture(ctx, p.IntentID); err != nil {
+		uc.logger.Error().Err(err).Str("payment_id", p.ID).Str("intent_id", p.IntentID).Msg("failed to capture payment")
+		return
+	}
+	p.Status = domain.PaymentSucceeded
+	p.UpdatedAt = time.Now()
+	if err := uc.repo.UpdateStatus(ctx, nil, p); err != nil {
+		uc.logger.Error().Err(err).Str("payment_id", p.ID).Msg("failed to mark payment captured")
+	}
+}
+
+// SettleCancellationInTx closes out the payment of a cancelled booking. An
+// unpaid intent is abandoned; an authorized or captured payment gets a pending
+// refund of the given percentage, which ProcessRefunds submits to the provider
+// once the payment is captured. It
+// returns nil when nothing is refunded.
+func (uc *PaymentUsecase) SettleCancellationInTx(ctx context.Context, tx *sql.Tx, booking *domain.Booking, percent int, reason string) (*domain.Refund, error) {
+	p, err := uc.repo.GetLatestByBookingID(ctx, tx, booking.ID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, nil
+		}
+		uc.logger.Error().Err(err).Str("booking_id", booking.ID).Msg("failed to get payment for cancelled booking")
+		return nil, err
+	}
+	now := time.Now()
+	switch p.Status {
+	case domain.PaymentPending:
+		p.Status = domain.PaymentCancelled
+		p.UpdatedAt = now
+		return nil, uc.repo.UpdateStatus(ctx, tx, p)
+	case domain.PaymentAuthorized, domain.PaymentSucceeded:
+	default:
+		return nil, nil
+	}
+	amount := p.Amount * int64(percent) / 100
+	if amount <= 0 {
+		return nil, nil
+	}
+	refund := &domain.Refund{
+		ID:        uuid.NewString(),
+		PaymentID: p.ID,
+		BookingID: booking.ID,
+		Amount:    amount,
+		Currency:  p.Currency,
+		Percent:   percent,
+		Reason:    reason,
+		Status:    domain.RefundPending,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := uc.refundRepo.Create(ctx, tx, refund); err != nil {
+		uc.logger.Error().Err(err).Str("booking_id", booking.ID).Msg("failed to create refund")
+		return nil, err
+	}
+	uc.logger.Info().
+		Str("refund_id", refund.ID).
+		Str("booking_id", booking.ID).
+		Int64("amount", amount).
+		Int("percent", percent).
+		Msg("Refund scheduled")
+	return refund, nil
+}
+
+// ProcessRefunds submits due refunds to the provider. Refunds of payments not
+// captured yet wait for the capture. Each refund is claimed and committed
+// before the provider is called and passes its ID as the idempotency key, so a
+// refund resubmitted after a crash is not paid out twice. Failed attempts are
+// retried on later runs until maxRefundAttempts is reached.
+func (uc *PaymentUsecase) ProcessRefunds(ctx context.Context) error {
+	refunds, err := uc.refundRepo.ClaimDue(ctx, refundBatchSize, time.Now().Add(-refundClaimTimeout))
+	if err != nil {
+		uc.logger.Error().Err(err).Msg("failed to claim refunds")
+		return err
+	}
+	for _, refund := range refunds {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		uc.submitRefund(ctx, refund)
+		if err := uc.refundRepo.Update(ctx, nil, refund); err != nil {
+			uc.logger.Error().Err(err).Str("refund_id", refund.ID).Msg("failed to update refund")
+			return err
+		}
+	}
+	return nil
+}
+
+func (uc *PaymentUsecase) submitRefund(ctx context.Context, refund *domain.Refund) {
+	refund.UpdatedAt = time.Now()
+	p, err := uc.repo.GetByID(ctx, nil, refund.PaymentID)
+	if err == nil {
+		var result *payment.Refund
+		result, err = uc.provider.Refund(ctx, p.IntentID, refund.Amount, refund.ID)
+		if err == nil {
+			refund.Status = domain.RefundSucceeded
+			refund.ProviderRefundID = result.ID
+			refund.LastError = ""
+			uc.logger.Info().
+				Str("refund_id", refund.ID).
+				Str("booking_id", refund.BookingID).
+				Int64("amount", refund.Amount).
+				Msg("Refund completed")
+			return
+		}
+	}
+	refund.LastError = err.Error()
+	refund.Status = domain.RefundPending
+	if refund.Attempts >= maxRefundAttempts {
+		refund.Status = domain.RefundFailed
+	}
+	uc.logger.Error().
+		Err(err).
+		Str("refund_id", refund.ID).
+		Int("attempts", refund.Attempts).
+		Msg("Refund attempt failed")
+}
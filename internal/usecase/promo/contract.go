@@ -0,0 +1,24 @@
+package promo_uc
+
+import (
+	"context"
+	"database/sql"
+
+	"event-booker/internal/domain"
+)
+
+type promoRepository interface {
+	Create(ctx context.Context, p *domain.PromoCode) error
+	GetByID(ctx context.Context, id string) (*domain.PromoCode, error)
+	GetByCodeForUpdate(ctx context.Context, tx *sql.Tx, code string) (*domain.PromoCode, error)
+	List(ctx context.Context) ([]*domain.PromoCode, error)
+	Update(ctx context.Context, p *domain.PromoCode) error
+	Delete(ctx context.Context, id string) error
+	CountUserUses(ctx context.Context, tx *sql.Tx, promoID, userID string) (int, error)
+	Redeem(ctx context.Context, tx *sql.Tx, id string) error
+	Release(ctx context.Context, tx *sql.Tx, id string) error
+}
+
+type eventRepository interface {
+	GetByID(ctx context.Context, id string) (*domain.Event, error)
+}
@@ -0,0 +1,20 @@
+package promo_uc
+
+import "errors"
+
+var (
+	ErrPromoNotFound      = errors.New("promo code not found")
+	ErrPromoExists        = errors.New("promo code already exists")
+	ErrPromoInUse         = errors.New("promo code has been used, deactivate it instead")
+	ErrInvalidCode        = errors.New("promo code must be 3-64 letters, digits, dashes or underscores")
+	ErrInvalidKind        = errors.New("discount kind must be percent or fixed")
+	ErrInvalidValue       = errors.New("discount must be 1-100 percent or a positive amount")
+	ErrInvalidCurrency    = errors.New("fixed discounts need a 3-letter currency")
+	ErrInvalidLimit       = errors.New("usage limits must not be negative")
+	ErrInvalidValidity    = errors.New("promo code must become valid before it expires")
+	ErrEventNotFound      = errors.New("event not found")
+	ErrPromoNotValid      = errors.New("promo code is not valid at this time")
+	ErrPromoNotApplicable = errors.New("promo code does not apply to this booking")
+	ErrPromoExhausted     = errors.New("promo code has been used up")
+	ErrPromoUserLimit     = errors.New("promo code usage limit per user reached")
+)
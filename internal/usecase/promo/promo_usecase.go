@@ -0,0 +1,213 @@
+package promo_uc
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+
+	"event-booker/internal/domain"
+	"event-booker/internal/repository"
+
+	"github.com/google/uuid"
+	"github.com/wb-go/wbf/zlog"
+)
+
+var codePattern = regexp.MustCompile(`^[A-Z0-9_-]{3,64}$`)
+
+type PromoUsecase struct {
+	repo      promoRepository
+	eventRepo eventRepository
+	logger    *zlog.Zerolog
+}
+
+func NewPromoUsecase(repo promoRepository, eventRepo eventRepository, logger *zlog.Zerolog) *PromoUsecase {
+	return &PromoUsecase{repo: repo, eventRepo: eventRepo, logger: logger}
+}
+
+// NormalizeCode returns the canonical, case-insensitive form of a code.
+func NormalizeCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// CreatePromoCode stores a new active code built from input; the identifier,
+// usage counter and timestamps are assigned here.
+func (uc *PromoUsecase) CreatePromoCode(ctx context.Context, input *domain.PromoCode) (*domain.PromoCode, error) {
+	code := NormalizeCode(input.Code)
+	if !codePattern.MatchString(code) {
+		return nil, ErrInvalidCode
+	}
+	switch input.Kind {
+	case domain.DiscountPercent:
+		if input.Value <= 0 || input.Value > 100 {
+			return nil, ErrInvalidValue
+		}
+	case domain.DiscountFixed:
+		if input.Value <= 0 {
+			return nil, ErrInvalidValue
+		}
+		if len(input.Currency) != 3 {
+			return nil, ErrInvalidCurrency
+		}
+	default:
+		return nil, ErrInvalidKind
+	}
+	if input.MaxUses < 0 || input.MaxUsesPerUser < 0 {
+		return nil, ErrInvalidLimit
+	}
+	if input.ValidFrom != nil && input.ValidUntil != nil && !input.ValidFrom.Before(*input.ValidUntil) {
+		return nil, ErrInvalidValidity
+	}
+	if input.EventID != nil {
+		if _, err := uc.eventRepo.GetByID(ctx, *input.EventID); err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				return nil, ErrEventNotFound
+			}
+			return nil, err
+		}
+	}
+	now := time.Now()
+	promo := &domain.PromoCode{
+		ID:             uuid.NewString(),
+		Code:           code,
+		EventID:        input.EventID,
+		Kind:           input.Kind,
+		Value:          input.Value,
+		MaxUses:        input.MaxUses,
+		MaxUsesPerUser: input.MaxUsesPerUser,
+		ValidFrom:      input.ValidFrom,
+		ValidUntil:     input.ValidUntil,
+		Active:         true,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+	if promo.Kind == domain.DiscountFixed {
+		promo.Currency = input.Currency
+	}
+	if err := uc.repo.Create(ctx, promo); err != nil {
+		if errors.Is(err, repository.ErrAlreadyExists) {
+			return nil, ErrPromoExists
+		}
+		uc.logger.Error().Err(err).Str("code", code).Msg("failed to create promo code")
+		return nil, err
+	}
+	uc.logger.Info().Str("promo_id", promo.ID).Str("code", code).Msg("Promo code created")
+	return promo, nil
+}
+
+func (uc *PromoUsecase) ListPromoCodes(ctx context.Context) ([]*domain.PromoCode, error) {
+	return uc.repo.List(ctx)
+}
+
+func (uc *PromoUsecase) UpdatePromoCode(ctx context.Context, id string, patch *domain.PromoCodePatch) (*domain.PromoCode, error) {
+	promo, err := uc.repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrPromoNotFound
+		}
+		return nil, err
+	}
+	if patch.Active != nil {
+		promo.Active = *patch.Active
+	}
+	if patch.MaxUses != nil {
+		if *patch.MaxUses < 0 {
+			return nil, ErrInvalidLimit
+		}
+		promo.MaxUses = *patch.MaxUses
+	}
+	if patch.MaxUsesPerUser != nil {
+		if *patch.MaxUsesPerUser < 0 {
+			return nil, ErrInvalidLimit
+		}
+		promo.MaxUsesPerUser = *patch.MaxUsesPerUser
+	}
+	switch {
+	case patch.ClearValidUntil && patch.ValidUntil != nil:
+		return nil, ErrInvalidValidity
+	case patch.ClearValidUntil:
+		promo.ValidUntil = nil
+	case patch.ValidUntil != nil:
+		if promo.ValidFrom != nil && !promo.ValidFrom.Before(*patch.ValidUntil) {
+			return nil, ErrInvalidValidity
+		}
+		promo.ValidUntil = patch.ValidUntil
+	}
+	promo.UpdatedAt = time.Now()
+	if err := uc.repo.Update(ctx, promo); err != nil {
+		uc.logger.Error().Err(err).Str("promo_id", id).Msg("failed to update promo code")
+		return nil, err
+	}
+	return promo, nil
+}
+
+func (uc *PromoUsecase) DeletePromoCode(ctx context.Context, id string) error {
+	if err := uc.repo.Delete(ctx, id); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrNotFound):
+			return ErrPromoNotFound
+		case errors.Is(err, repository.ErrInUse):
+			return ErrPromoInUse
+		}
+		uc.logger.Error().Err(err).Str("promo_id", id).Msg("failed to delete promo code")
+		return err
+	}
+	return nil
+}
+
+// ApplyInTx redeems code for a booking of event by userID and returns the
+// code together with the discount on subtotal. The code row stays locked
+// until tx ends, so concurrent bookings cannot overrun its limits; the use is
+// counted only if tx commits.
+func (uc *PromoUsecase) ApplyInTx(ctx context.Context, tx *sql.Tx, code, userID string, event *domain.Event, subtotal int64, currency string) (*domain.PromoCode, int64, error) {
+	promo, err := uc.repo.GetByCodeForUpdate(ctx, tx, NormalizeCode(code))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, 0, ErrPromoNotFound
+		}
+		uc.logger.Error().Err(err).Msg("failed to get promo code")
+		return nil, 0, err
+	}
+	if !promo.ValidAt(time.Now()) {
+		return nil, 0, ErrPromoNotValid
+	}
+	if !event.RequiresPayment || subtotal <= 0 {
+		return nil, 0, ErrPromoNotApplicable
+	}
+	if promo.EventID != nil && *promo.EventID != event.ID {
+		return nil, 0, ErrPromoNotApplicable
+	}
+	if promo.Kind == domain.DiscountFixed && promo.Currency != currency {
+		return nil, 0, ErrPromoNotApplicable
+	}
+	if promo.MaxUsesPerUser > 0 {
+		uses, err := uc.repo.CountUserUses(ctx, tx, promo.ID, userID)
+		if err != nil {
+			uc.logger.Error().Err(err).Str("promo_id", promo.ID).Msg("failed to count promo code uses")
+			return nil, 0, err
+		}
+		if uses >= promo.MaxUsesPerUser {
+			return nil, 0, ErrPromoUserLimit
+		}
+	}
+	if err := uc.repo.Redeem(ctx, tx, promo.ID); err != nil {
+		if errors.Is(err, repository.ErrLimitReached) {
+			return nil, 0, ErrPromoExhausted
+		}
+		uc.logger.Error().Err(err).Str("promo_id", promo.ID).Msg("failed to redeem promo code")
+		return nil, 0, err
+	}
+	promo.Uses++
+	return promo, promo.Discount(subtotal), nil
+}
+
+// ReleaseInTx gives back the use of the promo code held by a booking that is
+// being cancelled or has expired.
+func (uc *PromoUsecase) ReleaseInTx(ctx context.Context, tx *sql.Tx, booking *domain.Booking) error {
+	if booking.PromoCodeID == nil {
+		return nil
+	}
+	return uc.repo.Release(ctx, tx, *booking.PromoCodeID)
+}
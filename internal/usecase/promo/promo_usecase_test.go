@@ -0,0 +1,77 @@
+package promo_uc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"event-booker/internal/domain"
+
+	"github.com/wb-go/wbf/zlog"
+)
+
+type fakePromoRepo struct {
+	promoRepository
+	promo   domain.PromoCode
+	updated *domain.PromoCode
+}
+
+func (r *fakePromoRepo) GetByID(context.Context, string) (*domain.PromoCode, error) {
+	promo := r.promo
+	return &promo, nil
+}
+
+func (r *fakePromoRepo) Update(_ context.Context, p *domain.PromoCode) error {
+	r.updated = p
+	return nil
+}
+
+func TestUpdatePromoCodeValidUntil(t *testing.T) {
+	from := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	later := time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC)
+	early := from.Add(-time.Hour)
+	tests := []struct {
+		name    string
+		current *time.Time
+		patch   domain.PromoCodePatch
+		want    *time.Time
+		wantErr error
+	}{
+		{"unchanged", &until, domain.PromoCodePatch{}, &until, nil},
+		{"extended", &until, domain.PromoCodePatch{ValidUntil: &later}, &later, nil},
+		{"set on an open-ended code", nil, domain.PromoCodePatch{ValidUntil: &until}, &until, nil},
+		{"cleared", &until, domain.PromoCodePatch{ClearValidUntil: true}, nil, nil},
+		{"cleared when already open-ended", nil, domain.PromoCodePatch{ClearValidUntil: true}, nil, nil},
+		{"before valid from", &until, domain.PromoCodePatch{ValidUntil: &early}, nil, ErrInvalidValidity},
+		{"cleared and set at once", &until, domain.PromoCodePatch{ValidUntil: &later, ClearValidUntil: true}, nil, ErrInvalidValidity},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakePromoRepo{promo: domain.PromoCode{ID: "promo-1", Active: true, ValidFrom: &from, ValidUntil: tt.current}}
+			logger := zlog.Zerolog{}
+			uc := NewPromoUsecase(repo, nil, &logger)
+			got, err := uc.UpdatePromoCode(context.Background(), "promo-1", &tt.patch)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) || repo.updated != nil {
+					t.Fatalf("error = %v, stored %v; want %v and nothing stored", err, repo.updated, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !equalTime(got.ValidUntil, tt.want) || !equalTime(repo.updated.ValidUntil, tt.want) {
+				t.Errorf("ValidUntil = %v, stored %v, want %v", got.ValidUntil, repo.updated.ValidUntil, tt.want)
+			}
+		})
+	}
+}
+
+func equalTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
@@ -0,0 +1,18 @@
+package reminder_uc
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"event-booker/internal/domain"
+)
+
+type reminderRepository interface {
+	ClaimDue(ctx context.Context, tx *sql.Tx, lead time.Duration, now time.Time, limit int) ([]*domain.Reminder, error)
+	ClaimPendingPayments(ctx context.Context, tx *sql.Tx, lead time.Duration, now time.Time, limit int) ([]*domain.Reminder, error)
+}
+
+type outbox interface {
+	Enqueue(ctx context.Context, tx *sql.Tx, notification *domain.Notification) error
+}
@@ -0,0 +1,98 @@
+package reminder_uc
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"event-booker/internal/domain"
+
+	"github.com/wb-go/wbf/dbpg"
+	"github.com/wb-go/wbf/zlog"
+)
+
+const batchSize = 100
+
+type claimFunc func(ctx context.Context, tx *sql.Tx, lead time.Duration, now time.Time, limit int) ([]*domain.Reminder, error)
+
+type ReminderUsecase struct {
+	db          *dbpg.DB
+	repo        reminderRepository
+	outbox      outbox
+	leads       []time.Duration
+	paymentLead time.Duration
+	logger      *zlog.Zerolog
+}
+
+// NewReminderUsecase creates a usecase that reminds attendees leads before
+// their events and, when paymentLead is positive, reminds holders of unpaid
+// bookings paymentLead before the booking expires.
+func NewReminderUsecase(db *dbpg.DB, repo reminderRepository, outbox outbox, leads []time.Duration, paymentLead time.Duration, logger *zlog.Zerolog) *ReminderUsecase {
+	return &ReminderUsecase{db: db, repo: repo, outbox: outbox, leads: leads, paymentLead: paymentLead, logger: logger}
+}
+
+// SendDueReminders enqueues every reminder that has become due and returns how
+// many were enqueued. Each reminder is claimed in the same transaction as its
+// outbox message, so a reminder is never lost or sent twice.
+func (uc *ReminderUsecase) SendDueReminders(ctx context.Context) (int, error) {
+	sent := 0
+	for _, lead := range uc.leads {
+		if lead <= 0 {
+			continue
+		}
+		n, err := uc.sendAll(ctx, domain.NotificationEventReminder, uc.repo.ClaimDue, lead)
+		sent += n
+		if err != nil {
+			return sent, err
+		}
+	}
+	if uc.paymentLead > 0 {
+		n, err := uc.sendAll(ctx, domain.NotificationPaymentPending, uc.repo.ClaimPendingPayments, uc.paymentLead)
+		sent += n
+		if err != nil {
+			return sent, err
+		}
+	}
+	return sent, nil
+}
+
+func (uc *ReminderUsecase) sendAll(ctx context.Context, kind domain.NotificationKind, claim claimFunc, lead time.Duration) (int, error) {
+	sent := 0
+	for {
+		n, err := uc.sendBatch(ctx, kind, claim, lead)
+		sent += n
+		if err != nil || n < batchSize {
+			return sent, err
+		}
+	}
+}
+
+func (uc *ReminderUsecase) sendBatch(ctx context.Context, kind domain.NotificationKind, claim claimFunc, lead time.Duration) (int, error) {
+	tx, err := uc.db.BeginTx(ctx, nil)
+	if err != nil {
+		uc.logger.Error().Err(err).Msg("failed to begin transaction")
+		return 0, err
+	}
+	defer tx.Rollback()
+	reminders, err := claim(ctx, tx, lead, time.Now(), batchSize)
+	if err != nil {
+		uc.logger.Error().Err(err).Str("kind", string(kind)).Dur("lead", lead).Msg("failed to claim due reminders")
+		return 0, err
+	}
+	for _, reminder := range reminders {
+		if err := uc.outbox.Enqueue(ctx, tx, &domain.Notification{
+			Kind:    kind,
+			UserID:  reminder.Booking.UserID,
+			Booking: reminder.Booking,
+			Event:   reminder.Event,
+		}); err != nil {
+			uc.logger.Error().Err(err).Str("booking_id", reminder.Booking.ID).Msg("failed to enqueue reminder")
+			return 0, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		uc.logger.Error().Err(err).Msg("failed to commit transaction")
+		return 0, err
+	}
+	return len(reminders), nil
+}
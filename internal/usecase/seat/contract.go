@@ -0,0 +1,19 @@
+package seat_uc
+
+import (
+	"context"
+	"database/sql"
+
+	"event-booker/internal/domain"
+)
+
+type seatRepository interface {
+	CreateLayout(ctx context.Context, tx *sql.Tx, layout *domain.SeatLayout) error
+	GetLayout(ctx context.Context, id string) (*domain.SeatLayout, error)
+	ListLayouts(ctx context.Context) ([]*domain.SeatLayout, error)
+	GetSeatMap(ctx context.Context, eventID string) ([]*domain.EventSeat, error)
+}
+
+type eventRepository interface {
+	GetByID(ctx context.Context, id string) (*domain.Event, error)
+}
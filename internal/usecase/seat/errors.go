@@ -0,0 +1,14 @@
+package seat_uc
+
+import "errors"
+
+var (
+	ErrLayoutNotFound     = errors.New("seat layout not found")
+	ErrInvalidLayoutName  = errors.New("layout name must not be empty")
+	ErrNoSeats            = errors.New("layout must have at least one seat")
+	ErrTooManySeats       = errors.New("layout has too many seats")
+	ErrInvalidSeat        = errors.New("seats need a section, a row and a positive number")
+	ErrDuplicateSeat      = errors.New("layout lists the same seat twice")
+	ErrEventNotFound      = errors.New("event not found")
+	ErrNotReservedSeating = errors.New("event does not have reserved seating")
+)
@@ -0,0 +1,119 @@
+package seat_uc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"event-booker/internal/domain"
+	"event-booker/internal/repository"
+
+	"github.com/google/uuid"
+	"github.com/wb-go/wbf/dbpg"
+	"github.com/wb-go/wbf/zlog"
+)
+
+const maxLayoutSeats = 20000
+
+type SeatUsecase struct {
+	db        *dbpg.DB
+	repo      seatRepository
+	eventRepo eventRepository
+	logger    *zlog.Zerolog
+}
+
+func NewSeatUsecase(db *dbpg.DB, repo seatRepository, eventRepo eventRepository, logger *zlog.Zerolog) *SeatUsecase {
+	return &SeatUsecase{db: db, repo: repo, eventRepo: eventRepo, logger: logger}
+}
+
+// CreateLayout stores a new layout built from the name and seats of input;
+// identifiers are assigned here.
+func (uc *SeatUsecase) CreateLayout(ctx context.Context, input *domain.SeatLayout) (*domain.SeatLayout, error) {
+	name := strings.TrimSpace(input.Name)
+	if name == "" {
+		return nil, ErrInvalidLayoutName
+	}
+	if len(input.Seats) == 0 {
+		return nil, ErrNoSeats
+	}
+	if len(input.Seats) > maxLayoutSeats {
+		return nil, ErrTooManySeats
+	}
+	layout := &domain.SeatLayout{
+		ID:        uuid.NewString(),
+		Name:      name,
+		Seats:     make([]*domain.Seat, 0, len(input.Seats)),
+		CreatedAt: time.Now(),
+	}
+	seen := make(map[string]struct{}, len(input.Seats))
+	for _, in := range input.Seats {
+		section, row := strings.TrimSpace(in.Section), strings.TrimSpace(in.Row)
+		if section == "" || row == "" || in.Number <= 0 {
+			return nil, ErrInvalidSeat
+		}
+		key := fmt.Sprintf("%s\x00%s\x00%d", section, row, in.Number)
+		if _, ok := seen[key]; ok {
+			return nil, ErrDuplicateSeat
+		}
+		seen[key] = struct{}{}
+		layout.Seats = append(layout.Seats, &domain.Seat{
+			ID:         uuid.NewString(),
+			Section:    section,
+			Row:        row,
+			Number:     in.Number,
+			Accessible: in.Accessible,
+		})
+	}
+	tx, err := uc.db.BeginTx(ctx, nil)
+	if err != nil {
+		uc.logger.Error().Err(err).Msg("failed to begin transaction")
+		return nil, err
+	}
+	defer tx.Rollback()
+	if err := uc.repo.CreateLayout(ctx, tx, layout); err != nil {
+		if errors.Is(err, repository.ErrAlreadyExists) {
+			return nil, ErrDuplicateSeat
+		}
+		uc.logger.Error().Err(err).Str("layout_id", layout.ID).Msg("failed to create seat layout")
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		uc.logger.Error().Err(err).Msg("failed to commit transaction")
+		return nil, err
+	}
+	uc.logger.Info().Str("layout_id", layout.ID).Int("seats", len(layout.Seats)).Msg("Seat layout created")
+	return layout, nil
+}
+
+func (uc *SeatUsecase) GetLayout(ctx context.Context, id string) (*domain.SeatLayout, error) {
+	layout, err := uc.repo.GetLayout(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrLayoutNotFound
+		}
+		return nil, err
+	}
+	return layout, nil
+}
+
+func (uc *SeatUsecase) ListLayouts(ctx context.Context) ([]*domain.SeatLayout, error) {
+	return uc.repo.ListLayouts(ctx)
+}
+
+// GetSeatMap returns every seat of a reserved-seating event with its
+// availability.
+func (uc *SeatUsecase) GetSeatMap(ctx context.Context, eventID string) ([]*domain.EventSeat, error) {
+	event, err := uc.eventRepo.GetByID(ctx, eventID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrEventNotFound
+		}
+		return nil, err
+	}
+	if !event.ReservedSeating() {
+		return nil, ErrNotReservedSeating
+	}
+	return uc.repo.GetSeatMap(ctx, eventID)
+}
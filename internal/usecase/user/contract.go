@@ -2,10 +2,18 @@ package user_uc
 
 import (
 	"context"
+	"time"
+
 	"event-booker/internal/domain"
 )
 
 type userRepository interface {
 	Create(ctx context.Context, user *domain.User) error
 	GetByID(ctx context.Context, id string) (*domain.User, error)
+	GetByEmail(ctx context.Context, email string) (*domain.User, error)
+	SavePreferences(ctx context.Context, prefs *domain.NotificationPreferences) error
+}
+
+type tokenIssuer interface {
+	Issue(user *domain.User) (string, time.Time, error)
 }
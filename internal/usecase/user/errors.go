@@ -3,5 +3,12 @@ package user_uc
 import "errors"
 
 var (
-	ErrUserNotFound = errors.New("user not found")
+	ErrUserNotFound       = errors.New("user not found")
+	ErrInvalidRole        = errors.New("invalid role")
+	ErrEmailTaken         = errors.New("email is already registered")
+	ErrPasswordTooShort   = errors.New("password must be at least 8 characters")
+	ErrInvalidCredentials = errors.New("invalid email or password")
+	ErrForbidden          = errors.New("forbidden")
+	ErrInvalidLocale      = errors.New("locale must be en or ru")
+	ErrInvalidTimezone    = errors.New("unknown time zone")
 )
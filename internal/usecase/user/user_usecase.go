@@ -5,38 +5,85 @@ import (
 	"errors"
 	"time"
 
+	"event-booker/internal/auth"
 	"event-booker/internal/domain"
 	"event-booker/internal/repository"
 
 	"github.com/google/uuid"
 )
 
+const minPasswordLength = 8
+
 type UserUsecase struct {
-	repo userRepository
+	repo   userRepository
+	tokens tokenIssuer
 }
 
-func NewUserUsecase(repo userRepository) *UserUsecase {
-	return &UserUsecase{repo: repo}
+func NewUserUsecase(repo userRepository, tokens tokenIssuer) *UserUsecase {
+	return &UserUsecase{repo: repo, tokens: tokens}
 }
 
-func (uc *UserUsecase) RegisterUser(ctx context.Context, email, telegram string, role domain.UserRole) (*domain.User, error) {
+// RegisterUser creates an account from the profile fields of input. Locale and
+// time zone default to English and UTC.
+func (uc *UserUsecase) RegisterUser(ctx context.Context, input *domain.User, password string, actor *domain.Principal) (*domain.User, error) {
+	role := input.Role
+	if role == "" {
+		role = domain.RoleUser
+	}
 	if role != domain.RoleUser && role != domain.RoleAdmin {
-		return nil, errors.New("invalid role")
+		return nil, ErrInvalidRole
+	}
+	if role == domain.RoleAdmin && !actor.IsAdmin() {
+		return nil, ErrForbidden
 	}
 	user := &domain.User{
-		ID:        uuid.NewString(),
-		Email:     email,
-		Telegram:  telegram,
-		Role:      role,
-		CreatedAt: time.Now(),
+		Email:    input.Email,
+		Telegram: input.Telegram,
+		Role:     role,
+		Locale:   input.Locale,
+		Timezone: input.Timezone,
 	}
-	if err := uc.repo.Create(ctx, user); err != nil {
-		return nil, err
+	return uc.createUser(ctx, user, password)
+}
+
+func (uc *UserUsecase) Login(ctx context.Context, email, password string) (string, time.Time, *domain.User, error) {
+	user, err := uc.repo.GetByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return "", time.Time{}, nil, ErrInvalidCredentials
+		}
+		return "", time.Time{}, nil, err
 	}
-	return user, nil
+	if err := auth.CheckPassword(user.PasswordHash, password); err != nil {
+		return "", time.Time{}, nil, ErrInvalidCredentials
+	}
+	token, expiresAt, err := uc.tokens.Issue(user)
+	if err != nil {
+		return "", time.Time{}, nil, err
+	}
+	return token, expiresAt, user, nil
 }
 
-func (uc *UserUsecase) GetUser(ctx context.Context, id string) (*domain.User, error) {
+// EnsureAdmin creates the bootstrap administrator account if it does not exist yet.
+func (uc *UserUsecase) EnsureAdmin(ctx context.Context, email, password string) error {
+	_, err := uc.repo.GetByEmail(ctx, email)
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, repository.ErrNotFound) {
+		return err
+	}
+	_, err = uc.createUser(ctx, &domain.User{Email: email, Role: domain.RoleAdmin}, password)
+	if errors.Is(err, ErrEmailTaken) {
+		return nil
+	}
+	return err
+}
+
+func (uc *UserUsecase) GetUser(ctx context.Context, id string, actor *domain.Principal) (*domain.User, error) {
+	if !actor.CanAccessUser(id) {
+		return nil, ErrForbidden
+	}
 	user, err := uc.repo.GetByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, repository.ErrNotFound) {
@@ -46,3 +93,70 @@ func (uc *UserUsecase) GetUser(ctx context.Context, id string) (*domain.User, er
 	}
 	return user, nil
 }
+
+func (uc *UserUsecase) GetNotificationPreferences(ctx context.Context, userID string, actor *domain.Principal) (*domain.NotificationPreferences, error) {
+	user, err := uc.GetUser(ctx, userID, actor)
+	if err != nil {
+		return nil, err
+	}
+	return &user.Preferences, nil
+}
+
+func (uc *UserUsecase) UpdateNotificationPreferences(ctx context.Context, userID string, patch *domain.NotificationPreferencesPatch, actor *domain.Principal) (*domain.NotificationPreferences, error) {
+	user, err := uc.GetUser(ctx, userID, actor)
+	if err != nil {
+		return nil, err
+	}
+	prefs := user.Preferences
+	if patch.Email != nil {
+		prefs.Email = *patch.Email
+	}
+	if patch.Telegram != nil {
+		prefs.Telegram = *patch.Telegram
+	}
+	if patch.Webhook != nil {
+		prefs.Webhook = *patch.Webhook
+	}
+	if patch.Reminders != nil {
+		prefs.Reminders = *patch.Reminders
+	}
+	now := time.Now()
+	prefs.UpdatedAt = &now
+	if err := uc.repo.SavePreferences(ctx, &prefs); err != nil {
+		return nil, err
+	}
+	return &prefs, nil
+}
+
+func (uc *UserUsecase) createUser(ctx context.Context, user *domain.User, password string) (*domain.User, error) {
+	if len(password) < minPasswordLength {
+		return nil, ErrPasswordTooShort
+	}
+	if user.Locale == "" {
+		user.Locale = domain.DefaultLocale
+	}
+	if !user.Locale.Valid() {
+		return nil, ErrInvalidLocale
+	}
+	if user.Timezone == "" {
+		user.Timezone = domain.DefaultTimezone
+	}
+	if _, err := time.LoadLocation(user.Timezone); err != nil {
+		return nil, ErrInvalidTimezone
+	}
+	hash, err := auth.HashPassword(password)
+	if err != nil {
+		return nil, err
+	}
+	user.ID = uuid.NewString()
+	user.PasswordHash = hash
+	user.CreatedAt = time.Now()
+	user.Preferences = domain.DefaultNotificationPreferences(user.ID)
+	if err := uc.repo.Create(ctx, user); err != nil {
+		if errors.Is(err, repository.ErrAlreadyExists) {
+			return nil, ErrEmailTaken
+		}
+		return nil, err
+	}
+	return user, nil
+}
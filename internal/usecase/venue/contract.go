@@ -0,0 +1,18 @@
+package venue_uc
+
+import (
+	"context"
+	"database/sql"
+
+	"event-booker/internal/domain"
+)
+
+type venueRepository interface {
+	Create(ctx context.Context, v *domain.Venue) error
+	GetByID(ctx context.Context, id string) (*domain.Venue, error)
+	GetForUpdate(ctx context.Context, tx *sql.Tx, id string) (*domain.Venue, error)
+	List(ctx context.Context) ([]*domain.Venue, error)
+	Update(ctx context.Context, tx *sql.Tx, v *domain.Venue) error
+	MaxEventSeats(ctx context.Context, tx *sql.Tx, venueID string) (int, error)
+	Delete(ctx context.Context, id string) error
+}
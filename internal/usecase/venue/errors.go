@@ -0,0 +1,14 @@
+package venue_uc
+
+import "errors"
+
+var (
+	ErrVenueNotFound       = errors.New("venue not found")
+	ErrVenueInUse          = errors.New("venue has events")
+	ErrInvalidName         = errors.New("venue name must not be empty")
+	ErrInvalidAddress      = errors.New("venue address must not be empty")
+	ErrInvalidTimezone     = errors.New("venue timezone must be an IANA time zone name")
+	ErrInvalidCapacity     = errors.New("venue capacity must be positive")
+	ErrInvalidCoordinates  = errors.New("venue latitude and longitude must be given together and within range")
+	ErrCapacityBelowEvents = errors.New("venue capacity cannot be lower than the total seats of its active events")
+)
@@ -0,0 +1,163 @@
+package venue_uc
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"event-booker/internal/domain"
+	"event-booker/internal/repository"
+
+	"github.com/google/uuid"
+	"github.com/wb-go/wbf/dbpg"
+	"github.com/wb-go/wbf/zlog"
+)
+
+type VenueUsecase struct {
+	db     *dbpg.DB
+	repo   venueRepository
+	logger *zlog.Zerolog
+}
+
+func NewVenueUsecase(db *dbpg.DB, repo venueRepository, logger *zlog.Zerolog) *VenueUsecase {
+	return &VenueUsecase{db: db, repo: repo, logger: logger}
+}
+
+func (uc *VenueUsecase) CreateVenue(ctx context.Context, input *domain.Venue) (*domain.Venue, error) {
+	now := time.Now()
+	venue := &domain.Venue{
+		ID:        uuid.NewString(),
+		Name:      strings.TrimSpace(input.Name),
+		Address:   strings.TrimSpace(input.Address),
+		Timezone:  input.Timezone,
+		Capacity:  input.Capacity,
+		Latitude:  input.Latitude,
+		Longitude: input.Longitude,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := validate(venue); err != nil {
+		return nil, err
+	}
+	if err := uc.repo.Create(ctx, venue); err != nil {
+		uc.logger.Error().Err(err).Str("name", venue.Name).Msg("failed to create venue")
+		return nil, err
+	}
+	uc.logger.Info().Str("venue_id", venue.ID).Str("name", venue.Name).Msg("Venue created")
+	return venue, nil
+}
+
+func (uc *VenueUsecase) GetVenue(ctx context.Context, id string) (*domain.Venue, error) {
+	venue, err := uc.repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrVenueNotFound
+		}
+		return nil, err
+	}
+	return venue, nil
+}
+
+func (uc *VenueUsecase) ListVenues(ctx context.Context) ([]*domain.Venue, error) {
+	return uc.repo.List(ctx)
+}
+
+// UpdateVenue applies patch to the venue. Lowering the capacity is rejected
+// while an active event held there has more seats than the new capacity.
+func (uc *VenueUsecase) UpdateVenue(ctx context.Context, id string, patch *domain.VenuePatch) (*domain.Venue, error) {
+	tx, err := uc.db.BeginTx(ctx, nil)
+	if err != nil {
+		uc.logger.Error().Err(err).Msg("Failed to begin transaction")
+		return nil, err
+	}
+	defer tx.Rollback()
+	venue, err := uc.repo.GetForUpdate(ctx, tx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrVenueNotFound
+		}
+		uc.logger.Error().Err(err).Str("venue_id", id).Msg("failed to get venue for update")
+		return nil, err
+	}
+	if patch.Name != nil {
+		venue.Name = strings.TrimSpace(*patch.Name)
+	}
+	if patch.Address != nil {
+		venue.Address = strings.TrimSpace(*patch.Address)
+	}
+	if patch.Timezone != nil {
+		venue.Timezone = *patch.Timezone
+	}
+	if patch.Capacity != nil {
+		venue.Capacity = *patch.Capacity
+	}
+	if patch.Latitude != nil {
+		venue.Latitude = patch.Latitude
+	}
+	if patch.Longitude != nil {
+		venue.Longitude = patch.Longitude
+	}
+	if err := validate(venue); err != nil {
+		return nil, err
+	}
+	if patch.Capacity != nil {
+		seats, err := uc.repo.MaxEventSeats(ctx, tx, id)
+		if err != nil {
+			uc.logger.Error().Err(err).Str("venue_id", id).Msg("failed to get event seats of venue")
+			return nil, err
+		}
+		if seats > venue.Capacity {
+			return nil, ErrCapacityBelowEvents
+		}
+	}
+	venue.UpdatedAt = time.Now()
+	if err := uc.repo.Update(ctx, tx, venue); err != nil {
+		uc.logger.Error().Err(err).Str("venue_id", id).Msg("failed to update venue")
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		uc.logger.Error().Err(err).Str("venue_id", id).Msg("Failed to commit transaction")
+		return nil, err
+	}
+	return venue, nil
+}
+
+func (uc *VenueUsecase) DeleteVenue(ctx context.Context, id string) error {
+	if err := uc.repo.Delete(ctx, id); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrNotFound):
+			return ErrVenueNotFound
+		case errors.Is(err, repository.ErrInUse):
+			return ErrVenueInUse
+		}
+		uc.logger.Error().Err(err).Str("venue_id", id).Msg("failed to delete venue")
+		return err
+	}
+	return nil
+}
+
+func validate(venue *domain.Venue) error {
+	if venue.Name == "" {
+		return ErrInvalidName
+	}
+	if venue.Address == "" {
+		return ErrInvalidAddress
+	}
+	if venue.Timezone == "" || venue.Timezone == "Local" {
+		return ErrInvalidTimezone
+	}
+	if _, err := time.LoadLocation(venue.Timezone); err != nil {
+		return ErrInvalidTimezone
+	}
+	if venue.Capacity <= 0 {
+		return ErrInvalidCapacity
+	}
+	if (venue.Latitude == nil) != (venue.Longitude == nil) {
+		return ErrInvalidCoordinates
+	}
+	if venue.Latitude != nil && (*venue.Latitude < -90 || *venue.Latitude > 90 || *venue.Longitude < -180 || *venue.Longitude > 180) {
+		return ErrInvalidCoordinates
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package waitlist_uc
+
+import (
+	"context"
+	"database/sql"
+
+	"event-booker/internal/domain"
+)
+
+type waitlistRepository interface {
+	Create(ctx context.Context, entry *domain.WaitlistEntry) error
+	GetByEventID(ctx context.Context, eventID string) ([]*domain.WaitlistEntry, error)
+	GetWaitingForUpdate(ctx context.Context, tx *sql.Tx, eventID string) ([]*domain.WaitlistEntry, error)
+	Update(ctx context.Context, tx *sql.Tx, entry *domain.WaitlistEntry) error
+	CancelByEventID(ctx context.Context, tx *sql.Tx, eventID string) error
+}
+
+type eventRepository interface {
+	GetByID(ctx context.Context, id string) (*domain.Event, error)
+	GetForUpdate(ctx context.Context, tx *sql.Tx, id string) (*domain.Event, error)
+	DecrementAvailableSeats(ctx context.Context, tx *sql.Tx, id string, seats int) error
+	GetTier(ctx context.Context, id string) (*domain.TicketTier, error)
+	ListTiers(ctx context.Context, tx *sql.Tx, eventID string) ([]*domain.TicketTier, error)
+	DecrementTierSeats(ctx context.Context, tx *sql.Tx, tierID string, seats int) error
+}
+
+type bookingRepository interface {
+	Create(ctx context.Context, tx *sql.Tx, booking *domain.Booking) error
+	HasActiveBooking(ctx context.Context, tx *sql.Tx, eventID, userID string) (bool, error)
+}
+
+type outbox interface {
+	Enqueue(ctx context.Context, tx *sql.Tx, notification *domain.Notification) error
+}
+
+type publisher interface {
+	Publish(ctx context.Context, tx *sql.Tx, event *domain.DomainEvent) error
+}
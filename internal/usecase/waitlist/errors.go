@@ -0,0 +1,19 @@
+package waitlist_uc
+
+import "errors"
+
+var (
+	ErrEventNotFound      = errors.New("event not found")
+	ErrForbidden          = errors.New("forbidden")
+	ErrEventNotActive     = errors.New("event is not active")
+	ErrSeatsAvailable     = errors.New("seats are available, book directly")
+	ErrAlreadyWaitlisted  = errors.New("user is already on the waitlist")
+	ErrAlreadyBooked      = errors.New("user already holds a booking for this event")
+	ErrInvalidQuantity    = errors.New("quantity must be positive")
+	ErrQuantityTooLarge   = errors.New("quantity exceeds per-booking limit")
+	ErrQuantityExceedsCap = errors.New("quantity exceeds event capacity")
+	ErrReservedSeating    = errors.New("reserved-seating events have no waitlist")
+	ErrTierRequired       = errors.New("event has ticket tiers, choose one")
+	ErrTierNotFound       = errors.New("ticket tier not found for this event")
+	ErrTierNotOnSale      = errors.New("ticket tier is not on sale")
+)
@@ -0,0 +1,261 @@
+package waitlist_uc
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"event-booker/internal/config"
+	"event-booker/internal/domain"
+	"event-booker/internal/repository"
+
+	"github.com/google/uuid"
+	"github.com/wb-go/wbf/zlog"
+)
+
+type WaitlistUsecase struct {
+	repo        waitlistRepository
+	eventRepo   eventRepository
+	bookingRepo bookingRepository
+	outbox      outbox
+	events      publisher
+	cfg         *config.Config
+	logger      *zlog.Zerolog
+}
+
+func NewWaitlistUsecase(repo waitlistRepository, eventRepo eventRepository, bookingRepo bookingRepository, outbox outbox, events publisher, cfg *config.Config, logger *zlog.Zerolog) *WaitlistUsecase {
+	return &WaitlistUsecase{
+		repo:        repo,
+		eventRepo:   eventRepo,
+		bookingRepo: bookingRepo,
+		outbox:      outbox,
+		events:      events,
+		cfg:         cfg,
+		logger:      logger,
+	}
+}
+
+// JoinWaitlist queues the user for a sold-out event. On events with ticket
+// tiers the user waits for tickets of the tier named by tierID.
+func (uc *WaitlistUsecase) JoinWaitlist(ctx context.Context, eventID, userID string, quantity int, tierID string) (*domain.WaitlistEntry, error) {
+	if quantity <= 0 {
+		return nil, ErrInvalidQuantity
+	}
+	event, err := uc.eventRepo.GetByID(ctx, eventID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrEventNotFound
+		}
+		uc.logger.Error().Err(err).Str("event_id", eventID).Msg("failed to get event")
+		return nil, err
+	}
+	if event.Status != domain.EventActive || !event.Date.After(time.Now()) {
+		return nil, ErrEventNotActive
+	}
+	if event.ReservedSeating() {
+		return nil, ErrReservedSeating
+	}
+	if event.MaxPerBooking > 0 && quantity > event.MaxPerBooking {
+		return nil, ErrQuantityTooLarge
+	}
+	if quantity > event.TotalSeats {
+		return nil, ErrQuantityExceedsCap
+	}
+	tiers, err := uc.eventRepo.ListTiers(ctx, nil, eventID)
+	if err != nil {
+		uc.logger.Error().Err(err).Str("event_id", eventID).Msg("failed to list ticket tiers")
+		return nil, err
+	}
+	var tier *domain.TicketTier
+	for _, t := range tiers {
+		if t.ID == tierID {
+			tier = t
+		}
+	}
+	switch {
+	case tierID == "" && len(tiers) > 0:
+		return nil, ErrTierRequired
+	case tierID != "" && tier == nil:
+		return nil, ErrTierNotFound
+	}
+	if tier != nil {
+		if !tier.OnSale(time.Now()) {
+			return nil, ErrTierNotOnSale
+		}
+		if quantity > tier.Quota {
+			return nil, ErrQuantityExceedsCap
+		}
+		if event.Available >= quantity && tier.Available >= quantity {
+			return nil, ErrSeatsAvailable
+		}
+	} else if event.Available >= quantity {
+		return nil, ErrSeatsAvailable
+	}
+	booked, err := uc.bookingRepo.HasActiveBooking(ctx, nil, eventID, userID)
+	if err != nil {
+		uc.logger.Error().Err(err).Str("event_id", eventID).Str("user_id", userID).Msg("failed to check existing booking")
+		return nil, err
+	}
+	if booked {
+		return nil, ErrAlreadyBooked
+	}
+	entry := &domain.WaitlistEntry{
+		ID:        uuid.NewString(),
+		EventID:   eventID,
+		UserID:    userID,
+		Quantity:  quantity,
+		Status:    domain.WaitlistWaiting,
+		CreatedAt: time.Now(),
+	}
+	if tier != nil {
+		entry.TierID = &tier.ID
+	}
+	if err := uc.repo.Create(ctx, entry); err != nil {
+		if errors.Is(err, repository.ErrAlreadyExists) {
+			return nil, ErrAlreadyWaitlisted
+		}
+		uc.logger.Error().Err(err).Str("event_id", eventID).Str("user_id", userID).Msg("failed to create waitlist entry")
+		return nil, err
+	}
+	return entry, nil
+}
+
+func (uc *WaitlistUsecase) ListWaitlist(ctx context.Context, eventID string, actor *domain.Principal) ([]*domain.WaitlistEntry, error) {
+	event, err := uc.eventRepo.GetByID(ctx, eventID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrEventNotFound
+		}
+		return nil, err
+	}
+	if !actor.CanManageEvents(event.OrganizerID) {
+		return nil, ErrForbidden
+	}
+	return uc.repo.GetByEventID(ctx, eventID)
+}
+
+// PromoteInTx moves waiting entries into pending bookings in FIFO order while
+// the event has enough free seats, queueing a notification for each of them.
+// Promotion stops at the first entry that does not fit so that later, smaller
+// requests cannot jump the queue. Entries for a ticket tier also need room in
+// their tier; once one does not fit, the rest of that tier's queue waits while
+// other tiers carry on. Reserved-seating events have no waitlist, as a promoted
+// entry could not pick its seats, and events that have already started promote
+// nobody.
+func (uc *WaitlistUsecase) PromoteInTx(ctx context.Context, tx *sql.Tx, eventID string) ([]*domain.Booking, error) {
+	event, err := uc.eventRepo.GetForUpdate(ctx, tx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	if event.Status != domain.EventActive || !event.Date.After(time.Now()) || event.Available <= 0 || event.ReservedSeating() {
+		return nil, nil
+	}
+	entries, err := uc.repo.GetWaitingForUpdate(ctx, tx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	tierList, err := uc.eventRepo.ListTiers(ctx, tx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	tiers := make(map[string]*domain.TicketTier, len(tierList))
+	for _, tier := range tierList {
+		tiers[tier.ID] = tier
+	}
+	blocked := make(map[string]bool)
+	ttl := event.BookingTTL
+	if ttl == 0 {
+		ttl = uc.cfg.Scheduler.BookingTTL
+	}
+	available := event.Available
+	var promoted []*domain.Booking
+	for _, entry := range entries {
+		if entry.Quantity > available {
+			break
+		}
+		now := time.Now()
+		var tier *domain.TicketTier
+		if entry.TierID != nil {
+			tier = tiers[*entry.TierID]
+			if tier == nil || blocked[tier.ID] {
+				continue
+			}
+			if !tier.OnSale(now) || entry.Quantity > tier.Available {
+				blocked[tier.ID] = true
+				continue
+			}
+		}
+		booking := &domain.Booking{
+			ID:        uuid.NewString(),
+			EventID:   eventID,
+			UserID:    entry.UserID,
+			Quantity:  entry.Quantity,
+			TierID:    entry.TierID,
+			Status:    domain.BookingPending,
+			CreatedAt: now,
+			ExpiresAt: now.Add(ttl),
+		}
+		booked, err := uc.bookingRepo.HasActiveBooking(ctx, tx, eventID, entry.UserID)
+		if err != nil {
+			return nil, err
+		}
+		if booked {
+			uc.logger.Warn().
+				Str("event_id", eventID).
+				Str("user_id", entry.UserID).
+				Msg("waitlisted user already holds a booking, dropping entry")
+			entry.Status = domain.WaitlistCancelled
+			if err := uc.repo.Update(ctx, tx, entry); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if err := uc.bookingRepo.Create(ctx, tx, booking); err != nil {
+			return nil, err
+		}
+		if err := uc.eventRepo.DecrementAvailableSeats(ctx, tx, eventID, entry.Quantity); err != nil {
+			return nil, err
+		}
+		if tier != nil {
+			if err := uc.eventRepo.DecrementTierSeats(ctx, tx, tier.ID, entry.Quantity); err != nil {
+				return nil, err
+			}
+			tier.Available -= entry.Quantity
+		}
+		entry.Status = domain.WaitlistPromoted
+		entry.BookingID = &booking.ID
+		entry.PromotedAt = &now
+		if err := uc.repo.Update(ctx, tx, entry); err != nil {
+			return nil, err
+		}
+		if err := uc.outbox.Enqueue(ctx, tx, &domain.Notification{
+			Kind:    domain.NotificationWaitlistPromoted,
+			UserID:  booking.UserID,
+			Booking: booking,
+			Event:   event,
+		}); err != nil {
+			return nil, err
+		}
+		if err := uc.events.Publish(ctx, tx, &domain.DomainEvent{
+			Type:    domain.DomainBookingCreated,
+			Event:   event,
+			Booking: booking,
+		}); err != nil {
+			return nil, err
+		}
+		available -= entry.Quantity
+		promoted = append(promoted, booking)
+		uc.logger.Info().
+			Str("event_id", eventID).
+			Str("entry_id", entry.ID).
+			Str("booking_id", booking.ID).
+			Int("quantity", booking.Quantity).
+			Msg("Waitlist entry promoted")
+	}
+	return promoted, nil
+}
+
+func (uc *WaitlistUsecase) CancelForEventInTx(ctx context.Context, tx *sql.Tx, eventID string) error {
+	return uc.repo.CancelByEventID(ctx, tx, eventID)
+}
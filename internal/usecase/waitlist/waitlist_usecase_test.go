@@ -0,0 +1,67 @@
+package waitlist_uc
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"event-booker/internal/config"
+	"event-booker/internal/domain"
+
+	"github.com/wb-go/wbf/zlog"
+)
+
+type fakeEventRepo struct {
+	eventRepository
+	event *domain.Event
+}
+
+func (r *fakeEventRepo) GetForUpdate(context.Context, *sql.Tx, string) (*domain.Event, error) {
+	return r.event, nil
+}
+
+func (r *fakeEventRepo) ListTiers(context.Context, *sql.Tx, string) ([]*domain.TicketTier, error) {
+	return nil, nil
+}
+
+type fakeWaitlistRepo struct {
+	waitlistRepository
+	locked bool
+}
+
+func (r *fakeWaitlistRepo) GetWaitingForUpdate(context.Context, *sql.Tx, string) ([]*domain.WaitlistEntry, error) {
+	r.locked = true
+	return nil, nil
+}
+
+func TestPromoteInTxSkipsClosedEvents(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name      string
+		status    domain.EventStatus
+		date      time.Time
+		available int
+		want      bool
+	}{
+		{"upcoming with free seats", domain.EventActive, now.Add(time.Hour), 2, true},
+		{"already started", domain.EventActive, now.Add(-time.Minute), 2, false},
+		{"cancelled", domain.EventCancelled, now.Add(time.Hour), 2, false},
+		{"sold out", domain.EventActive, now.Add(time.Hour), 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events := &fakeEventRepo{event: &domain.Event{ID: "event-1", Status: tt.status, Date: tt.date, TotalSeats: 10, Available: tt.available}}
+			entries := &fakeWaitlistRepo{}
+			logger := zlog.Zerolog{}
+			uc := NewWaitlistUsecase(entries, events, nil, nil, nil, &config.Config{}, &logger)
+			promoted, err := uc.PromoteInTx(context.Background(), nil, "event-1")
+			if err != nil || len(promoted) != 0 {
+				t.Fatalf("PromoteInTx = %v, %v", promoted, err)
+			}
+			if entries.locked != tt.want {
+				t.Errorf("waiting entries considered = %v, want %v", entries.locked, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package webhook_uc
+
+import (
+	"context"
+
+	"event-booker/internal/domain"
+)
+
+type webhookRepository interface {
+	CreateEndpoint(ctx context.Context, endpoint *domain.WebhookEndpoint) error
+	GetEndpoint(ctx context.Context, id string) (*domain.WebhookEndpoint, error)
+	ListEndpoints(ctx context.Context, userID string) ([]*domain.WebhookEndpoint, error)
+	ListOrganizerEndpoints(ctx context.Context, organizerID string) ([]*domain.WebhookEndpoint, error)
+	DeleteEndpoint(ctx context.Context, id string) error
+	ListDeliveries(ctx context.Context, endpointID string, limit int) ([]*domain.WebhookDelivery, error)
+	CreateSubscription(ctx context.Context, sub *domain.WebhookSubscription) error
+	GetSubscription(ctx context.Context, id string) (*domain.WebhookSubscription, error)
+	ListSubscriptions(ctx context.Context) ([]*domain.WebhookSubscription, error)
+	UpdateSubscription(ctx context.Context, sub *domain.WebhookSubscription) error
+	DeleteSubscription(ctx context.Context, id string) error
+	ListSubscriptionDeliveries(ctx context.Context, subscriptionID string, limit int) ([]*domain.WebhookDelivery, error)
+}
+
+type pinger interface {
+	Ping(ctx context.Context, subscriptionID string) (*domain.WebhookDelivery, error)
+}
@@ -0,0 +1,14 @@
+package webhook_uc
+
+import "errors"
+
+var (
+	ErrEndpointNotFound     = errors.New("webhook endpoint not found")
+	ErrSubscriptionNotFound = errors.New("webhook subscription not found")
+	ErrOrganizerNotFound    = errors.New("organizer not found")
+	ErrForbidden            = errors.New("forbidden")
+	ErrInvalidURL           = errors.New("webhook url must be an absolute http or https url")
+	ErrTooManyEndpoints     = errors.New("too many webhook endpoints")
+	ErrInvalidEventType     = errors.New("unknown webhook event type")
+	ErrSecretTooShort       = errors.New("webhook secret must be at least 16 characters")
+)
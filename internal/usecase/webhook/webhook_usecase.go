@@ -0,0 +1,282 @@
+package webhook_uc
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"net/url"
+	"time"
+
+	"event-booker/internal/domain"
+	"event-booker/internal/repository"
+
+	"github.com/google/uuid"
+	"github.com/wb-go/wbf/zlog"
+)
+
+const (
+	maxEndpointsPerOwner = 10
+	deliveriesLimit      = 100
+	secretBytes          = 32
+	minSecretLength      = 16
+)
+
+type WebhookUsecase struct {
+	repo   webhookRepository
+	pinger pinger
+	logger *zlog.Zerolog
+}
+
+func NewWebhookUsecase(repo webhookRepository, pinger pinger, logger *zlog.Zerolog) *WebhookUsecase {
+	return &WebhookUsecase{repo: repo, pinger: pinger, logger: logger}
+}
+
+// CreateEndpoint registers a new endpoint for the user's notifications. The
+// returned endpoint carries the signing secret, which is not exposed again
+// afterwards.
+func (uc *WebhookUsecase) CreateEndpoint(ctx context.Context, userID, rawURL string, actor *domain.Principal) (*domain.WebhookEndpoint, error) {
+	if !actor.CanAccessUser(userID) {
+		return nil, ErrForbidden
+	}
+	existing, err := uc.repo.ListEndpoints(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return uc.createEndpoint(ctx, &domain.WebhookEndpoint{UserID: &userID}, rawURL, existing)
+}
+
+// CreateOrganizerEndpoint registers a new endpoint that receives the domain
+// events of the organizer's events. Only the organizer's owners and managers
+// may add one.
+func (uc *WebhookUsecase) CreateOrganizerEndpoint(ctx context.Context, organizerID, rawURL string, actor *domain.Principal) (*domain.WebhookEndpoint, error) {
+	if !actor.CanManageEvents(&organizerID) {
+		return nil, ErrForbidden
+	}
+	existing, err := uc.repo.ListOrganizerEndpoints(ctx, organizerID)
+	if err != nil {
+		return nil, err
+	}
+	endpoint, err := uc.createEndpoint(ctx, &domain.WebhookEndpoint{OrganizerID: &organizerID}, rawURL, existing)
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, ErrOrganizerNotFound
+	}
+	return endpoint, err
+}
+
+func (uc *WebhookUsecase) createEndpoint(ctx context.Context, endpoint *domain.WebhookEndpoint, rawURL string, existing []*domain.WebhookEndpoint) (*domain.WebhookEndpoint, error) {
+	u, err := parseURL(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	if len(existing) >= maxEndpointsPerOwner {
+		return nil, ErrTooManyEndpoints
+	}
+	secret, err := newSecret()
+	if err != nil {
+		return nil, err
+	}
+	endpoint.ID = uuid.NewString()
+	endpoint.URL = u
+	endpoint.Secret = secret
+	endpoint.CreatedAt = time.Now()
+	if err := uc.repo.CreateEndpoint(ctx, endpoint); err != nil {
+		if !errors.Is(err, repository.ErrNotFound) {
+			uc.logger.Error().Err(err).Str("endpoint_id", endpoint.ID).Msg("failed to create webhook endpoint")
+		}
+		return nil, err
+	}
+	uc.logger.Info().Str("endpoint_id", endpoint.ID).Msg("Webhook endpoint created")
+	return endpoint, nil
+}
+
+func (uc *WebhookUsecase) ListEndpoints(ctx context.Context, userID string, actor *domain.Principal) ([]*domain.WebhookEndpoint, error) {
+	if !actor.CanAccessUser(userID) {
+		return nil, ErrForbidden
+	}
+	return uc.repo.ListEndpoints(ctx, userID)
+}
+
+func (uc *WebhookUsecase) ListOrganizerEndpoints(ctx context.Context, organizerID string, actor *domain.Principal) ([]*domain.WebhookEndpoint, error) {
+	if !actor.CanManageEvents(&organizerID) {
+		return nil, ErrForbidden
+	}
+	return uc.repo.ListOrganizerEndpoints(ctx, organizerID)
+}
+
+func (uc *WebhookUsecase) DeleteEndpoint(ctx context.Context, id string, actor *domain.Principal) error {
+	if _, err := uc.getEndpoint(ctx, id, actor); err != nil {
+		return err
+	}
+	if err := uc.repo.DeleteEndpoint(ctx, id); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrEndpointNotFound
+		}
+		return err
+	}
+	uc.logger.Info().Str("endpoint_id", id).Msg("Webhook endpoint deleted")
+	return nil
+}
+
+func (uc *WebhookUsecase) ListDeliveries(ctx context.Context, endpointID string, actor *domain.Principal) ([]*domain.WebhookDelivery, error) {
+	if _, err := uc.getEndpoint(ctx, endpointID, actor); err != nil {
+		return nil, err
+	}
+	return uc.repo.ListDeliveries(ctx, endpointID, deliveriesLimit)
+}
+
+// canManageEndpoint reports whether actor may see and delete endpoint: its user
+// or, for organizer endpoints, the organizer's owners and managers.
+func canManageEndpoint(actor *domain.Principal, endpoint *domain.WebhookEndpoint) bool {
+	if endpoint.OrganizerID != nil {
+		return actor.CanManageEvents(endpoint.OrganizerID)
+	}
+	return endpoint.UserID != nil && actor.CanAccessUser(*endpoint.UserID)
+}
+
+func (uc *WebhookUsecase) getEndpoint(ctx context.Context, id string, actor *domain.Principal) (*domain.WebhookEndpoint, error) {
+	endpoint, err := uc.repo.GetEndpoint(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrEndpointNotFound
+		}
+		return nil, err
+	}
+	if !canManageEndpoint(actor, endpoint) {
+		return nil, ErrForbidden
+	}
+	return endpoint, nil
+}
+
+// CreateSubscription registers an integrator's subscription to domain events.
+// A secret is generated when input carries none; the returned subscription
+// carries it either way.
+func (uc *WebhookUsecase) CreateSubscription(ctx context.Context, input *domain.WebhookSubscription) (*domain.WebhookSubscription, error) {
+	u, err := parseURL(input.URL)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateEventTypes(input.EventTypes); err != nil {
+		return nil, err
+	}
+	secret := input.Secret
+	if secret == "" {
+		if secret, err = newSecret(); err != nil {
+			return nil, err
+		}
+	} else if len(secret) < minSecretLength {
+		return nil, ErrSecretTooShort
+	}
+	now := time.Now()
+	sub := &domain.WebhookSubscription{
+		ID:         uuid.NewString(),
+		URL:        u,
+		Secret:     secret,
+		EventTypes: input.EventTypes,
+		Active:     true,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+	if err := uc.repo.CreateSubscription(ctx, sub); err != nil {
+		uc.logger.Error().Err(err).Msg("failed to create webhook subscription")
+		return nil, err
+	}
+	uc.logger.Info().Str("subscription_id", sub.ID).Str("url", sub.URL).Msg("Webhook subscription created")
+	return sub, nil
+}
+
+func (uc *WebhookUsecase) ListSubscriptions(ctx context.Context) ([]*domain.WebhookSubscription, error) {
+	return uc.repo.ListSubscriptions(ctx)
+}
+
+func (uc *WebhookUsecase) UpdateSubscription(ctx context.Context, id string, patch *domain.WebhookSubscriptionPatch) (*domain.WebhookSubscription, error) {
+	sub, err := uc.getSubscription(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if patch.URL != nil {
+		if sub.URL, err = parseURL(*patch.URL); err != nil {
+			return nil, err
+		}
+	}
+	if patch.EventTypes != nil {
+		if err := validateEventTypes(*patch.EventTypes); err != nil {
+			return nil, err
+		}
+		sub.EventTypes = *patch.EventTypes
+	}
+	if patch.Active != nil {
+		sub.Active = *patch.Active
+	}
+	sub.UpdatedAt = time.Now()
+	if err := uc.repo.UpdateSubscription(ctx, sub); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrSubscriptionNotFound
+		}
+		return nil, err
+	}
+	return sub, nil
+}
+
+func (uc *WebhookUsecase) DeleteSubscription(ctx context.Context, id string) error {
+	if err := uc.repo.DeleteSubscription(ctx, id); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return ErrSubscriptionNotFound
+		}
+		return err
+	}
+	uc.logger.Info().Str("subscription_id", id).Msg("Webhook subscription deleted")
+	return nil
+}
+
+func (uc *WebhookUsecase) ListSubscriptionDeliveries(ctx context.Context, id string) ([]*domain.WebhookDelivery, error) {
+	if _, err := uc.getSubscription(ctx, id); err != nil {
+		return nil, err
+	}
+	return uc.repo.ListSubscriptionDeliveries(ctx, id, deliveriesLimit)
+}
+
+// PingSubscription sends a test delivery to the subscription and returns it
+// with the attempt's outcome.
+func (uc *WebhookUsecase) PingSubscription(ctx context.Context, id string) (*domain.WebhookDelivery, error) {
+	if _, err := uc.getSubscription(ctx, id); err != nil {
+		return nil, err
+	}
+	return uc.pinger.Ping(ctx, id)
+}
+
+func (uc *WebhookUsecase) getSubscription(ctx context.Context, id string) (*domain.WebhookSubscription, error) {
+	sub, err := uc.repo.GetSubscription(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, ErrSubscriptionNotFound
+		}
+		return nil, err
+	}
+	return sub, nil
+}
+
+func parseURL(raw string) (string, error) {
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", ErrInvalidURL
+	}
+	return u.String(), nil
+}
+
+func validateEventTypes(types []domain.DomainEventType) error {
+	for _, t := range types {
+		if !t.Valid() {
+			return ErrInvalidEventType
+		}
+	}
+	return nil
+}
+
+func newSecret() (string, error) {
+	secret := make([]byte, secretBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(secret), nil
+}
@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE events ADD COLUMN max_per_booking INT NOT NULL DEFAULT 0;
+ALTER TABLE events ADD CONSTRAINT events_max_per_booking_check CHECK (max_per_booking >= 0);
+ALTER TABLE events ADD CONSTRAINT events_available_check CHECK (available >= 0 AND available <= total_seats);
+
+ALTER TABLE bookings ADD COLUMN quantity INT NOT NULL DEFAULT 1;
+ALTER TABLE bookings ADD CONSTRAINT bookings_quantity_check CHECK (quantity > 0);
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE bookings DROP CONSTRAINT IF EXISTS bookings_quantity_check;
+ALTER TABLE bookings DROP COLUMN IF EXISTS quantity;
+
+ALTER TABLE events DROP CONSTRAINT IF EXISTS events_available_check;
+ALTER TABLE events DROP CONSTRAINT IF EXISTS events_max_per_booking_check;
+ALTER TABLE events DROP COLUMN IF EXISTS max_per_booking;
+-- +goose StatementEnd
@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE bookings DROP CONSTRAINT bookings_event_user_unique;
+CREATE UNIQUE INDEX bookings_event_user_unique ON bookings(event_id, user_id) WHERE status <> 'cancelled';
+
+CREATE TABLE waitlist_entries (
+    id VARCHAR(36) PRIMARY KEY,
+    event_id VARCHAR(36) NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    user_id VARCHAR(36) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    quantity INT NOT NULL DEFAULT 1,
+    status VARCHAR(20) NOT NULL DEFAULT 'waiting',
+    booking_id VARCHAR(36) REFERENCES bookings(id) ON DELETE SET NULL,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    promoted_at timestamptz,
+    CONSTRAINT waitlist_entries_status_check CHECK (status IN ('waiting', 'promoted', 'cancelled')),
+    CONSTRAINT waitlist_entries_quantity_check CHECK (quantity > 0)
+);
+CREATE UNIQUE INDEX waitlist_entries_event_user_waiting ON waitlist_entries(event_id, user_id) WHERE status = 'waiting';
+CREATE INDEX idx_waitlist_entries_queue ON waitlist_entries(event_id, created_at) WHERE status = 'waiting';
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS waitlist_entries;
+
+DROP INDEX IF EXISTS bookings_event_user_unique;
+ALTER TABLE bookings ADD CONSTRAINT bookings_event_user_unique UNIQUE (event_id, user_id);
+-- +goose StatementEnd
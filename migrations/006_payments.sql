@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE events ADD COLUMN price BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE events ADD COLUMN currency VARCHAR(3) NOT NULL DEFAULT 'RUB';
+ALTER TABLE events ADD CONSTRAINT events_price_check CHECK (price >= 0);
+
+CREATE TABLE payments (
+    id VARCHAR(36) PRIMARY KEY,
+    booking_id VARCHAR(36) NOT NULL REFERENCES bookings(id) ON DELETE CASCADE,
+    provider VARCHAR(50) NOT NULL,
+    intent_id TEXT NOT NULL,
+    amount BIGINT NOT NULL,
+    currency VARCHAR(3) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    checkout_url TEXT NOT NULL DEFAULT '',
+    created_at timestamptz NOT NULL DEFAULT now(),
+    updated_at timestamptz NOT NULL DEFAULT now(),
+    CONSTRAINT payments_status_check CHECK (status IN ('pending', 'succeeded', 'failed', 'cancelled')),
+    CONSTRAINT payments_amount_check CHECK (amount > 0)
+);
+CREATE UNIQUE INDEX payments_provider_intent_unique ON payments(provider, intent_id);
+CREATE INDEX idx_payments_booking_id ON payments(booking_id);
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS payments;
+
+ALTER TABLE events DROP CONSTRAINT IF EXISTS events_price_check;
+ALTER TABLE events DROP COLUMN IF EXISTS currency;
+ALTER TABLE events DROP COLUMN IF EXISTS price;
+-- +goose StatementEnd
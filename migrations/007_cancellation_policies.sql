@@ -0,0 +1,34 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Durations are stored in nanoseconds; the default keeps the previous fixed
+-- 24 hour cutoff for existing events.
+ALTER TABLE events ADD COLUMN cancellation_policy JSONB NOT NULL
+    DEFAULT '{"FreeWindow": 86400000000000, "Tiers": null, "Cutoff": 86400000000000}';
+
+CREATE TABLE refunds (
+    id VARCHAR(36) PRIMARY KEY,
+    payment_id VARCHAR(36) NOT NULL REFERENCES payments(id) ON DELETE CASCADE,
+    booking_id VARCHAR(36) NOT NULL REFERENCES bookings(id) ON DELETE CASCADE,
+    amount BIGINT NOT NULL,
+    currency VARCHAR(3) NOT NULL,
+    percent INT NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    provider_refund_id TEXT NOT NULL DEFAULT '',
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT '',
+    created_at timestamptz NOT NULL DEFAULT now(),
+    updated_at timestamptz NOT NULL DEFAULT now(),
+    CONSTRAINT refunds_status_check CHECK (status IN ('pending', 'succeeded', 'failed')),
+    CONSTRAINT refunds_amount_check CHECK (amount > 0),
+    CONSTRAINT refunds_percent_check CHECK (percent BETWEEN 1 AND 100)
+);
+CREATE UNIQUE INDEX refunds_booking_unique ON refunds(booking_id);
+CREATE INDEX idx_refunds_pending ON refunds(created_at) WHERE status = 'pending';
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS refunds;
+
+ALTER TABLE events DROP COLUMN IF EXISTS cancellation_policy;
+-- +goose StatementEnd
@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Keyset pagination orders by (sort column, id); these indexes back each sort
+-- and the common filter + sort combinations.
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX idx_events_date_id ON events(date, id);
+CREATE INDEX idx_events_created_at_id ON events(created_at, id);
+CREATE INDEX idx_events_name_id ON events(name, id);
+CREATE INDEX idx_events_status_date_id ON events(status, date, id);
+CREATE INDEX idx_events_name_trgm ON events USING gin (name gin_trgm_ops);
+
+CREATE INDEX idx_bookings_created_at_id ON bookings(created_at, id);
+CREATE INDEX idx_bookings_event_created ON bookings(event_id, created_at, id);
+CREATE INDEX idx_bookings_user_created ON bookings(user_id, created_at, id);
+CREATE INDEX idx_bookings_status_created ON bookings(status, created_at, id);
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_bookings_status_created;
+DROP INDEX IF EXISTS idx_bookings_user_created;
+DROP INDEX IF EXISTS idx_bookings_event_created;
+DROP INDEX IF EXISTS idx_bookings_created_at_id;
+
+DROP INDEX IF EXISTS idx_events_name_trgm;
+DROP INDEX IF EXISTS idx_events_status_date_id;
+DROP INDEX IF EXISTS idx_events_name_id;
+DROP INDEX IF EXISTS idx_events_created_at_id;
+DROP INDEX IF EXISTS idx_events_date_id;
+-- +goose StatementEnd
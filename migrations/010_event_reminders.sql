@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+-- One row per reminder sent for a booking; the primary key guarantees that each
+-- reminder is enqueued once even with several scheduler replicas.
+CREATE TABLE event_reminders (
+    booking_id VARCHAR(36) NOT NULL REFERENCES bookings(id) ON DELETE CASCADE,
+    lead_seconds BIGINT NOT NULL,
+    sent_at timestamptz NOT NULL DEFAULT now(),
+    PRIMARY KEY (booking_id, lead_seconds)
+);
+CREATE INDEX idx_bookings_confirmed_event ON bookings(event_id) WHERE status = 'confirmed';
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_bookings_confirmed_event;
+DROP TABLE IF EXISTS event_reminders;
+-- +goose StatementEnd
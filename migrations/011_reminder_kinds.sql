@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Reminders are no longer only sent ahead of events; kind tells them apart so
+-- that a booking can receive one of each.
+ALTER TABLE event_reminders ADD COLUMN kind VARCHAR(32) NOT NULL DEFAULT 'event_reminder';
+ALTER TABLE event_reminders DROP CONSTRAINT event_reminders_pkey;
+ALTER TABLE event_reminders ADD PRIMARY KEY (booking_id, kind, lead_seconds);
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM event_reminders WHERE kind <> 'event_reminder';
+ALTER TABLE event_reminders DROP CONSTRAINT event_reminders_pkey;
+ALTER TABLE event_reminders ADD PRIMARY KEY (booking_id, lead_seconds);
+ALTER TABLE event_reminders DROP COLUMN kind;
+-- +goose StatementEnd
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Users without a row receive every notification on every channel.
+CREATE TABLE notification_preferences (
+    user_id VARCHAR(36) PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    email_enabled BOOLEAN NOT NULL DEFAULT true,
+    telegram_enabled BOOLEAN NOT NULL DEFAULT true,
+    reminders_enabled BOOLEAN NOT NULL DEFAULT true,
+    updated_at timestamptz NOT NULL DEFAULT now()
+);
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS notification_preferences;
+-- +goose StatementEnd
@@ -0,0 +1,36 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE notification_preferences ADD COLUMN webhook_enabled BOOLEAN NOT NULL DEFAULT true;
+
+CREATE TABLE webhook_endpoints (
+    id VARCHAR(36) PRIMARY KEY,
+    user_id VARCHAR(36) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    url TEXT NOT NULL,
+    secret TEXT NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT now()
+);
+CREATE INDEX idx_webhook_endpoints_user ON webhook_endpoints(user_id);
+
+CREATE TABLE webhook_deliveries (
+    id VARCHAR(36) PRIMARY KEY,
+    endpoint_id VARCHAR(36) NOT NULL REFERENCES webhook_endpoints(id) ON DELETE CASCADE,
+    kind VARCHAR(32) NOT NULL,
+    payload JSONB NOT NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'pending',
+    attempts INT NOT NULL DEFAULT 0,
+    next_attempt_at timestamptz NOT NULL DEFAULT now(),
+    response_status INT,
+    last_error TEXT,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    updated_at timestamptz NOT NULL DEFAULT now(),
+    delivered_at timestamptz
+);
+CREATE INDEX idx_webhook_deliveries_due ON webhook_deliveries(next_attempt_at) WHERE status = 'pending';
+CREATE INDEX idx_webhook_deliveries_endpoint ON webhook_deliveries(endpoint_id, created_at DESC);
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS webhook_deliveries;
+DROP TABLE IF EXISTS webhook_endpoints;
+ALTER TABLE notification_preferences DROP COLUMN IF EXISTS webhook_enabled;
+-- +goose StatementEnd
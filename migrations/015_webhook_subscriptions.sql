@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE webhook_subscriptions (
+    id VARCHAR(36) PRIMARY KEY,
+    url TEXT NOT NULL,
+    secret TEXT NOT NULL,
+    event_types TEXT[] NOT NULL DEFAULT '{}',
+    active BOOLEAN NOT NULL DEFAULT true,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    updated_at timestamptz NOT NULL DEFAULT now()
+);
+
+ALTER TABLE webhook_deliveries ALTER COLUMN endpoint_id DROP NOT NULL;
+ALTER TABLE webhook_deliveries
+    ADD COLUMN subscription_id VARCHAR(36) REFERENCES webhook_subscriptions(id) ON DELETE CASCADE;
+ALTER TABLE webhook_deliveries
+    ADD CONSTRAINT webhook_deliveries_target CHECK ((endpoint_id IS NULL) <> (subscription_id IS NULL));
+CREATE INDEX idx_webhook_deliveries_subscription ON webhook_deliveries(subscription_id, created_at DESC);
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM webhook_deliveries WHERE subscription_id IS NOT NULL;
+DROP INDEX IF EXISTS idx_webhook_deliveries_subscription;
+ALTER TABLE webhook_deliveries DROP CONSTRAINT IF EXISTS webhook_deliveries_target;
+ALTER TABLE webhook_deliveries DROP COLUMN IF EXISTS subscription_id;
+ALTER TABLE webhook_deliveries ALTER COLUMN endpoint_id SET NOT NULL;
+DROP TABLE IF EXISTS webhook_subscriptions;
+-- +goose StatementEnd
@@ -0,0 +1,42 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Publishes availability and status changes of events on the event_changes
+-- channel. NOTIFY is delivered when the transaction commits, so listeners on
+-- every replica only ever see committed state.
+CREATE OR REPLACE FUNCTION notify_event_change() RETURNS trigger AS $$
+DECLARE
+    rec events%ROWTYPE;
+    new_status TEXT;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        rec := OLD;
+        new_status := 'deleted';
+    ELSE
+        rec := NEW;
+        new_status := NEW.status;
+        IF TG_OP = 'UPDATE'
+            AND OLD.available = NEW.available
+            AND OLD.total_seats = NEW.total_seats
+            AND OLD.status = NEW.status THEN
+            RETURN NEW;
+        END IF;
+    END IF;
+    PERFORM pg_notify('event_changes', json_build_object(
+        'event_id', rec.id,
+        'available', rec.available,
+        'total_seats', rec.total_seats,
+        'status', new_status
+    )::text);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER events_notify_change
+AFTER INSERT OR UPDATE OR DELETE ON events
+FOR EACH ROW EXECUTE FUNCTION notify_event_change();
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER IF EXISTS events_notify_change ON events;
+DROP FUNCTION IF EXISTS notify_event_change();
+-- +goose StatementEnd
@@ -0,0 +1,37 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE seat_layouts (
+    id VARCHAR(36) PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE TABLE layout_seats (
+    id VARCHAR(36) PRIMARY KEY,
+    layout_id VARCHAR(36) NOT NULL REFERENCES seat_layouts(id) ON DELETE CASCADE,
+    section VARCHAR(64) NOT NULL,
+    row_label VARCHAR(16) NOT NULL,
+    number INT NOT NULL CHECK (number > 0),
+    accessible BOOLEAN NOT NULL DEFAULT false,
+    UNIQUE (layout_id, section, row_label, number)
+);
+
+ALTER TABLE events ADD COLUMN layout_id VARCHAR(36) REFERENCES seat_layouts(id);
+
+-- Per-event inventory of a reserved-seating event; a seat is free while
+-- booking_id is NULL.
+CREATE TABLE event_seats (
+    event_id VARCHAR(36) NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    seat_id VARCHAR(36) NOT NULL REFERENCES layout_seats(id),
+    booking_id VARCHAR(36) REFERENCES bookings(id) ON DELETE SET NULL,
+    PRIMARY KEY (event_id, seat_id)
+);
+CREATE INDEX idx_event_seats_booking ON event_seats(booking_id) WHERE booking_id IS NOT NULL;
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS event_seats;
+ALTER TABLE events DROP COLUMN IF EXISTS layout_id;
+DROP TABLE IF EXISTS layout_seats;
+DROP TABLE IF EXISTS seat_layouts;
+-- +goose StatementEnd
@@ -0,0 +1,33 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE ticket_tiers (
+    id VARCHAR(36) PRIMARY KEY,
+    event_id VARCHAR(36) NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    price BIGINT NOT NULL DEFAULT 0,
+    currency VARCHAR(3) NOT NULL,
+    quota INT NOT NULL,
+    available INT NOT NULL,
+    sales_start timestamptz,
+    sales_end timestamptz,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    updated_at timestamptz NOT NULL DEFAULT now(),
+    CONSTRAINT ticket_tiers_event_name_unique UNIQUE (event_id, name),
+    CONSTRAINT ticket_tiers_price_check CHECK (price >= 0),
+    CONSTRAINT ticket_tiers_quota_check CHECK (quota > 0),
+    CONSTRAINT ticket_tiers_available_check CHECK (available >= 0 AND available <= quota),
+    CONSTRAINT ticket_tiers_sales_window_check CHECK (sales_start IS NULL OR sales_end IS NULL OR sales_start < sales_end)
+);
+
+ALTER TABLE bookings ADD COLUMN tier_id VARCHAR(36) REFERENCES ticket_tiers(id);
+CREATE INDEX idx_bookings_tier_id ON bookings(tier_id) WHERE tier_id IS NOT NULL;
+
+ALTER TABLE waitlist_entries ADD COLUMN tier_id VARCHAR(36) REFERENCES ticket_tiers(id);
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE waitlist_entries DROP COLUMN IF EXISTS tier_id;
+DROP INDEX IF EXISTS idx_bookings_tier_id;
+ALTER TABLE bookings DROP COLUMN IF EXISTS tier_id;
+DROP TABLE IF EXISTS ticket_tiers;
+-- +goose StatementEnd
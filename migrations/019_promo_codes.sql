@@ -0,0 +1,39 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE promo_codes (
+    id VARCHAR(36) PRIMARY KEY,
+    code VARCHAR(64) NOT NULL,
+    event_id VARCHAR(36) REFERENCES events(id) ON DELETE CASCADE,
+    kind VARCHAR(10) NOT NULL,
+    value BIGINT NOT NULL,
+    currency VARCHAR(3) NOT NULL DEFAULT '',
+    max_uses INT NOT NULL DEFAULT 0,
+    max_uses_per_user INT NOT NULL DEFAULT 0,
+    uses INT NOT NULL DEFAULT 0,
+    valid_from timestamptz,
+    valid_until timestamptz,
+    active BOOLEAN NOT NULL DEFAULT true,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    updated_at timestamptz NOT NULL DEFAULT now(),
+    CONSTRAINT promo_codes_code_unique UNIQUE (code),
+    CONSTRAINT promo_codes_kind_check CHECK (kind IN ('percent', 'fixed')),
+    CONSTRAINT promo_codes_value_check CHECK (value > 0 AND (kind <> 'percent' OR value <= 100)),
+    CONSTRAINT promo_codes_limits_check CHECK (max_uses >= 0 AND max_uses_per_user >= 0 AND uses >= 0),
+    CONSTRAINT promo_codes_validity_check CHECK (valid_from IS NULL OR valid_until IS NULL OR valid_from < valid_until)
+);
+
+-- uses counts the pending and confirmed bookings holding the code; it is
+-- decremented when such a booking is cancelled or expires.
+ALTER TABLE bookings ADD COLUMN promo_code_id VARCHAR(36) REFERENCES promo_codes(id);
+ALTER TABLE bookings ADD COLUMN discount BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE bookings ADD CONSTRAINT bookings_discount_check CHECK (discount >= 0);
+CREATE INDEX idx_bookings_promo_user ON bookings(promo_code_id, user_id) WHERE promo_code_id IS NOT NULL;
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_bookings_promo_user;
+ALTER TABLE bookings DROP CONSTRAINT IF EXISTS bookings_discount_check;
+ALTER TABLE bookings DROP COLUMN IF EXISTS discount;
+ALTER TABLE bookings DROP COLUMN IF EXISTS promo_code_id;
+DROP TABLE IF EXISTS promo_codes;
+-- +goose StatementEnd
@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE event_series (
+    id VARCHAR(36) PRIMARY KEY,
+    name TEXT NOT NULL,
+    recurrence TEXT NOT NULL,
+    starts_at timestamptz NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    updated_at timestamptz NOT NULL DEFAULT now()
+);
+
+ALTER TABLE events ADD COLUMN series_id VARCHAR(36) REFERENCES event_series(id) ON DELETE SET NULL;
+CREATE INDEX idx_events_series_date ON events(series_id, date) WHERE series_id IS NOT NULL;
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_events_series_date;
+ALTER TABLE events DROP COLUMN IF EXISTS series_id;
+DROP TABLE IF EXISTS event_series;
+-- +goose StatementEnd
@@ -0,0 +1,44 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE venues (
+    id VARCHAR(36) PRIMARY KEY,
+    name TEXT NOT NULL,
+    address TEXT NOT NULL,
+    timezone TEXT NOT NULL,
+    capacity INT NOT NULL,
+    latitude DOUBLE PRECISION,
+    longitude DOUBLE PRECISION,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    updated_at timestamptz NOT NULL DEFAULT now(),
+    CONSTRAINT venues_capacity_check CHECK (capacity > 0),
+    CONSTRAINT venues_geo_check CHECK (
+        (latitude IS NULL AND longitude IS NULL) OR
+        (latitude BETWEEN -90 AND 90 AND longitude BETWEEN -180 AND 180)
+    )
+);
+
+CREATE TABLE organizers (
+    id VARCHAR(36) PRIMARY KEY,
+    name TEXT NOT NULL,
+    email TEXT NOT NULL DEFAULT '',
+    website TEXT NOT NULL DEFAULT '',
+    created_at timestamptz NOT NULL DEFAULT now(),
+    updated_at timestamptz NOT NULL DEFAULT now(),
+    CONSTRAINT organizers_name_unique UNIQUE (name)
+);
+
+-- Venues and organizers cannot be deleted while events still reference them.
+ALTER TABLE events ADD COLUMN venue_id VARCHAR(36) REFERENCES venues(id);
+ALTER TABLE events ADD COLUMN organizer_id VARCHAR(36) REFERENCES organizers(id);
+CREATE INDEX idx_events_venue ON events(venue_id) WHERE venue_id IS NOT NULL;
+CREATE INDEX idx_events_organizer_date ON events(organizer_id, date) WHERE organizer_id IS NOT NULL;
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_events_organizer_date;
+DROP INDEX IF EXISTS idx_events_venue;
+ALTER TABLE events DROP COLUMN IF EXISTS organizer_id;
+ALTER TABLE events DROP COLUMN IF EXISTS venue_id;
+DROP TABLE IF EXISTS organizers;
+DROP TABLE IF EXISTS venues;
+-- +goose StatementEnd
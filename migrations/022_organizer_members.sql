@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE organizer_members (
+    organizer_id VARCHAR(36) NOT NULL REFERENCES organizers(id) ON DELETE CASCADE,
+    user_id VARCHAR(36) NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role VARCHAR(20) NOT NULL,
+    created_at timestamptz NOT NULL DEFAULT now(),
+    PRIMARY KEY (organizer_id, user_id),
+    CONSTRAINT organizer_members_role_check CHECK (role IN ('owner', 'manager', 'checkin'))
+);
+
+CREATE INDEX idx_organizer_members_user ON organizer_members(user_id);
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS organizer_members;
+-- +goose StatementEnd
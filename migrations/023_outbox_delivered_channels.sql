@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Channels a message has already been delivered over, so that a retry after a
+-- partial failure only resends to the channels that failed.
+ALTER TABLE outbox ADD COLUMN delivered_channels TEXT[] NOT NULL DEFAULT '{}';
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE outbox DROP COLUMN IF EXISTS delivered_channels;
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+-- A payment is authorized once the provider reports it succeeded and its
+-- booking is confirmed; it becomes succeeded when the capture goes through.
+ALTER TABLE payments DROP CONSTRAINT payments_status_check;
+ALTER TABLE payments ADD CONSTRAINT payments_status_check
+    CHECK (status IN ('pending', 'authorized', 'succeeded', 'failed', 'cancelled'));
+CREATE INDEX idx_payments_authorized ON payments(updated_at) WHERE status = 'authorized';
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_payments_authorized;
+UPDATE payments SET status = 'succeeded' WHERE status = 'authorized';
+ALTER TABLE payments DROP CONSTRAINT payments_status_check;
+ALTER TABLE payments ADD CONSTRAINT payments_status_check
+    CHECK (status IN ('pending', 'succeeded', 'failed', 'cancelled'));
+-- +goose StatementEnd
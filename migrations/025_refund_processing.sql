@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+-- A refund is processing while it is being submitted to the provider. The
+-- claim is committed before the call, so a run that dies mid-call leaves the
+-- refund processing until its claim goes stale and another run resubmits it.
+ALTER TABLE refunds DROP CONSTRAINT refunds_status_check;
+ALTER TABLE refunds ADD CONSTRAINT refunds_status_check
+    CHECK (status IN ('pending', 'processing', 'succeeded', 'failed'));
+CREATE INDEX idx_refunds_processing ON refunds(updated_at) WHERE status = 'processing';
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_refunds_processing;
+UPDATE refunds SET status = 'pending' WHERE status = 'processing';
+ALTER TABLE refunds DROP CONSTRAINT refunds_status_check;
+ALTER TABLE refunds ADD CONSTRAINT refunds_status_check
+    CHECK (status IN ('pending', 'succeeded', 'failed'));
+-- +goose StatementEnd
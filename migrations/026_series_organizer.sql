@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+-- A series belongs to the organizer its occurrences were created for, so bulk
+-- edits can be authorized once before any occurrence is locked.
+ALTER TABLE event_series ADD COLUMN organizer_id VARCHAR(36) REFERENCES organizers(id);
+UPDATE event_series s SET organizer_id = (
+    SELECT e.organizer_id FROM events e
+    WHERE e.series_id = s.id
+    ORDER BY e.date, e.id
+    LIMIT 1
+);
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE event_series DROP COLUMN IF EXISTS organizer_id;
+-- +goose StatementEnd
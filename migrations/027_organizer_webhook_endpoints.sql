@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Webhook endpoints belong either to a user, who receives their own
+-- notifications, or to an organizer, which receives the domain events of its
+-- events.
+ALTER TABLE webhook_endpoints ALTER COLUMN user_id DROP NOT NULL;
+ALTER TABLE webhook_endpoints ADD COLUMN organizer_id VARCHAR(36) REFERENCES organizers(id) ON DELETE CASCADE;
+ALTER TABLE webhook_endpoints ADD CONSTRAINT webhook_endpoints_owner_check
+    CHECK ((user_id IS NULL) <> (organizer_id IS NULL));
+CREATE INDEX idx_webhook_endpoints_organizer ON webhook_endpoints(organizer_id) WHERE organizer_id IS NOT NULL;
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_webhook_endpoints_organizer;
+DELETE FROM webhook_endpoints WHERE organizer_id IS NOT NULL;
+ALTER TABLE webhook_endpoints DROP CONSTRAINT IF EXISTS webhook_endpoints_owner_check;
+ALTER TABLE webhook_endpoints DROP COLUMN IF EXISTS organizer_id;
+ALTER TABLE webhook_endpoints ALTER COLUMN user_id SET NOT NULL;
+-- +goose StatementEnd
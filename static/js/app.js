@@ -2,6 +2,7 @@ class EventBooker {
     constructor() {
         this.baseUrl = '/api';
         this.currentUser = null;
+        this.token = localStorage.getItem('eventbooker_token');
         this.events = [];
         this.bookings = [];
         this.currentPage = this.detectPage();
@@ -17,6 +18,7 @@ class EventBooker {
         this.setupEventListeners();
         this.loadEvents();
         this.startAutoRefresh();
+        this.subscribeToEventStream();
         this.updateUI();
     }
     async loadCurrentUser() {
@@ -42,13 +44,62 @@ class EventBooker {
                 `;
             }
            
-            const bookUserId = document.getElementById('book-user-id');
-            if (bookUserId) {
-                bookUserId.value = this.currentUser.id;
-            }
+        }
+    }
+    async apiFetch(url, options = {}) {
+        const headers = Object.assign({}, options.headers);
+        if (this.token) {
+            headers['Authorization'] = `Bearer ${this.token}`;
+        }
+        const response = await fetch(url, Object.assign({}, options, { headers }));
+        if (response.status === 401 && this.token) {
+            this.token = null;
+            localStorage.removeItem('eventbooker_token');
+        }
+        return response;
+    }
+    async login(email, password) {
+        const response = await fetch(`${this.baseUrl}/auth/login`, {
+            method: 'POST',
+            headers: {'Content-Type': 'application/json'},
+            body: JSON.stringify({ email, password })
+        });
+        if (!response.ok) {
+            const error = await response.text();
+            throw new Error(error);
+        }
+        const session = await response.json();
+        this.token = session.token;
+        this.currentUser = { id: session.user.ID, email: session.user.Email };
+        localStorage.setItem('eventbooker_token', session.token);
+        localStorage.setItem('eventbooker_user_id', session.user.ID);
+        localStorage.setItem('eventbooker_user_email', session.user.Email);
+        this.updateUserUI();
+        return session.user;
+    }
+    async handleLogin(e) {
+        e.preventDefault();
+        try {
+            const user = await this.login(
+                document.getElementById('login-email').value,
+                document.getElementById('login-password').value
+            );
+            this.showToast(`Вы вошли как ${user.Email}`, 'success', 'Вход');
+            const modal = bootstrap.Modal.getInstance(document.getElementById('loginModal'));
+            if (modal) modal.hide();
+            e.target.reset();
+            this.loadBookings();
+        } catch (error) {
+            console.error('Login error:', error);
+            this.showToast(error.message || 'Ошибка входа', 'danger', 'Ошибка');
         }
     }
     setupEventListeners() {
+        // Вход
+        const loginForm = document.getElementById('login-form');
+        if (loginForm) {
+            loginForm.addEventListener('submit', (e) => this.handleLogin(e));
+        }
         // Регистрация пользователя
         const registerForm = document.getElementById('register-form');
         if (registerForm) {
@@ -88,7 +139,7 @@ class EventBooker {
     async loadEvents() {
         try {
             this.showLoader();
-            const response = await fetch(`${this.baseUrl}/events`);
+            const response = await this.apiFetch(`${this.baseUrl}/events`);
             if (!response.ok) throw new Error('Ошибка загрузки мероприятий');
            
             this.events = await response.json();
@@ -104,10 +155,13 @@ class EventBooker {
         }
     }
     async loadBookings() {
-        if (this.currentPage !== 'admin') return;
+        if (this.currentPage !== 'admin') {
+            this.loadMyBookings();
+            return;
+        }
        
         try {
-            const response = await fetch(`${this.baseUrl}/bookings`);
+            const response = await this.apiFetch(`${this.baseUrl}/bookings`);
             if (!response.ok) throw new Error('Ошибка загрузки бронирований');
             this.bookings = await response.json();
             this.renderBookings();
@@ -117,6 +171,35 @@ class EventBooker {
             this.showToast('Ошибка загрузки бронирований', 'danger');
         }
     }
+    async loadMyBookings() {
+        const container = document.getElementById('my-bookings');
+        if (!container || !this.currentUser || !this.token) return;
+        try {
+            const response = await this.apiFetch(`${this.baseUrl}/users/${this.currentUser.id}/bookings?scope=upcoming`);
+            if (!response.ok) throw new Error('Ошибка загрузки бронирований');
+            const bookings = await response.json();
+            if (bookings.length === 0) {
+                container.innerHTML = '';
+                return;
+            }
+            container.innerHTML = `
+                <h5><i class="bi bi-ticket-perforated me-2"></i>Мои бронирования</h5>
+                <ul class="list-group">
+                    ${bookings.map(b => `
+                        <li class="list-group-item d-flex justify-content-between align-items-center">
+                            <span>
+                                <strong>${this.escapeHtml(b.EventName)}</strong>
+                                <small class="text-muted ms-2">${this.formatDate(b.EventDate)}</small>
+                            </span>
+                            <span class="badge bg-secondary">${b.Quantity} × ${b.Status}</span>
+                        </li>
+                    `).join('')}
+                </ul>
+            `;
+        } catch (error) {
+            console.error('Error loading my bookings:', error);
+        }
+    }
     renderEvents() {
         const container = document.getElementById('events-container');
         const tableBody = document.getElementById('events-table-body');
@@ -356,11 +439,14 @@ class EventBooker {
         const formData = {
             email: document.getElementById('email').value,
             telegram: document.getElementById('telegram').value,
-            role: document.querySelector('input[name="role"]:checked')?.value || 'user'
+            password: document.getElementById('password').value,
+            role: document.querySelector('input[name="role"]:checked')?.value || 'user',
+            locale: (navigator.language || '').toLowerCase().startsWith('ru') ? 'ru' : 'en',
+            timezone: Intl.DateTimeFormat().resolvedOptions().timeZone || 'UTC'
         };
        
         try {
-            const response = await fetch(`${this.baseUrl}/users`, {
+            const response = await this.apiFetch(`${this.baseUrl}/users`, {
                 method: 'POST',
                 headers: {'Content-Type': 'application/json'},
                 body: JSON.stringify(formData)
@@ -371,12 +457,7 @@ class EventBooker {
                 throw new Error(error);
             }
            
-            const user = await response.json();
-            this.currentUser = user;
-           
-            // Сохраняем в localStorage
-            localStorage.setItem('eventbooker_user_id', user.ID);
-            localStorage.setItem('eventbooker_user_email', user.Email);
+            const user = await this.login(formData.email, formData.password);
            
             this.showToast(
                 `Регистрация успешна! Ваш ID: ${user.ID}`,
@@ -404,11 +485,13 @@ class EventBooker {
             date: new Date(document.getElementById('event-date').value).toISOString(),
             total_seats: parseInt(document.getElementById('total-seats').value),
             booking_ttl: document.getElementById('booking-ttl').value,
-            requires_payment: document.querySelector('input[name="requires-payment"]:checked').value === 'true'
+            requires_payment: document.querySelector('input[name="requires-payment"]:checked').value === 'true',
+            price: Math.round(parseFloat(document.getElementById('event-price').value || '0') * 100),
+            currency: document.getElementById('event-currency').value
         };
        
         try {
-            const response = await fetch(`${this.baseUrl}/events`, {
+            const response = await this.apiFetch(`${this.baseUrl}/events`, {
                 method: 'POST',
                 headers: {'Content-Type': 'application/json'},
                 body: JSON.stringify(formData)
@@ -444,17 +527,23 @@ class EventBooker {
         e.preventDefault();
        
         const eventId = document.getElementById('book-event-id').value;
-        const userId = document.getElementById('book-user-id').value;
+        const userId = this.currentUser?.id;
+        const quantityInput = document.getElementById('book-quantity');
+        const quantity = quantityInput ? parseInt(quantityInput.value) || 1 : 1;
        
-        if (!eventId || !userId) {
+        if (!userId || !this.token) {
+            this.showToast('Войдите или зарегистрируйтесь, чтобы бронировать места', 'warning', 'Внимание');
+            return;
+        }
+        if (!eventId) {
             this.showToast('Заполните все поля', 'warning', 'Внимание');
             return;
         }
        
-        const bookingData = { user_id: userId };
+        const bookingData = { quantity };
        
         try {
-            const response = await fetch(`${this.baseUrl}/events/${eventId}/book`, {
+            const response = await this.apiFetch(`${this.baseUrl}/events/${eventId}/book`, {
                 method: 'POST',
                 headers: {'Content-Type': 'application/json'},
                 body: JSON.stringify(bookingData)
@@ -472,6 +561,9 @@ class EventBooker {
                 'success',
                 'Бронирование'
             );
+            if (booking.Payment && booking.Payment.CheckoutURL) {
+                window.open(booking.Payment.CheckoutURL, '_blank');
+            }
            
             // Закрыть модальное окно и очистить форму
             const modal = bootstrap.Modal.getInstance(document.getElementById('bookModal'));
@@ -497,7 +589,7 @@ class EventBooker {
         }
        
         try {
-            const response = await fetch(`${this.baseUrl}/bookings/${bookingId}/confirm`, {
+            const response = await this.apiFetch(`${this.baseUrl}/bookings/${bookingId}/confirm`, {
                 method: 'POST',
                 headers: {'Content-Type': 'application/json'}
             });
@@ -534,7 +626,7 @@ class EventBooker {
         }
        
         try {
-            const response = await fetch(`${this.baseUrl}/events/${eventId}`, {
+            const response = await this.apiFetch(`${this.baseUrl}/events/${eventId}`, {
                 method: 'DELETE',
                 headers: {'Content-Type': 'application/json'},
                 body: JSON.stringify({ reason })
@@ -562,7 +654,7 @@ class EventBooker {
     }
     async confirmBooking(bookingId) {
         try {
-            const response = await fetch(`${this.baseUrl}/bookings/${bookingId}/confirm`, {
+            const response = await this.apiFetch(`${this.baseUrl}/bookings/${bookingId}/confirm`, {
                 method: 'POST',
                 headers: {'Content-Type': 'application/json'}
             });
@@ -584,7 +676,7 @@ class EventBooker {
         if (!confirm('Вы уверены, что хотите отменить эту бронь?')) return;
        
         try {
-            const response = await fetch(`${this.baseUrl}/bookings/${bookingId}`, {
+            const response = await this.apiFetch(`${this.baseUrl}/bookings/${bookingId}`, {
                 method: 'DELETE',
                 headers: {'Content-Type': 'application/json'}
             });
@@ -835,6 +927,30 @@ class EventBooker {
             this.showToast('Скопировано в буфер обмена', 'success');
         });
     }
+    subscribeToEventStream() {
+        // Живое обновление свободных мест и статусов через SSE
+        if (!window.EventSource) return;
+        const source = new EventSource(`${this.baseUrl}/events/stream`);
+        source.addEventListener('availability', (e) => {
+            const change = JSON.parse(e.data);
+            const index = this.events.findIndex(event => event.ID === change.event_id);
+            if (index === -1) return;
+            if (change.status === 'deleted') {
+                this.events.splice(index, 1);
+            } else {
+                this.events[index] = {
+                    ...this.events[index],
+                    Available: change.available,
+                    TotalSeats: change.total_seats,
+                    Status: change.status
+                };
+            }
+            this.renderEvents();
+            this.updateStatistics();
+        });
+        // Сервер мог пропустить изменения (переподключение к БД) — перезагружаем список
+        source.addEventListener('resync', () => this.loadEvents());
+    }
     startAutoRefresh() {
         // Обновление каждые 30 секунд для админа, 60 для пользователя
         const interval = this.currentPage === 'admin' ? 30000 : 60000;
@@ -856,7 +972,7 @@ class EventBooker {
     }
     async viewEventDetails(eventId) {
         try {
-            const response = await fetch(`${this.baseUrl}/events/${eventId}`);
+            const response = await this.apiFetch(`${this.baseUrl}/events/${eventId}`);
             if (!response.ok) throw new Error('Ошибка загрузки деталей');
             const event = await response.json();
             // Render in modal